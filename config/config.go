@@ -1,6 +1,9 @@
 package config
 
 import (
+	// Go imports
+	"time"
+
 	// External imports
 	"github.com/spf13/viper"
 )
@@ -14,6 +17,28 @@ type Config struct {
 	DBSSLMode  string `mapstructure:"DB_SSLMODE"`
 	DBDriver   string `mapstructure:"DB_DRIVER"`
 	ServerPort string `mapstructure:"SERVER_PORT"`
+
+	RateProvider        string        `mapstructure:"RATE_PROVIDER"`         // Source of exchange rates: db, file or http
+	RateProviderFile    string        `mapstructure:"RATE_PROVIDER_FILE"`    // Path of the rates file for file provider
+	RateProviderUrl     string        `mapstructure:"RATE_PROVIDER_URL"`     // Base url of the currency api for http provider
+	RateRefreshInterval time.Duration `mapstructure:"RATE_REFRESH_INTERVAL"` // Interval between exchange rate refreshes, e.g. 5m
+	RatePivotCurrency   string        `mapstructure:"RATE_PIVOT_CURRENCY"`   // Currency missing pairs are computed through first, e.g. USD
+	RateStreamInterval  time.Duration `mapstructure:"RATE_STREAM_INTERVAL"`  // Interval between checks for changed rates to stream, e.g. 2s
+	RateStreamOrigins   string        `mapstructure:"RATE_STREAM_ORIGINS"`   // Comma separated origins of other sites allowed to open WebSocket streams, the own host always is
+	OfferTTL            time.Duration `mapstructure:"OFFER_TTL"`             // Time an offer can be accepted in unless its pair has its own TTL, e.g. 3m
+
+	LimitOrderMatchInterval   time.Duration `mapstructure:"LIMIT_ORDER_MATCH_INTERVAL"`   // Interval between limit order matches, e.g. 30s
+	ConversionPlanRunInterval time.Duration `mapstructure:"CONVERSION_PLAN_RUN_INTERVAL"` // Interval between runs of the due conversion plans, e.g. 1m
+
+	SweepInterval  time.Duration `mapstructure:"SWEEP_INTERVAL"`  // Interval between sweeps of expired offers and limit orders, e.g. 1m
+	OfferRetention time.Duration `mapstructure:"OFFER_RETENTION"` // Closed offers unchanged for longer are moved to the offer history, e.g. 720h
+
+	PaymentGateway         string        `mapstructure:"PAYMENT_GATEWAY"`           // Gateway deposits and withdrawals go through: fake
+	PaymentCallbackSecret  string        `mapstructure:"PAYMENT_CALLBACK_SECRET"`   // Secret gateway callbacks are signed with, callbacks are refused without it
+	FakeGatewaySettleDelay time.Duration `mapstructure:"FAKE_GATEWAY_SETTLE_DELAY"` // Time the fake gateway settles payments after, e.g. 5s, never when empty
+
+	OnboardingPolicyFile     string        `mapstructure:"ONBOARDING_POLICY_FILE"`     // Path of the onboarding credit policy, no credit is granted when empty
+	OnboardingExpiryInterval time.Duration `mapstructure:"ONBOARDING_EXPIRY_INTERVAL"` // Interval between claw backs of expired onboarding credit, e.g. 1h
 }
 
 func LoadConfig() (config Config, err error) {
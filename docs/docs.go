@@ -1,6 +1,6 @@
 // Package docs GENERATED BY SWAG; DO NOT EDIT
 // This file was generated by swaggo/swag at
-// 2022-12-07 01:45:04.644527 +0300 +03 m=+1.313400277
+// 2026-10-18 09:43:48.27552999 +0000 UTC m=+22.556145147
 package docs
 
 import "github.com/swaggo/swag"
@@ -17,9 +17,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/account/list": {
-            "get": {
-                "description": "List user's balances with currencies",
+        "/account/close": {
+            "post": {
+                "description": "Close an account of the user, only an account without balance and holds which is not frozen can be closed",
                 "consumes": [
                     "application/json"
                 ],
@@ -29,7 +29,7 @@ const docTemplate = `{
                 "tags": [
                     "Account"
                 ],
-                "summary": "List User Accounts",
+                "summary": "Close Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -37,11 +37,26 @@ const docTemplate = `{
                         "name": "X-Auth-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/account.AccountRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
@@ -50,19 +65,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/account.WalletAccount"
-                                            }
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -79,8 +91,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "allOf": [
                                 {
@@ -97,8 +109,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "allOf": [
                                 {
@@ -136,9 +148,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/exchange/accept/offer": {
+        "/account/deposit": {
             "post": {
-                "description": "Accept the given exchange rate",
+                "description": "Ask the payment gateway to collect an amount, the account is credited once the gateway settles the deposit",
                 "consumes": [
                     "application/json"
                 ],
@@ -146,9 +158,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exchange"
+                    "Account"
                 ],
-                "summary": "Accept exchange rate offer",
+                "summary": "Deposit Money",
                 "parameters": [
                     {
                         "type": "string",
@@ -163,9 +175,125 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/exchange.AcceptOfferRequest"
+                            "$ref": "#/definitions/payment.PaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/payment.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
+                }
+            }
+        },
+        "/account/list": {
+            "get": {
+                "description": "List user's balances with currencies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "List User Accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -264,9 +392,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/exchange/rate": {
+        "/account/open": {
             "post": {
-                "description": "Get exchange rate on given currencies",
+                "description": "Open an empty account in a supported currency, a closed account is opened again",
                 "consumes": [
                     "application/json"
                 ],
@@ -274,9 +402,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exchange"
+                    "Account"
                 ],
-                "summary": "Get Exchange Rate",
+                "summary": "Open Account",
                 "parameters": [
                     {
                         "type": "string",
@@ -291,7 +419,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/exchange.OfferRequest"
+                            "$ref": "#/definitions/account.AccountRequest"
                         }
                     }
                 ],
@@ -307,7 +435,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/exchange.OfferResponse"
+                                            "$ref": "#/definitions/account.WalletAccount"
                                         }
                                     }
                                 }
@@ -350,8 +478,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "allOf": [
                                 {
@@ -389,9 +517,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/login": {
-            "post": {
-                "description": "User Login",
+        "/account/payments": {
+            "get": {
+                "description": "List the deposits and withdrawals of the user newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -399,18 +527,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Account"
                 ],
-                "summary": "Auth User",
+                "summary": "List Payments",
                 "parameters": [
                     {
-                        "description": "body params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, SETTLED or FAILED, every status by default",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DEPOSIT or WITHDRAWAL, both by default",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -425,7 +575,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user.LoginResponse"
+                                            "$ref": "#/definitions/payment.PaymentListResponse"
                                         }
                                     }
                                 }
@@ -450,8 +600,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -489,9 +639,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/register": {
-            "post": {
-                "description": "Create a user",
+        "/account/payments/{id}": {
+            "get": {
+                "description": "Get a deposit or withdrawal of the user with its current status",
                 "consumes": [
                     "application/json"
                 ],
@@ -499,18 +649,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Account"
                 ],
-                "summary": "Create User",
+                "summary": "Get Payment",
                 "parameters": [
                     {
-                        "description": "body params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the payment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -525,7 +680,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user.RegisterResponse"
+                                            "$ref": "#/definitions/payment.PaymentResponse"
                                         }
                                     }
                                 }
@@ -550,6 +705,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -588,45 +761,5881 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "account.WalletAccount": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "type": "number"
-                },
-                "currency_code": {
-                    "type": "string"
-                }
-            }
         },
-        "exchange.AcceptOfferRequest": {
-            "type": "object",
-            "required": [
-                "amount",
-                "offer_id"
+        "/account/statement": {
+            "get": {
+                "description": "Download the monthly statement of an account with its opening balance, every movement and its closing balance,\nconversions show the offer they were made with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get Account Statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency of the account",
+                        "name": "currencyCode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or pdf, csv by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Month of the statement as YYYY-MM, the current month by default",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statement file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/account/summary": {
+            "get": {
+                "description": "Value every balance of the user at the current mid rate in the default currency of the user or in the requested currency,\nwith the net worth and the share of each currency in it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get Account Summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to value the balances in, default currency of the user when empty",
+                        "name": "currencyCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/account.AccountSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/account/transactions": {
+            "get": {
+                "description": "List the movements on user's accounts newest first, each conversion links back to its offer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "List User Transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions on given currency",
+                        "name": "currencyCode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive end of the date range in RFC3339",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive start of the date range in RFC3339",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "REGISTRATION_BONUS, BONUS_CLAWBACK, CONVERSION_DEBIT, CONVERSION_CREDIT, TRANSFER_OUT, TRANSFER_IN, DEPOSIT, WITHDRAWAL or OPENING_BALANCE",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/account.TransactionListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/account/transfer": {
+            "post": {
+                "description": "Send an amount to another user found by username or email, optionally converted into the default currency of the recipient",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Transfer Money",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transfer.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/transfer.TransferResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/account/transfers": {
+            "get": {
+                "description": "List the transfers the user sent or received newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "List Transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "SENT or RECEIVED, both by default",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/transfer.TransferListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/account/transfers/{id}": {
+            "get": {
+                "description": "Get a transfer the user sent or received",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get Transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the transfer",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/transfer.TransferResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/account/withdraw": {
+            "post": {
+                "description": "Ask the payment gateway to pay an amount out, the amount is held on the account until the withdrawal is settled or fails",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Withdraw Money",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payment.PaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/payment.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/account/{user_id}/{currency}/freeze": {
+            "post": {
+                "description": "Reject the debits and credits of an account of a user until it is unfrozen, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Freeze Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of an admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the user",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code of the account",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/account.WalletAccount"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/account/{user_id}/{currency}/unfreeze": {
+            "post": {
+                "description": "Accept the debits and credits of a frozen account again, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Unfreeze Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of an admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the user",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code of the account",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/account.WalletAccount"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange/audits": {
+            "get": {
+                "description": "List who changed the exchange pairs and how, newest first, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Exchange Pair Audits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency code",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency code",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/exchange.PairAuditResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange/pairs": {
+            "get": {
+                "description": "List every exchange pair including the disabled ones, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Exchange Pairs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/exchange.PairResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an exchange pair on currencies known by the currency service, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Exchange Pair",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchange.CreatePairRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.PairResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Pair Already Exists",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange/pairs/{from}/{to}": {
+            "put": {
+                "description": "Update the exchange and markup rates of an exchange pair, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Exchange Pair",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency code",
+                        "name": "from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency code",
+                        "name": "to",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchange.UpdatePairRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.PairResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an exchange pair, its audits and rate history are kept, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Exchange Pair",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency code",
+                        "name": "from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency code",
+                        "name": "to",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange/pairs/{from}/{to}/disable": {
+            "post": {
+                "description": "Stop quoting an exchange pair and using it for cross rates, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Disable Exchange Pair",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency code",
+                        "name": "from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency code",
+                        "name": "to",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.PairResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange/pairs/{from}/{to}/enable": {
+            "post": {
+                "description": "Quote a disabled exchange pair again, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Enable Exchange Pair",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency code",
+                        "name": "from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency code",
+                        "name": "to",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.PairResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange/pairs/{from}/{to}/markups": {
+            "get": {
+                "description": "List the markup rules of a pair by segment and tier, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Markup Rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency code",
+                        "name": "from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency code",
+                        "name": "to",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/exchange.MarkupRuleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the markup rules of a pair, pairs without rules are marked up by their markup rate, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replace Markup Rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency code",
+                        "name": "from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency code",
+                        "name": "to",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchange.MarkupRulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/exchange.MarkupRuleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange/sweeper": {
+            "get": {
+                "description": "Get the stats of the last run of the sweeper which expires offers and limit orders and archives old offers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Last Sweep",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of an admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.SweepStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/onboarding/grants/{id}/clawback": {
+            "post": {
+                "description": "Take the unspent onboarding credit of a user back right away, the credit the user already spent is not taken back",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Claw Back Onboarding Credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of an admin user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the grant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/onboarding.GrantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/accept/offer": {
+            "post": {
+                "description": "Accept the given exchange rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Accept exchange rate offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchange.AcceptOfferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/account.WalletAccount"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Offer Already Used, Expired or Cancelled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/limit-orders": {
+            "get": {
+                "description": "List the limit orders of the user newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "List Limit Orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "OPEN, FILLED, CANCELLED or EXPIRED, every order by default",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/exchange.LimitOrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Reserve the amount and convert it once the offered rate reaches the limit rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Place Limit Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchange.LimitOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.LimitOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/limit-orders/{id}": {
+            "delete": {
+                "description": "Cancel an open limit order and release its reserved amount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Cancel Limit Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the limit order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.LimitOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Limit Order Not Open",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/offers": {
+            "get": {
+                "description": "List the offers of the user newest first, pending offers past their expiry are listed as expired",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "List Offers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive end of the creation date range in RFC3339",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Only offers from given currency",
+                        "name": "fromCurrencyCode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive start of the creation date range in RFC3339",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, ACCEPTED, EXPIRED or CANCELLED, every offer by default",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "TRY",
+                        "description": "Only offers to given currency",
+                        "name": "toCurrencyCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.OfferListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/offers/{id}": {
+            "get": {
+                "description": "Get an offer of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Get Offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the offer",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.OfferDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel a pending offer of the user so it can not be accepted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Cancel Offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the offer",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.OfferDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Offer Not Pending",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/plans": {
+            "get": {
+                "description": "List the conversion plans of the user newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "List Conversion Plans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schedule.PlanResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Convert the same amount on every day, week or month through an offer accepted on the user's behalf",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Create Conversion Plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schedule.PlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schedule.PlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/plans/{id}": {
+            "delete": {
+                "description": "Stop an active conversion plan, its run history is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Cancel Conversion Plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the conversion plan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schedule.PlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conversion Plan Not Active",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/plans/{id}/runs": {
+            "get": {
+                "description": "List every attempt of a conversion plan with its outcome newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "List Conversion Plan Runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the conversion plan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schedule.RunResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/rate": {
+            "post": {
+                "description": "Get exchange rate on given currencies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Get Exchange Rate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchange.OfferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.OfferResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/rates/history": {
+            "get": {
+                "description": "Get the recorded exchange rates of the given currencies as OHLC buckets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Get Exchange Rate History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user.",
+                        "name": "X-Auth-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive end of the range in RFC3339, now by default",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "From currency code",
+                        "name": "fromCurrencyCode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Bucket length, e.g. 15m, 1h or 24h, 1h by default",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive start of the range in RFC3339, a day before end by default",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "TRY",
+                        "description": "To currency code",
+                        "name": "toCurrencyCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/exchange.RateHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/stream": {
+            "get": {
+                "description": "Push the rates of the watched pairs as server-sent events, every pair is sent on connect and then whenever it changes.\nEvents are rate with a RateUpdate and heartbeat without data.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Stream Exchange Rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user, may be given as token query param instead.",
+                        "name": "X-Auth-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD/TRY,EUR/TRY",
+                        "description": "Comma separated pairs to watch, every pair by default",
+                        "name": "pairs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Auth token for clients which can not set the X-Auth-Token header",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of rate events",
+                        "schema": {
+                            "$ref": "#/definitions/exchange.RateUpdate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange/stream/ws": {
+            "get": {
+                "description": "Push the rates of the watched pairs as RateStreamMessage, every pair is sent on connect and then whenever it changes.\nMessages sent by the client are ignored.",
+                "tags": [
+                    "Exchange"
+                ],
+                "summary": "Stream Exchange Rates Over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Auth token of logged-in user, may be given as token query param instead.",
+                        "name": "X-Auth-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD/TRY,EUR/TRY",
+                        "description": "Comma separated pairs to watch, every pair by default",
+                        "name": "pairs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Auth token for clients which can not set the X-Auth-Token header",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/exchange.RateStreamMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/callback": {
+            "post": {
+                "description": "Report the outcome of a payment, called by the payment gateway. The body is a GatewayEvent signed by the gateway.\nA repeated event is accepted without changing the payment again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Payment Gateway Callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signature of the body.",
+                        "name": "X-Gateway-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payment.GatewayEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/payment.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "User Login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Auth User",
+                "parameters": [
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/register": {
+            "post": {
+                "description": "Create a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create User",
+                "parameters": [
+                    {
+                        "description": "body params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.RegisterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/helper.ResponseError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "account.AccountRequest": {
+            "type": "object",
+            "required": [
+                "currency_code"
+            ],
+            "properties": {
+                "currency_code": {
+                    "description": "Currency of the account",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "EUR"
+                }
+            }
+        },
+        "account.AccountSummary": {
+            "type": "object",
+            "properties": {
+                "currency_code": {
+                    "description": "Valuation currency",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "TRY"
+                },
+                "net_worth": {
+                    "description": "Total value of the accounts in the valuation currency",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2861.40"
+                },
+                "accounts": {
+                    "description": "Accounts by value, largest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.AccountValuation"
+                    },
+                    "x-order": "3"
+                },
+                "valued_at": {
+                    "description": "Time the rates were read at",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "2022-12-01T10:00:00+03:00"
+                }
+            }
+        },
+        "account.AccountValuation": {
+            "type": "object",
+            "properties": {
+                "currency_code": {
+                    "description": "Currency of the account",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "USD"
+                },
+                "balance": {
+                    "description": "Total balance including the held amounts",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "100.00"
+                },
+                "mid_rate": {
+                    "description": "Mid rate from the account currency to the valuation currency",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "18.634"
+                },
+                "value": {
+                    "description": "Balance in the valuation currency",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "1863.40"
+                },
+                "share": {
+                    "description": "Percentage of the net worth",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "65.12"
+                }
+            }
+        },
+        "account.Transaction": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the ledger posting",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 12
+                },
+                "type": {
+                    "description": "Type of the transaction",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "CONVERSION_DEBIT"
+                },
+                "currency_code": {
+                    "description": "Currency of the account",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "TRY"
+                },
+                "amount": {
+                    "description": "Change on the balance, negative when money left the account",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "-100.00"
+                },
+                "offer_id": {
+                    "description": "ID of the originating exchange offer",
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 4
+                },
+                "limit_order_id": {
+                    "description": "ID of the originating limit order",
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 2
+                },
+                "transfer_id": {
+                    "description": "ID of the originating transfer",
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 3
+                },
+                "payment_id": {
+                    "description": "ID of the originating deposit or withdrawal",
+                    "type": "integer",
+                    "x-order": "8",
+                    "example": 5
+                },
+                "created_at": {
+                    "description": "Time of the transaction",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "2022-12-01T10:00:00+03:00"
+                }
+            }
+        },
+        "account.TransactionListResponse": {
+            "type": "object",
+            "properties": {
+                "transactions": {
+                    "description": "Transactions newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.Transaction"
+                    },
+                    "x-order": "1"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last page",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "12"
+                }
+            }
+        },
+        "account.WalletAccount": {
+            "type": "object",
+            "properties": {
+                "available_balance": {
+                    "description": "Balance which can be spent",
+                    "type": "string",
+                    "example": "80.50"
+                },
+                "balance": {
+                    "description": "Total balance including the held amounts",
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "frozen": {
+                    "description": "Debits and credits are rejected while the account is frozen",
+                    "type": "boolean",
+                    "example": false
+                },
+                "held_balance": {
+                    "description": "Balance held for pending operations",
+                    "type": "string",
+                    "example": "20.00"
+                }
+            }
+        },
+        "exchange.AcceptOfferRequest": {
+            "type": "object",
+            "required": [
+                "offer_id"
+            ],
+            "properties": {
+                "offer_id": {
+                    "description": "ID of the offer",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 4
+                },
+                "amount": {
+                    "description": "Amount to convert in from currency, may be left out when the offer has a locked amount",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "100.00"
+                }
+            }
+        },
+        "exchange.AppliedMarkup": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "description": "ABSOLUTE, BASIS_POINTS or PAIR_DEFAULT",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "BASIS_POINTS"
+                },
+                "value": {
+                    "description": "Value of the markup rule",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "50"
+                },
+                "segment": {
+                    "description": "User segment of the markup rule",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "VIP"
+                },
+                "min_amount": {
+                    "description": "Smallest amount of the markup tier",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "0"
+                },
+                "rate": {
+                    "description": "Markup subtracted from the mid rate",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "0.11"
+                }
+            }
+        },
+        "exchange.CreatePairRequest": {
+            "type": "object",
+            "required": [
+                "exchange_rate",
+                "from_currency_code",
+                "to_currency_code"
+            ],
+            "properties": {
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "USD"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "GBP"
+                },
+                "exchange_rate": {
+                    "description": "Mid rate of the pair",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "0.82"
+                },
+                "markup_rate": {
+                    "description": "Markup subtracted from the mid rate",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "0.01"
+                },
+                "fixed_fee": {
+                    "description": "Fee in to currency deducted from every conversion",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "1.50"
+                },
+                "offer_ttl_seconds": {
+                    "description": "Seconds offers of the pair can be accepted in, the configured offer TTL when zero",
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 30
+                }
+            }
+        },
+        "exchange.LimitOrderRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "from_currency_code",
+                "limit_rate",
+                "to_currency_code"
+            ],
+            "properties": {
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "USD"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "TRY"
+                },
+                "amount": {
+                    "description": "Amount to convert in from currency, reserved until the order is closed",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "100.00"
+                },
+                "limit_rate": {
+                    "description": "Lowest offered rate the order is filled at",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "19.10"
+                },
+                "expires_at": {
+                    "description": "Order expires at this time, a week later by default",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2022-12-08T10:00:00Z"
+                }
+            }
+        },
+        "exchange.LimitOrderResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the limit order",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "filled_at": {
+                    "description": "Time the order was filled",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "2022-12-02T10:00:00Z"
+                },
+                "created_at": {
+                    "description": "Time the order was placed",
+                    "type": "string",
+                    "x-order": "11",
+                    "example": "2022-12-01T10:00:00Z"
+                },
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "USD"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "TRY"
+                },
+                "amount": {
+                    "description": "Amount to convert in from currency",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "100.00"
+                },
+                "limit_rate": {
+                    "description": "Lowest offered rate the order is filled at",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "19.10"
+                },
+                "status": {
+                    "description": "OPEN, FILLED, CANCELLED or EXPIRED",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "OPEN"
+                },
+                "expires_at": {
+                    "description": "Order expires at this time",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-12-08T10:00:00Z"
+                },
+                "offer_id": {
+                    "description": "Offer the order was filled through",
+                    "type": "integer",
+                    "x-order": "8",
+                    "example": 4
+                },
+                "filled_rate": {
+                    "description": "Offered rate the order was filled at",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "19.12"
+                }
+            }
+        },
+        "exchange.MarkupRuleRequest": {
+            "type": "object",
+            "properties": {
+                "segment": {
+                    "description": "User segment, empty for every user",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "VIP"
+                },
+                "type": {
+                    "description": "ABSOLUTE or BASIS_POINTS",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "BASIS_POINTS"
+                },
+                "value": {
+                    "description": "Markup in rate units or basis points",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "50"
+                },
+                "min_amount": {
+                    "description": "Smallest amount in from currency the tier applies to",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "10000"
+                }
+            }
+        },
+        "exchange.MarkupRuleResponse": {
+            "type": "object",
+            "properties": {
+                "segment": {
+                    "description": "User segment, empty for every user",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "VIP"
+                },
+                "type": {
+                    "description": "ABSOLUTE or BASIS_POINTS",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "BASIS_POINTS"
+                },
+                "value": {
+                    "description": "Markup in rate units or basis points",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "50"
+                },
+                "min_amount": {
+                    "description": "Smallest amount in from currency the tier applies to",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "10000"
+                }
+            }
+        },
+        "exchange.MarkupRulesRequest": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "description": "Markup rules replacing the current rules of the pair, empty to use the pair markup rate",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/exchange.MarkupRuleRequest"
+                    },
+                    "x-order": "1"
+                }
+            }
+        },
+        "exchange.OfferDetailResponse": {
+            "type": "object",
+            "properties": {
+                "offer_id": {
+                    "description": "ID of the exchange rate offer",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 4
+                },
+                "accepted_at": {
+                    "description": "Time the offer was accepted",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "2022-12-01T10:01:00Z"
+                },
+                "expires_at": {
+                    "description": "Offer can not be accepted after this time",
+                    "type": "string",
+                    "x-order": "11",
+                    "example": "2022-12-01T10:03:00Z"
+                },
+                "created_at": {
+                    "description": "Time the offer was quoted",
+                    "type": "string",
+                    "x-order": "12",
+                    "example": "2022-12-01T10:00:00Z"
+                },
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "TRY"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "EUR"
+                },
+                "exchange_rate": {
+                    "description": "Exchange rate with markup rate",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "22.00"
+                },
+                "status": {
+                    "description": "PENDING, ACCEPTED, EXPIRED or CANCELLED",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "PENDING"
+                },
+                "sell_amount": {
+                    "description": "Locked amount in from currency, null when the offer is only a rate",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "10204.09"
+                },
+                "buy_amount": {
+                    "description": "Locked amount in to currency, null when the offer is only a rate",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "500.00"
+                },
+                "fixed_fee": {
+                    "description": "Fee in to currency deducted on acceptance, null when the pair has no fee",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "1.50"
+                },
+                "accepted_amount": {
+                    "description": "Amount converted in from currency",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "100.00"
+                }
+            }
+        },
+        "exchange.OfferListResponse": {
+            "type": "object",
+            "properties": {
+                "offers": {
+                    "description": "Offers newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/exchange.OfferDetailResponse"
+                    },
+                    "x-order": "1"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last page",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "4"
+                }
+            }
+        },
+        "exchange.OfferRequest": {
+            "type": "object",
+            "required": [
+                "from_currency_code",
+                "to_currency_code"
+            ],
+            "properties": {
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "TRY"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "EUR"
+                },
+                "sell_amount": {
+                    "description": "Amount to sell in from currency, locked into the offer",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "1000.00"
+                },
+                "buy_amount": {
+                    "description": "Amount to buy in to currency, locked into the offer instead of sell amount",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "500.00"
+                }
+            }
+        },
+        "exchange.OfferResponse": {
+            "type": "object",
+            "properties": {
+                "offer_id": {
+                    "description": "ID of the exchange rate offer",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 4
+                },
+                "fixed_fee": {
+                    "description": "Fee in to currency deducted on acceptance",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "1.50"
+                },
+                "quote": {
+                    "description": "Breakdown of the credited amount, only on offers with an amount",
+                    "x-order": "11",
+                    "$ref": "#/definitions/exchange.Quote"
+                },
+                "expires_at": {
+                    "description": "Offer can not be accepted after this time",
+                    "type": "string",
+                    "x-order": "12",
+                    "example": "2022-12-01T10:03:00Z"
+                },
+                "expires_in": {
+                    "description": "Seconds left to accept the offer",
+                    "type": "integer",
+                    "x-order": "13",
+                    "example": 180
+                },
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "TRY"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "EUR"
+                },
+                "exchange_rate": {
+                    "description": "Exchange rate with markup rate",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "22.00"
+                },
+                "path": {
+                    "description": "Currencies the rate is computed through",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "5",
+                    "example": [
+                        "TRY",
+                        "USD",
+                        "EUR"
+                    ]
+                },
+                "mid_rate": {
+                    "description": "Exchange rate before markup",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "22.11"
+                },
+                "markup": {
+                    "description": "Markup subtracted from the mid rate",
+                    "x-order": "7",
+                    "$ref": "#/definitions/exchange.AppliedMarkup"
+                },
+                "sell_amount": {
+                    "description": "Amount debited in from currency, null when the offer is only a rate",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "10204.09"
+                },
+                "buy_amount": {
+                    "description": "Amount credited in to currency, null when the offer is only a rate",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "500.00"
+                }
+            }
+        },
+        "exchange.PairAuditResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the audit record",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "admin_user_id": {
+                    "description": "ID of the admin who made the change",
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 1
+                },
+                "action": {
+                    "description": "CREATE, UPDATE, DISABLE, ENABLE or DELETE",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "UPDATE"
+                },
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "USD"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "GBP"
+                },
+                "before": {
+                    "description": "Pair before the change",
+                    "type": "object",
+                    "x-order": "6"
+                },
+                "after": {
+                    "description": "Pair after the change",
+                    "type": "object",
+                    "x-order": "7"
+                },
+                "created_at": {
+                    "description": "Time of the change",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-12-01T10:00:00Z"
+                }
+            }
+        },
+        "exchange.PairResponse": {
+            "type": "object",
+            "properties": {
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "USD"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "GBP"
+                },
+                "exchange_rate": {
+                    "description": "Mid rate of the pair",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "0.82"
+                },
+                "markup_rate": {
+                    "description": "Markup subtracted from the mid rate",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "0.01"
+                },
+                "fixed_fee": {
+                    "description": "Fee in to currency deducted from every conversion",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "1.50"
+                },
+                "disabled": {
+                    "description": "Whether the pair is disabled",
+                    "type": "boolean",
+                    "x-order": "6",
+                    "example": false
+                },
+                "offer_ttl_seconds": {
+                    "description": "Seconds offers of the pair can be accepted in, the configured offer TTL when zero",
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 30
+                },
+                "updated_at": {
+                    "description": "Time of the last change",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-12-01T10:00:00Z"
+                }
+            }
+        },
+        "exchange.Quote": {
+            "type": "object",
+            "properties": {
+                "gross_amount": {
+                    "description": "Sell amount at mid rate",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "513.28"
+                },
+                "markup_cost": {
+                    "description": "Sell amount at markup rate",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "11.76"
+                },
+                "fixed_fee": {
+                    "description": "Fee of the pair",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "1.50"
+                },
+                "rounding": {
+                    "description": "Left over by rounding to minor units",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "0.02"
+                },
+                "net_amount": {
+                    "description": "Amount credited on acceptance",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "500.00"
+                }
+            }
+        },
+        "exchange.RateBucket": {
+            "type": "object",
+            "properties": {
+                "start": {
+                    "description": "Start of the bucket",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "2022-12-01T10:00:00Z"
+                },
+                "open": {
+                    "description": "First rate of the bucket",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "18.63"
+                },
+                "high": {
+                    "description": "Highest rate of the bucket",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "18.71"
+                },
+                "low": {
+                    "description": "Lowest rate of the bucket",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "18.60"
+                },
+                "close": {
+                    "description": "Last rate of the bucket",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "18.70"
+                },
+                "count": {
+                    "description": "Number of rate changes in the bucket",
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 12
+                }
+            }
+        },
+        "exchange.RateHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "USD"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "TRY"
+                },
+                "interval": {
+                    "description": "Bucket length",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "1h0m0s"
+                },
+                "buckets": {
+                    "description": "Buckets with at least one rate, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/exchange.RateBucket"
+                    },
+                    "x-order": "4"
+                }
+            }
+        },
+        "exchange.RateStreamMessage": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "description": "rate or heartbeat",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "rate"
+                },
+                "data": {
+                    "description": "Updated rate, empty on heartbeat",
+                    "x-order": "2",
+                    "$ref": "#/definitions/exchange.RateUpdate"
+                }
+            }
+        },
+        "exchange.RateUpdate": {
+            "type": "object",
+            "properties": {
+                "from_currency_code": {
+                    "description": "From currency code",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "USD"
+                },
+                "to_currency_code": {
+                    "description": "To currency code",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "TRY"
+                },
+                "exchange_rate": {
+                    "description": "Rate the pair is quoted at, markup rules may quote some amounts differently",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "18.52"
+                },
+                "disabled": {
+                    "description": "Disabled pairs are not quoted",
+                    "type": "boolean",
+                    "x-order": "4",
+                    "example": false
+                },
+                "updated_at": {
+                    "description": "Time the pair was last changed",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2022-12-01T10:00:00Z"
+                }
+            }
+        },
+        "exchange.SweepStats": {
+            "type": "object",
+            "properties": {
+                "started_at": {
+                    "description": "Time the sweep started",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "2022-12-01T10:00:00Z"
+                },
+                "finished_at": {
+                    "description": "Time the sweep finished",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2022-12-01T10:00:01Z"
+                },
+                "expired_offers": {
+                    "description": "Pending offers marked as expired",
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 12
+                },
+                "expired_limit_orders": {
+                    "description": "Open limit orders expired and released",
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 1
+                },
+                "archived_offers": {
+                    "description": "Closed offers moved to the offer history",
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 250
+                },
+                "error": {
+                    "description": "Reason the sweep stopped early",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "connection refused"
+                }
+            }
+        },
+        "exchange.UpdatePairRequest": {
+            "type": "object",
+            "required": [
+                "exchange_rate"
             ],
             "properties": {
-                "offer_id": {
-                    "description": "ID of the offer",
+                "exchange_rate": {
+                    "description": "Mid rate of the pair",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "0.82"
+                },
+                "markup_rate": {
+                    "description": "Markup subtracted from the mid rate",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "0.01"
+                },
+                "fixed_fee": {
+                    "description": "Fee in to currency deducted from every conversion",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "1.50"
+                },
+                "offer_ttl_seconds": {
+                    "description": "Seconds offers of the pair can be accepted in, the configured offer TTL when zero",
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 30
+                }
+            }
+        },
+        "helper.Response": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean",
+                    "x-order": "1",
+                    "example": true
+                },
+                "status_code": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 200
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helper.ResponseWarning"
+                    },
+                    "x-order": "3"
+                },
+                "error": {
+                    "x-order": "4",
+                    "$ref": "#/definitions/helper.ResponseError"
+                },
+                "data": {
+                    "x-order": "5"
+                }
+            }
+        },
+        "helper.ResponseError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "NOT_FOUND"
+                },
+                "detail": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "user not found"
+                }
+            }
+        },
+        "helper.ResponseWarning": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "password"
+                },
+                "code": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "invalid"
+                }
+            }
+        },
+        "onboarding.GrantResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the grant",
                     "type": "integer",
                     "x-order": "1",
-                    "example": 4
+                    "example": 3
+                },
+                "created_at": {
+                    "description": "Time the credit was granted",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "2022-12-01T10:00:00Z"
+                },
+                "user_id": {
+                    "description": "ID of the credited user",
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 7
+                },
+                "campaign_code": {
+                    "description": "Campaign the credit was granted by",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "WELCOME"
+                },
+                "currency_code": {
+                    "description": "Currency of the credit",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "TRY"
                 },
                 "amount": {
-                    "description": "ID of the offer",
-                    "type": "number",
+                    "description": "Credited amount",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "1863.00"
+                },
+                "status": {
+                    "description": "ACTIVE, EXPIRED or CLAWED_BACK",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "ACTIVE"
+                },
+                "expires_at": {
+                    "description": "Unspent credit is clawed back after this",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-12-31T10:00:00Z"
+                },
+                "clawed_back_amount": {
+                    "description": "Amount taken back from the user",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "1200.00"
+                },
+                "clawed_back_at": {
+                    "description": "Time the credit was clawed back",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "2022-12-31T10:00:00Z"
+                }
+            }
+        },
+        "payment.GatewayEvent": {
+            "type": "object",
+            "properties": {
+                "failure_reason": {
+                    "description": "Reason of a failed payment",
+                    "type": "string"
+                },
+                "payment_id": {
+                    "description": "ID of the payment on this service",
+                    "type": "integer"
+                },
+                "reference": {
+                    "description": "ID of the payment on the gateway",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "PENDING, SETTLED or FAILED",
+                    "type": "string"
+                }
+            }
+        },
+        "payment.PaymentListResponse": {
+            "type": "object",
+            "properties": {
+                "payments": {
+                    "description": "Payments newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payment.PaymentResponse"
+                    },
+                    "x-order": "1"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last page",
+                    "type": "string",
                     "x-order": "2",
-                    "example": 100
+                    "example": "5"
                 }
             }
         },
-        "exchange.OfferRequest": {
+        "payment.PaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "currency_code"
+            ],
+            "properties": {
+                "currency_code": {
+                    "description": "Currency of the payment",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "USD"
+                },
+                "amount": {
+                    "description": "Amount to deposit or withdraw",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "100.00"
+                }
+            }
+        },
+        "payment.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the payment",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 5
+                },
+                "type": {
+                    "description": "DEPOSIT or WITHDRAWAL",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "DEPOSIT"
+                },
+                "currency_code": {
+                    "description": "Currency of the payment",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "USD"
+                },
+                "amount": {
+                    "description": "Amount of the payment",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "100.00"
+                },
+                "status": {
+                    "description": "PENDING, SETTLED or FAILED",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "PENDING"
+                },
+                "failure_reason": {
+                    "description": "Reason reported by the gateway for a failed payment",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "card declined"
+                },
+                "settled_at": {
+                    "description": "Time the payment was settled",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-12-01T10:01:00Z"
+                },
+                "created_at": {
+                    "description": "Time the payment was requested",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-12-01T10:00:00Z"
+                },
+                "updated_at": {
+                    "description": "Time of the last status change",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "2022-12-01T10:01:00Z"
+                }
+            }
+        },
+        "schedule.PlanRequest": {
             "type": "object",
             "required": [
+                "amount",
                 "from_currency_code",
+                "interval",
                 "to_currency_code"
             ],
             "properties": {
@@ -634,101 +6643,309 @@ const docTemplate = `{
                     "description": "From currency code",
                     "type": "string",
                     "x-order": "1",
-                    "example": "TRY"
+                    "example": "USD"
                 },
                 "to_currency_code": {
                     "description": "To currency code",
                     "type": "string",
                     "x-order": "2",
-                    "example": "EUR"
+                    "example": "TRY"
+                },
+                "amount": {
+                    "description": "Amount to sell in from currency on every run",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "1000.00"
+                },
+                "interval": {
+                    "description": "DAILY, WEEKLY or MONTHLY",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "MONTHLY"
+                },
+                "start_at": {
+                    "description": "Time of the first run, now by default",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2022-12-25T09:00:00Z"
+                },
+                "on_insufficient_balance": {
+                    "description": "SKIP or RETRY, SKIP by default",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "RETRY"
+                },
+                "max_retries": {
+                    "description": "Retries in a period on RETRY policy, 3 by default",
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 3
                 }
             }
         },
-        "exchange.OfferResponse": {
+        "schedule.PlanResponse": {
             "type": "object",
             "properties": {
-                "offer_id": {
-                    "description": "ID of the exchange rate offer",
+                "id": {
+                    "description": "ID of the plan",
                     "type": "integer",
                     "x-order": "1",
-                    "example": 4
+                    "example": 3
+                },
+                "created_at": {
+                    "description": "Time the plan was created",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "2022-12-01T10:00:00Z"
                 },
                 "from_currency_code": {
                     "description": "From currency code",
                     "type": "string",
                     "x-order": "2",
-                    "example": "TRY"
+                    "example": "USD"
                 },
                 "to_currency_code": {
                     "description": "To currency code",
                     "type": "string",
                     "x-order": "3",
-                    "example": "EUR"
+                    "example": "TRY"
                 },
-                "exchange_rate": {
-                    "description": "Exchange rate with markup rate",
-                    "type": "number",
+                "amount": {
+                    "description": "Amount to sell in from currency on every run",
+                    "type": "string",
                     "x-order": "4",
-                    "example": 22
+                    "example": "1000.00"
+                },
+                "interval": {
+                    "description": "DAILY, WEEKLY or MONTHLY",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "MONTHLY"
+                },
+                "on_insufficient_balance": {
+                    "description": "SKIP or RETRY",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "RETRY"
+                },
+                "max_retries": {
+                    "description": "Retries in a period on RETRY policy",
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 3
+                },
+                "status": {
+                    "description": "ACTIVE or CANCELLED",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "ACTIVE"
+                },
+                "next_run_at": {
+                    "description": "Time of the next attempt",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "2023-01-25T09:00:00Z"
                 }
             }
         },
-        "helper.Response": {
+        "schedule.RunResponse": {
             "type": "object",
             "properties": {
-                "success": {
-                    "type": "boolean",
+                "id": {
+                    "description": "ID of the run",
+                    "type": "integer",
                     "x-order": "1",
-                    "example": true
+                    "example": 12
                 },
-                "status_code": {
-                    "type": "integer",
+                "created_at": {
+                    "description": "Time of the attempt",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "2022-12-25T09:00:05Z"
+                },
+                "scheduled_at": {
+                    "description": "Scheduled time of the period",
+                    "type": "string",
                     "x-order": "2",
-                    "example": 200
+                    "example": "2022-12-25T09:00:00Z"
                 },
-                "warnings": {
+                "attempt": {
+                    "description": "Attempt in the period starting from 1",
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 1
+                },
+                "outcome": {
+                    "description": "SUCCEEDED, RETRYING, SKIPPED or FAILED",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "SUCCEEDED"
+                },
+                "offer_id": {
+                    "description": "Offer the run was quoted with",
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 4
+                },
+                "exchange_rate": {
+                    "description": "Offered exchange rate",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "18.63"
+                },
+                "sell_amount": {
+                    "description": "Amount debited in from currency",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "1000.00"
+                },
+                "buy_amount": {
+                    "description": "Amount credited in to currency",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "18630.00"
+                },
+                "error": {
+                    "description": "Reason of an unsuccessful run",
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "not enough balance"
+                }
+            }
+        },
+        "transfer.TransferListResponse": {
+            "type": "object",
+            "properties": {
+                "transfers": {
+                    "description": "Transfers newest first",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/helper.ResponseWarning"
+                        "$ref": "#/definitions/transfer.TransferResponse"
                     },
-                    "x-order": "3"
-                },
-                "error": {
-                    "x-order": "4",
-                    "$ref": "#/definitions/helper.ResponseError"
+                    "x-order": "1"
                 },
-                "data": {
-                    "x-order": "5"
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last page",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "3"
                 }
             }
         },
-        "helper.ResponseError": {
+        "transfer.TransferRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "currency_code",
+                "recipient"
+            ],
             "properties": {
-                "message": {
+                "recipient": {
+                    "description": "Username or email of the recipient",
                     "type": "string",
                     "x-order": "1",
-                    "example": "NOT_FOUND"
+                    "example": "jane@gmail.com"
                 },
-                "detail": {
+                "currency_code": {
+                    "description": "Currency to send from",
                     "type": "string",
                     "x-order": "2",
-                    "example": "user not found"
+                    "example": "USD"
+                },
+                "amount": {
+                    "description": "Amount to send in currency",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "100.00"
+                },
+                "convert_to_recipient_currency": {
+                    "description": "Convert the amount into the default currency of the recipient",
+                    "type": "boolean",
+                    "x-order": "4",
+                    "example": true
+                },
+                "note": {
+                    "description": "Message shown to both parties, at most 140 characters",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "Dinner"
                 }
             }
         },
-        "helper.ResponseWarning": {
+        "transfer.TransferResponse": {
             "type": "object",
             "properties": {
-                "field": {
-                    "type": "string",
+                "id": {
+                    "description": "ID of the transfer",
+                    "type": "integer",
                     "x-order": "1",
-                    "example": "password"
+                    "example": 3
                 },
-                "code": {
+                "exchange_rate": {
+                    "description": "Rate the amount was converted with",
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "18.33"
+                },
+                "note": {
+                    "description": "Message of the sender",
+                    "type": "string",
+                    "x-order": "11",
+                    "example": "Dinner"
+                },
+                "created_at": {
+                    "description": "Time of the transfer",
+                    "type": "string",
+                    "x-order": "12",
+                    "example": "2022-12-01T10:00:00Z"
+                },
+                "direction": {
+                    "description": "SENT or RECEIVED from the user's side",
                     "type": "string",
                     "x-order": "2",
-                    "example": "invalid"
+                    "example": "SENT"
+                },
+                "sender_username": {
+                    "description": "Username of the sender",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "john"
+                },
+                "recipient_username": {
+                    "description": "Username of the recipient",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "jane"
+                },
+                "currency_code": {
+                    "description": "Currency debited from the sender",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "USD"
+                },
+                "amount": {
+                    "description": "Amount debited from the sender",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "100.00"
+                },
+                "recipient_currency_code": {
+                    "description": "Currency credited to the recipient",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "TRY"
+                },
+                "recipient_amount": {
+                    "description": "Amount credited to the recipient",
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "1833.00"
+                },
+                "offer_id": {
+                    "description": "Offer the amount was converted with",
+                    "type": "integer",
+                    "x-order": "9",
+                    "example": 4
                 }
             }
         },
@@ -805,6 +7022,12 @@ const docTemplate = `{
                     "type": "string",
                     "x-order": "4",
                     "example": "TRY"
+                },
+                "campaign_code": {
+                    "description": "Code of the campaign the user registers with for its onboarding credit",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "WELCOME"
                 }
             }
         },
@@ -5,6 +5,7 @@ import (
 )
 
 type Token struct {
-	UserId uint
+	UserId  uint
+	IsAdmin bool
 	jwt.StandardClaims
 }
@@ -4,11 +4,38 @@ import "errors"
 
 var (
 	ErrBindJson                   = errors.New("BINDING_JSON")
+	ErrBindQuery                  = errors.New("BINDING_QUERY")
 	ErrCreateError                = errors.New("CREATE")
 	ErrNotFoundError              = errors.New("NOT_FOUND")
+	ErrAccountError               = errors.New("ACCOUNT")
+	ErrAccountExistsError         = errors.New("ACCOUNT_ALREADY_EXISTS")
+	ErrAccountFrozenError         = errors.New("ACCOUNT_FROZEN")
+	ErrAccountStatementError      = errors.New("ACCOUNT_STATEMENT")
+	ErrAccountSummaryError        = errors.New("ACCOUNT_SUMMARY")
 	ErrExchangeOfferError         = errors.New("EXCHANGE_OFFER")
 	ErrExchangeOfferAcceptedError = errors.New("EXCHANGE_OFFER_ACCEPT")
+	ErrExchangeOfferUsedError     = errors.New("EXCHANGE_OFFER_ALREADY_USED")
+	ErrExchangeOfferClosedError   = errors.New("EXCHANGE_OFFER_CLOSED")
+	ErrExchangeRateHistoryError   = errors.New("EXCHANGE_RATE_HISTORY")
+	ErrExchangePairError          = errors.New("EXCHANGE_PAIR")
+	ErrExchangePairExistsError    = errors.New("EXCHANGE_PAIR_ALREADY_EXISTS")
+	ErrLimitOrderError            = errors.New("LIMIT_ORDER")
+	ErrLimitOrderClosedError      = errors.New("LIMIT_ORDER_CLOSED")
+	ErrConversionPlanError        = errors.New("CONVERSION_PLAN")
+	ErrConversionPlanClosedError  = errors.New("CONVERSION_PLAN_CLOSED")
+	ErrTransferError              = errors.New("TRANSFER")
+	ErrPaymentError               = errors.New("PAYMENT")
+	ErrPaymentClosedError         = errors.New("PAYMENT_CLOSED")
+	ErrPaymentGatewayError        = errors.New("PAYMENT_GATEWAY")
+	ErrOnboardingError            = errors.New("ONBOARDING")
+	ErrOnboardingClosedError      = errors.New("ONBOARDING_CLOSED")
+	ErrForbiddenError             = errors.New("FORBIDDEN")
 	ErrInvalidTokenError          = errors.New("INVALID_TOKEN")
 	ErrExpiredTokenError          = errors.New("EXPIRED_TOKEN")
 	ErrCreateTokenError           = errors.New("CREATE_TOKEN")
 )
+
+// Is reports whether any error in err's chain matches target
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
@@ -3,8 +3,11 @@ package account
 import (
 	// Go imports
 	"net/http"
+	"strconv"
+	"strings"
 
 	// External imports
+	"github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 
 	// Internal imports
@@ -16,7 +19,13 @@ import (
 
 type Handler interface {
 	List(c *gin.Context)
+	Transactions(c *gin.Context)
+	Open(c *gin.Context)
+	Close(c *gin.Context)
+	Freeze(c *gin.Context)
+	Unfreeze(c *gin.Context)
 	AccountRoutes(router *gin.RouterGroup)
+	AdminRoutes(router *gin.RouterGroup)
 }
 
 type accountHandler struct {
@@ -30,6 +39,15 @@ func NewAccountHandler(accountService IAccountService, currencyService currency.
 
 func (h *accountHandler) AccountRoutes(router *gin.RouterGroup) {
 	router.GET("/list", h.List)
+	router.GET("/transactions", h.Transactions)
+	router.POST("/open", h.Open)
+	router.POST("/close", h.Close)
+}
+
+// AdminRoutes let admins freeze the account of any user
+func (h *accountHandler) AdminRoutes(router *gin.RouterGroup) {
+	router.POST("/:user_id/:currency/freeze", h.Freeze)
+	router.POST("/:user_id/:currency/unfreeze", h.Unfreeze)
 }
 
 // List godoc
@@ -60,3 +78,198 @@ func (h *accountHandler) List(c *gin.Context) {
 
 	helper.Success(c, walletAccounts)
 }
+
+// Transactions godoc
+// @Summary List User Transactions
+// @Description List the movements on user's accounts newest first, each conversion links back to its offer
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query TransactionListRequest false "query params"
+// @Success 200 {object} helper.Response{data=TransactionListResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/transactions [get]
+func (h *accountHandler) Transactions(c *gin.Context) {
+	var req TransactionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	transactions, err := h.accountService.ListUserTransactions(userId, req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTransactionFilter) {
+			helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+			return
+		}
+		helper.Error(c, http.StatusInternalServerError, errors.ErrNotFoundError.Error(), err.Error())
+		return
+	}
+
+	helper.Success(c, transactions)
+}
+
+// Open godoc
+// @Summary Open Account
+// @Description Open an empty account in a supported currency, a closed account is opened again
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request body AccountRequest true "body params"
+// @Success 200 {object} helper.Response{data=WalletAccount} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Conflict"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/open [post]
+func (h *accountHandler) Open(c *gin.Context) {
+	req, userId, ok := h.bindAccountRequest(c)
+	if !ok {
+		return
+	}
+
+	if ok = h.currencyService.CheckIsCurrencyCodeExist(strings.ToUpper(req.CurrencyCode)); !ok {
+		helper.Error(c, http.StatusBadRequest, errors.ErrAccountError.Error(), "currency not found")
+		return
+	}
+
+	walletAccount, err := h.accountService.OpenAccount(userId, req.CurrencyCode)
+	if err != nil {
+		accountError(c, err)
+		return
+	}
+
+	helper.Success(c, walletAccount)
+}
+
+// Close godoc
+// @Summary Close Account
+// @Description Close an account of the user, only an account without balance and holds which is not frozen can be closed
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request body AccountRequest true "body params"
+// @Success 200 {object} helper.Response "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Conflict"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/close [post]
+func (h *accountHandler) Close(c *gin.Context) {
+	req, userId, ok := h.bindAccountRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.accountService.CloseAccount(userId, req.CurrencyCode); err != nil {
+		accountError(c, err)
+		return
+	}
+
+	helper.Success(c, nil)
+}
+
+func (h *accountHandler) bindAccountRequest(c *gin.Context) (AccountRequest, uint, bool) {
+	var req AccountRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return req, 0, false
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return req, 0, false
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return req, 0, false
+	}
+
+	return req, userId, true
+}
+
+// Freeze godoc
+// @Summary Freeze Account
+// @Description Reject the debits and credits of an account of a user until it is unfrozen, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of an admin user."
+// @Param user_id path int true "ID of the user"
+// @Param currency path string true "Currency code of the account"
+// @Success 200 {object} helper.Response{data=WalletAccount} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/account/{user_id}/{currency}/freeze [post]
+func (h *accountHandler) Freeze(c *gin.Context) {
+	h.setFrozen(c, h.accountService.FreezeAccount)
+}
+
+// Unfreeze godoc
+// @Summary Unfreeze Account
+// @Description Accept the debits and credits of a frozen account again, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of an admin user."
+// @Param user_id path int true "ID of the user"
+// @Param currency path string true "Currency code of the account"
+// @Success 200 {object} helper.Response{data=WalletAccount} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/account/{user_id}/{currency}/unfreeze [post]
+func (h *accountHandler) Unfreeze(c *gin.Context) {
+	h.setFrozen(c, h.accountService.UnfreezeAccount)
+}
+
+func (h *accountHandler) setFrozen(c *gin.Context, setFrozen func(userId uint, currencyCode string) (*WalletAccount, error)) {
+	userId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrAccountError.Error(), "invalid user id")
+		return
+	}
+
+	walletAccount, err := setFrozen(uint(userId), c.Param("currency"))
+	if err != nil {
+		accountError(c, err)
+		return
+	}
+
+	helper.Success(c, walletAccount)
+}
+
+func accountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrAccountNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrAccountExists):
+		helper.Error(c, http.StatusConflict, errors.ErrAccountExistsError.Error(), err.Error())
+	case errors.Is(err, ErrAccountNotEmpty):
+		helper.Error(c, http.StatusConflict, errors.ErrAccountError.Error(), err.Error())
+	case errors.Is(err, ErrAccountFrozen):
+		helper.Error(c, http.StatusConflict, errors.ErrAccountFrozenError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrAccountError.Error(), err.Error())
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestAccountHandler_List(t *testing.T) {
@@ -42,15 +44,15 @@ func TestAccountHandler_List(t *testing.T) {
 		walletAccounts := []WalletAccount{
 			{
 				CurrencyCode: "TRY",
-				Balance:      50,
+				Balance:      decimal.NewFromInt(50),
 			},
 			{
 				CurrencyCode: "EUR",
-				Balance:      35,
+				Balance:      decimal.NewFromInt(35),
 			},
 			{
 				CurrencyCode: "USD",
-				Balance:      60,
+				Balance:      decimal.NewFromInt(60),
 			},
 		}
 
@@ -68,3 +70,47 @@ func TestAccountHandler_List(t *testing.T) {
 
 	})
 }
+
+func TestAccountHandler_Transactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAccountService := NewMockIAccountService(ctrl)
+	httpHandler := NewAccountHandler(mockAccountService, currency.Service{})
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uint(1)
+	router.GET("/transactions", func(c *gin.Context) {
+		c.Set("user_id", userId)
+		httpHandler.Transactions(c)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/transactions?start=yesterday", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		mockAccountService.EXPECT().ListUserTransactions(userId, TransactionListRequest{Type: "REFUND"}).
+			Return(nil, ErrInvalidTransactionFilter)
+		req, _ := http.NewRequest(http.MethodGet, "/transactions?type=REFUND", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("successfully list user transactions", func(t *testing.T) {
+		start := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+		mockAccountService.EXPECT().ListUserTransactions(userId, gomock.Any()).
+			DoAndReturn(func(_ uint, request TransactionListRequest) (*TransactionListResponse, error) {
+				assert.Equal(t, "USD", request.CurrencyCode)
+				assert.True(t, request.Start.Equal(start))
+				assert.Equal(t, 5, request.Limit)
+				return &TransactionListResponse{Transactions: []Transaction{}}, nil
+			})
+		req, _ := http.NewRequest(http.MethodGet, "/transactions?currency=USD&start=2022-12-01T00:00:00Z&limit=5", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,181 @@
+package account
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+)
+
+var (
+	ErrInvalidHold  = errors.New("invalid hold")
+	ErrHoldNotFound = errors.New("hold not found")
+	ErrHoldClosed   = errors.New("hold is already closed")
+)
+
+// CreateHold earmarks an amount of the available balance for a pending operation. The amount stays on the account
+// but can not be spent until the hold is released or captured.
+func (s *accountService) CreateHold(userId uint, currencyCode string, amount decimal.Decimal, referenceType string, referenceId uint) (*Hold, error) {
+	currencyCode = strings.ToUpper(currencyCode)
+	amount = currency.Round(amount, currencyCode)
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidHold)
+	}
+
+	var created *Hold
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		account, err := txService.lockAccount(userId, currencyCode)
+		if err != nil {
+			return err
+		}
+
+		if account.FrozenAt != nil {
+			return fmt.Errorf("%w: %s account of user %d", ErrAccountFrozen, currencyCode, userId)
+		}
+
+		if account.availableBalance().LessThan(amount) {
+			return ErrNotEnoughBalance
+		}
+
+		now := time.Now()
+		if created, err = txService.accountRepo.CreateHold(Hold{
+			UserId:         userId,
+			CurrencyCode:   currencyCode,
+			Amount:         amount,
+			Status:         HoldStatusActive,
+			ReferenceType:  referenceType,
+			ReferenceId:    referenceId,
+			CapturedAmount: decimal.Zero,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}); err != nil {
+			return err
+		}
+
+		return txService.accountRepo.UpdateUserHeldBalance(userId, currencyCode, account.HeldBalance.Add(amount))
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ReleaseHold gives the held amount back to the available balance, e.g. when the pending operation is cancelled
+func (s *accountService) ReleaseHold(holdId uint) (*Hold, error) {
+	var released *Hold
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		hold, err := txService.closeHold(holdId)
+		if err != nil {
+			return err
+		}
+
+		hold.Status = HoldStatusReleased
+		if err = txService.accountRepo.UpdateHold(*hold); err != nil {
+			return err
+		}
+
+		released = hold
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return released, nil
+}
+
+// CaptureHold spends the held amount through the journal entry in one transaction. The entry may debit the held
+// account up to the held amount, the rest of the hold is released.
+func (s *accountService) CaptureHold(holdId uint, entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+	var recorded *ledger.JournalEntry
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		hold, err := txService.closeHold(holdId)
+		if err != nil {
+			return err
+		}
+
+		captured := decimal.Zero
+		for _, posting := range entry.Postings {
+			if posting.AccountKind == ledger.AccountKindUser && posting.UserId == hold.UserId && posting.CurrencyCode == hold.CurrencyCode {
+				captured = captured.Sub(posting.SignedAmount())
+			}
+		}
+
+		if !captured.IsPositive() || captured.GreaterThan(hold.Amount) {
+			return fmt.Errorf("%w: entry has to debit the held %s account up to %s", ErrInvalidHold, hold.CurrencyCode, hold.Amount.String())
+		}
+
+		if recorded, err = txService.applyJournalEntry(entry, hold); err != nil {
+			return err
+		}
+
+		hold.Status = HoldStatusCaptured
+		hold.CapturedAmount = captured
+		return txService.accountRepo.UpdateHold(*hold)
+	}); err != nil {
+		return nil, err
+	}
+
+	return recorded, nil
+}
+
+// heldBeforeFreeze reports whether the hold is on the frozen account and was created before the freeze. Such a hold
+// is still captured, the user set the amount aside before the freeze and the pending operation may have finished
+// already, e.g. a withdrawal the gateway paid out. Other entries are rejected on a frozen account.
+func (h *Hold) heldBeforeFreeze(account Account) bool {
+	return account.FrozenAt != nil && h.UserId == account.UserId && h.CurrencyCode == account.CurrencyCode && h.CreatedAt.Before(*account.FrozenAt)
+}
+
+// closeHold locks an active hold and takes its amount off the held balance, it has to run inside a transaction
+func (s *accountService) closeHold(holdId uint) (*Hold, error) {
+	hold, err := s.accountRepo.GetHoldForUpdate(holdId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrHoldNotFound, holdId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.Status != HoldStatusActive {
+		return nil, fmt.Errorf("%w: hold %d is %s", ErrHoldClosed, hold.Id, hold.Status)
+	}
+
+	account, err := s.lockAccount(hold.UserId, hold.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.accountRepo.UpdateUserHeldBalance(hold.UserId, hold.CurrencyCode, account.HeldBalance.Sub(hold.Amount)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hold.ClosedAt = &now
+	hold.UpdatedAt = now
+	return hold, nil
+}
+
+func (s *accountService) lockAccount(userId uint, currencyCode string) (*Account, error) {
+	accounts, err := s.accountRepo.GetUserAccountsForUpdate(userId, []string{currencyCode})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("%s %w", currencyCode, ErrAccountNotFound)
+	}
+
+	return &accounts[0], nil
+}
@@ -0,0 +1,160 @@
+package account
+
+import (
+	// Go imports
+	"testing"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+func newTestHoldService(t *testing.T) (IAccountService, *MockIAccountRepository, *ledger.MockILedgerService) {
+	ctrl := gomock.NewController(t)
+	mockAccountRepository := NewMockIAccountRepository(ctrl)
+	mockLedgerService := ledger.NewMockILedgerService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+
+	mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	mockAccountRepository.EXPECT().WithTx(gomock.Any()).Return(mockAccountRepository).AnyTimes()
+	mockLedgerService.EXPECT().WithTx(gomock.Any()).Return(mockLedgerService).AnyTimes()
+
+	return NewAccountService(mockAccountRepository, mockLedgerService, mockUnitOfWork, config.Config{}), mockAccountRepository, mockLedgerService
+}
+
+func TestAccountService_CreateHold(t *testing.T) {
+	accService, mockAccountRepository, _ := newTestHoldService(t)
+	userId := uint(1)
+
+	t.Run("amount is not positive", func(t *testing.T) {
+		_, err := accService.CreateHold(userId, "TRY", decimal.Zero, "LIMIT_ORDER", 2)
+		assert.ErrorIs(t, err, ErrInvalidHold)
+	})
+
+	t.Run("not enough available balance", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(100), HeldBalance: decimal.NewFromInt(60)}}, nil)
+		_, err := accService.CreateHold(userId, "try", decimal.NewFromInt(50), "LIMIT_ORDER", 2)
+		assert.ErrorIs(t, err, ErrNotEnoughBalance)
+	})
+
+	t.Run("amount is held", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(100), HeldBalance: decimal.NewFromInt(10)}}, nil)
+		mockAccountRepository.EXPECT().CreateHold(gomock.Any()).DoAndReturn(func(hold Hold) (*Hold, error) {
+			assert.Equal(t, HoldStatusActive, hold.Status)
+			assert.True(t, hold.Amount.Equal(decimal.RequireFromString("50.13")))
+			hold.Id = 4
+			return &hold, nil
+		})
+		mockAccountRepository.EXPECT().UpdateUserHeldBalance(userId, "TRY", gomock.Any()).DoAndReturn(func(_ uint, _ string, heldBalance decimal.Decimal) error {
+			assert.True(t, heldBalance.Equal(decimal.RequireFromString("60.13")))
+			return nil
+		})
+
+		hold, err := accService.CreateHold(userId, "TRY", decimal.RequireFromString("50.129"), "LIMIT_ORDER", 2)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(4), hold.Id)
+	})
+}
+
+func TestAccountService_ReleaseHold(t *testing.T) {
+	accService, mockAccountRepository, _ := newTestHoldService(t)
+	userId := uint(1)
+
+	t.Run("hold not found", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetHoldForUpdate(uint(9)).Return(nil, gorm.ErrRecordNotFound)
+		_, err := accService.ReleaseHold(9)
+		assert.ErrorIs(t, err, ErrHoldNotFound)
+	})
+
+	t.Run("hold is closed", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetHoldForUpdate(uint(4)).Return(&Hold{Id: 4, Status: HoldStatusCaptured}, nil)
+		_, err := accService.ReleaseHold(4)
+		assert.ErrorIs(t, err, ErrHoldClosed)
+	})
+
+	t.Run("amount is given back", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetHoldForUpdate(uint(4)).
+			Return(&Hold{Id: 4, UserId: userId, CurrencyCode: "TRY", Amount: decimal.NewFromInt(50), Status: HoldStatusActive}, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(100), HeldBalance: decimal.NewFromInt(60)}}, nil)
+		mockAccountRepository.EXPECT().UpdateUserHeldBalance(userId, "TRY", gomock.Any()).DoAndReturn(func(_ uint, _ string, heldBalance decimal.Decimal) error {
+			assert.True(t, heldBalance.Equal(decimal.NewFromInt(10)))
+			return nil
+		})
+		mockAccountRepository.EXPECT().UpdateHold(gomock.Any()).DoAndReturn(func(hold Hold) error {
+			assert.Equal(t, HoldStatusReleased, hold.Status)
+			assert.NotNil(t, hold.ClosedAt)
+			return nil
+		})
+
+		hold, err := accService.ReleaseHold(4)
+		assert.Nil(t, err)
+		assert.Equal(t, HoldStatusReleased, hold.Status)
+	})
+}
+
+func TestAccountService_CaptureHold(t *testing.T) {
+	accService, mockAccountRepository, mockLedgerService := newTestHoldService(t)
+	userId := uint(1)
+	hold := Hold{Id: 4, UserId: userId, CurrencyCode: "TRY", Amount: decimal.NewFromInt(50), Status: HoldStatusActive}
+
+	expectClose := func() {
+		mockAccountRepository.EXPECT().GetHoldForUpdate(uint(4)).DoAndReturn(func(uint) (*Hold, error) {
+			locked := hold
+			return &locked, nil
+		})
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(100), HeldBalance: decimal.NewFromInt(50)}}, nil)
+		mockAccountRepository.EXPECT().UpdateUserHeldBalance(userId, "TRY", gomock.Any()).Return(nil)
+	}
+
+	t.Run("entry debits more than held", func(t *testing.T) {
+		expectClose()
+		entry := ledger.NewWithdrawalEntry(userId, 5, "TRY", decimal.NewFromInt(60))
+		_, err := accService.CaptureHold(4, entry)
+		assert.ErrorIs(t, err, ErrInvalidHold)
+	})
+
+	t.Run("part of the held amount is spent", func(t *testing.T) {
+		expectClose()
+		entry := ledger.NewWithdrawalEntry(userId, 5, "TRY", decimal.NewFromInt(40))
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		// The held amount is off the held balance by the time the entry debits the account
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(100)}}, nil)
+		mockLedgerService.EXPECT().GetUserBalance(userId, "TRY").Return(decimal.NewFromInt(60), nil)
+		mockAccountRepository.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, "TRY", gomock.Any()).Return(nil)
+		mockAccountRepository.EXPECT().UpdateHold(gomock.Any()).DoAndReturn(func(hold Hold) error {
+			assert.Equal(t, HoldStatusCaptured, hold.Status)
+			assert.True(t, hold.CapturedAmount.Equal(decimal.NewFromInt(40)))
+			return nil
+		})
+
+		_, err := accService.CaptureHold(4, entry)
+		assert.Nil(t, err)
+	})
+}
+
+func TestAccountService_ApplyJournalEntry_HeldBalance(t *testing.T) {
+	accService, mockAccountRepository, mockLedgerService := newTestHoldService(t)
+	userId := uint(1)
+	entry := ledger.NewWithdrawalEntry(userId, 5, "TRY", decimal.NewFromInt(60))
+
+	mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+	mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+		Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(100), HeldBalance: decimal.NewFromInt(50)}}, nil)
+
+	_, err := accService.ApplyJournalEntry(entry)
+	assert.Equal(t, ErrNotEnoughBalance, err)
+}
@@ -0,0 +1,93 @@
+package account
+
+import (
+	// Go imports
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"gorm.io/gorm"
+)
+
+// OpenAccount opens an empty account of the user in the currency, a closed account is opened again
+func (s *accountService) OpenAccount(userId uint, currencyCode string) (*WalletAccount, error) {
+	currencyCode = strings.ToUpper(currencyCode)
+	if s.accountRepo.IsUserHasAccountOnGivenCurrency(userId, currencyCode) {
+		return nil, fmt.Errorf("%w: %s", ErrAccountExists, currencyCode)
+	}
+
+	account, err := s.CreateUserAccount(userId, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	walletAccount := account.toWalletAccount()
+	return &walletAccount, nil
+}
+
+// CloseAccount soft deletes an empty account of the user, its history stays on the ledger.
+// An account with funds on hold for an open limit order or a pending withdrawal is not empty, the hold is released
+// or captured on the account later. A frozen account can not be closed so it can not escape the freeze by being
+// opened again.
+func (s *accountService) CloseAccount(userId uint, currencyCode string) error {
+	currencyCode = strings.ToUpper(currencyCode)
+	return s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		account, err := txService.lockAccount(userId, currencyCode)
+		if err != nil {
+			return err
+		}
+
+		if account.FrozenAt != nil {
+			return fmt.Errorf("%w: %s account of user %d", ErrAccountFrozen, currencyCode, userId)
+		}
+
+		if !account.HeldBalance.IsZero() {
+			return fmt.Errorf("%w: %s %s is on hold", ErrAccountNotEmpty, account.HeldBalance.String(), currencyCode)
+		}
+
+		if !account.Balance.IsZero() {
+			return fmt.Errorf("%w: %s balance is %s", ErrAccountNotEmpty, currencyCode, account.Balance.String())
+		}
+
+		return txService.accountRepo.CloseAccount(userId, currencyCode)
+	})
+}
+
+// FreezeAccount rejects the debits and credits of the account until it is unfrozen, freezing a frozen account changes
+// nothing. Holds are still released, and holds created before the freeze are still captured.
+func (s *accountService) FreezeAccount(userId uint, currencyCode string) (*WalletAccount, error) {
+	now := time.Now()
+	return s.setFrozenAt(userId, currencyCode, &now)
+}
+
+func (s *accountService) UnfreezeAccount(userId uint, currencyCode string) (*WalletAccount, error) {
+	return s.setFrozenAt(userId, currencyCode, nil)
+}
+
+func (s *accountService) setFrozenAt(userId uint, currencyCode string, frozenAt *time.Time) (*WalletAccount, error) {
+	currencyCode = strings.ToUpper(currencyCode)
+	var walletAccount WalletAccount
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		account, err := txService.lockAccount(userId, currencyCode)
+		if err != nil {
+			return err
+		}
+
+		if (account.FrozenAt != nil) != (frozenAt != nil) {
+			if err = txService.accountRepo.UpdateAccountFrozenAt(userId, currencyCode, frozenAt); err != nil {
+				return err
+			}
+			account.FrozenAt = frozenAt
+		}
+
+		walletAccount = account.toWalletAccount()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &walletAccount, nil
+}
@@ -0,0 +1,121 @@
+package account
+
+import (
+	// Go imports
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+func TestAccountHandler_OpenClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAccountService := NewMockIAccountService(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	currencyService.Cache.Set("EUR", "EUR", cache.NoExpiration)
+	httpHandler := NewAccountHandler(mockAccountService, currencyService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uint(1)
+	withUser := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set("user_id", userId)
+			handler(c)
+		}
+	}
+	router.POST("/open", withUser(httpHandler.Open))
+	router.POST("/close", withUser(httpHandler.Close))
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("currency is missing", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post("/open", `{}`).Code)
+	})
+
+	t.Run("currency is not supported", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post("/open", `{"currency_code":"ABC"}`).Code)
+	})
+
+	t.Run("account is opened", func(t *testing.T) {
+		mockAccountService.EXPECT().OpenAccount(userId, "eur").
+			Return(&WalletAccount{CurrencyCode: "EUR", Balance: decimal.Zero}, nil)
+		w := post("/open", `{"currency_code":"eur"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"currency_code":"EUR"`)
+	})
+
+	t.Run("account already exists", func(t *testing.T) {
+		mockAccountService.EXPECT().OpenAccount(userId, "EUR").Return(nil, fmt.Errorf("%w: EUR", ErrAccountExists))
+		assert.Equal(t, http.StatusConflict, post("/open", `{"currency_code":"EUR"}`).Code)
+	})
+
+	t.Run("account is closed", func(t *testing.T) {
+		mockAccountService.EXPECT().CloseAccount(userId, "EUR").Return(nil)
+		assert.Equal(t, http.StatusOK, post("/close", `{"currency_code":"EUR"}`).Code)
+	})
+
+	t.Run("account is not empty", func(t *testing.T) {
+		mockAccountService.EXPECT().CloseAccount(userId, "EUR").Return(fmt.Errorf("%w: EUR balance is 5", ErrAccountNotEmpty))
+		assert.Equal(t, http.StatusConflict, post("/close", `{"currency_code":"EUR"}`).Code)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		mockAccountService.EXPECT().CloseAccount(userId, "EUR").Return(fmt.Errorf("EUR %w", ErrAccountNotFound))
+		assert.Equal(t, http.StatusNotFound, post("/close", `{"currency_code":"EUR"}`).Code)
+	})
+}
+
+func TestAccountHandler_Freeze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAccountService := NewMockIAccountService(ctrl)
+	httpHandler := NewAccountHandler(mockAccountService, currency.Service{})
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	httpHandler.AdminRoutes(router.Group("/admin/account"))
+
+	post := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("invalid user id", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post("/admin/account/abc/EUR/freeze").Code)
+	})
+
+	t.Run("account is frozen", func(t *testing.T) {
+		mockAccountService.EXPECT().FreezeAccount(uint(2), "EUR").Return(&WalletAccount{CurrencyCode: "EUR", Frozen: true}, nil)
+		w := post("/admin/account/2/EUR/freeze")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"frozen":true`)
+	})
+
+	t.Run("account is unfrozen", func(t *testing.T) {
+		mockAccountService.EXPECT().UnfreezeAccount(uint(2), "EUR").Return(&WalletAccount{CurrencyCode: "EUR"}, nil)
+		assert.Equal(t, http.StatusOK, post("/admin/account/2/EUR/unfreeze").Code)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		mockAccountService.EXPECT().FreezeAccount(uint(2), "GBP").Return(nil, fmt.Errorf("GBP %w", ErrAccountNotFound))
+		assert.Equal(t, http.StatusNotFound, post("/admin/account/2/GBP/freeze").Code)
+	})
+}
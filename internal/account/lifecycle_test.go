@@ -0,0 +1,229 @@
+package account
+
+import (
+	// Go imports
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+)
+
+func TestAccountService_OpenAccount(t *testing.T) {
+	accService, mockAccountRepository, _ := newTestHoldService(t)
+	userId := uint(1)
+
+	t.Run("account already exists", func(t *testing.T) {
+		mockAccountRepository.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "EUR").Return(true)
+		_, err := accService.OpenAccount(userId, "eur")
+		assert.ErrorIs(t, err, ErrAccountExists)
+	})
+
+	t.Run("closed account is opened again", func(t *testing.T) {
+		mockAccountRepository.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "EUR").Return(false)
+		mockAccountRepository.EXPECT().GetClosedUserAccount(userId, "EUR").
+			Return(&Account{CurrencyCode: "EUR", UserId: userId, Balance: decimal.Zero, HeldBalance: decimal.Zero}, nil)
+		mockAccountRepository.EXPECT().RestoreAccount(userId, "EUR").Return(nil)
+
+		walletAccount, err := accService.OpenAccount(userId, "EUR")
+		assert.Nil(t, err)
+		assert.Equal(t, "EUR", walletAccount.CurrencyCode)
+		assert.True(t, walletAccount.Balance.IsZero())
+	})
+
+	t.Run("new account is created", func(t *testing.T) {
+		mockAccountRepository.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "EUR").Return(false)
+		mockAccountRepository.EXPECT().GetClosedUserAccount(userId, "EUR").Return(nil, gorm.ErrRecordNotFound)
+		mockAccountRepository.EXPECT().CreateAccount(gomock.Any()).DoAndReturn(func(account Account) (*Account, error) {
+			assert.Equal(t, "EUR", account.CurrencyCode)
+			assert.True(t, account.Balance.IsZero())
+			return &account, nil
+		})
+
+		walletAccount, err := accService.OpenAccount(userId, "EUR")
+		assert.Nil(t, err)
+		assert.Equal(t, "EUR", walletAccount.CurrencyCode)
+		assert.False(t, walletAccount.Frozen)
+	})
+}
+
+func TestAccountService_CloseAccount(t *testing.T) {
+	accService, mockAccountRepository, _ := newTestHoldService(t)
+	userId := uint(1)
+	frozenAt := time.Now()
+
+	t.Run("account not found", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).Return(nil, nil)
+		err := accService.CloseAccount(userId, "EUR")
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+
+	t.Run("account has balance", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.RequireFromString("0.01")}}, nil)
+		err := accService.CloseAccount(userId, "EUR")
+		assert.ErrorIs(t, err, ErrAccountNotEmpty)
+	})
+
+	t.Run("account has funds on hold", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(100), HeldBalance: decimal.NewFromInt(100)}}, nil)
+		err := accService.CloseAccount(userId, "EUR")
+		assert.ErrorIs(t, err, ErrAccountNotEmpty)
+		assert.Contains(t, err.Error(), "on hold")
+	})
+
+	t.Run("account is frozen", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.Zero, FrozenAt: &frozenAt}}, nil)
+		err := accService.CloseAccount(userId, "EUR")
+		assert.ErrorIs(t, err, ErrAccountFrozen)
+	})
+
+	t.Run("empty account is closed", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.Zero}}, nil)
+		mockAccountRepository.EXPECT().CloseAccount(userId, "EUR").Return(nil)
+		err := accService.CloseAccount(userId, "eur")
+		assert.Nil(t, err)
+	})
+}
+
+func TestAccountService_FreezeAccount(t *testing.T) {
+	accService, mockAccountRepository, _ := newTestHoldService(t)
+	userId := uint(1)
+	frozenAt := time.Now()
+
+	t.Run("account is frozen", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10)}}, nil)
+		mockAccountRepository.EXPECT().UpdateAccountFrozenAt(userId, "EUR", gomock.Not(gomock.Nil())).Return(nil)
+
+		walletAccount, err := accService.FreezeAccount(userId, "eur")
+		assert.Nil(t, err)
+		assert.True(t, walletAccount.Frozen)
+	})
+
+	t.Run("frozen account is not frozen again", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), FrozenAt: &frozenAt}}, nil)
+
+		walletAccount, err := accService.FreezeAccount(userId, "EUR")
+		assert.Nil(t, err)
+		assert.True(t, walletAccount.Frozen)
+	})
+
+	t.Run("account is unfrozen", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), FrozenAt: &frozenAt}}, nil)
+		mockAccountRepository.EXPECT().UpdateAccountFrozenAt(userId, "EUR", nil).Return(nil)
+
+		walletAccount, err := accService.UnfreezeAccount(userId, "EUR")
+		assert.Nil(t, err)
+		assert.False(t, walletAccount.Frozen)
+	})
+}
+
+func TestAccountService_ApplyJournalEntry_FrozenAccount(t *testing.T) {
+	accService, mockAccountRepository, mockLedgerService := newTestHoldService(t)
+	userId := uint(1)
+	frozenAt := time.Now()
+	expectFrozenAccount := func() {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), FrozenAt: &frozenAt}}, nil)
+	}
+
+	t.Run("deposit is rejected", func(t *testing.T) {
+		entry := ledger.NewDepositEntry(userId, 3, "EUR", decimal.NewFromInt(5))
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		expectFrozenAccount()
+
+		_, err := accService.ApplyJournalEntry(entry)
+		assert.ErrorIs(t, err, ErrAccountFrozen)
+	})
+
+	t.Run("withdrawal without a hold is rejected", func(t *testing.T) {
+		entry := ledger.NewWithdrawalEntry(userId, 4, "EUR", decimal.NewFromInt(5))
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		expectFrozenAccount()
+
+		_, err := accService.ApplyJournalEntry(entry)
+		assert.ErrorIs(t, err, ErrAccountFrozen)
+	})
+}
+
+func TestAccountService_CaptureHold_FrozenAccount(t *testing.T) {
+	accService, mockAccountRepository, mockLedgerService := newTestHoldService(t)
+	userId := uint(1)
+	frozenAt := time.Now()
+	expectFrozenClose := func(createdAt time.Time) {
+		mockAccountRepository.EXPECT().GetHoldForUpdate(uint(4)).
+			Return(&Hold{Id: 4, UserId: userId, CurrencyCode: "EUR", Amount: decimal.NewFromInt(5), Status: HoldStatusActive, CreatedAt: createdAt}, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), HeldBalance: decimal.NewFromInt(5), FrozenAt: &frozenAt}}, nil)
+		mockAccountRepository.EXPECT().UpdateUserHeldBalance(userId, "EUR", gomock.Any()).Return(nil)
+	}
+
+	t.Run("hold created before the freeze is captured", func(t *testing.T) {
+		expectFrozenClose(frozenAt.Add(-time.Hour))
+		entry := ledger.NewWithdrawalEntry(userId, 4, "EUR", decimal.NewFromInt(5))
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), FrozenAt: &frozenAt}}, nil)
+		mockLedgerService.EXPECT().GetUserBalance(userId, "EUR").Return(decimal.NewFromInt(5), nil)
+		mockAccountRepository.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, "EUR", gomock.Any()).Return(nil)
+		mockAccountRepository.EXPECT().UpdateHold(gomock.Any()).Return(nil)
+
+		_, err := accService.CaptureHold(4, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("hold created after the freeze is not captured", func(t *testing.T) {
+		expectFrozenClose(frozenAt.Add(time.Hour))
+		entry := ledger.NewWithdrawalEntry(userId, 4, "EUR", decimal.NewFromInt(5))
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), FrozenAt: &frozenAt}}, nil)
+
+		_, err := accService.CaptureHold(4, entry)
+		assert.ErrorIs(t, err, ErrAccountFrozen)
+	})
+
+	t.Run("other frozen account of the entry is rejected", func(t *testing.T) {
+		expectFrozenClose(frozenAt.Add(-time.Hour))
+		entry := ledger.NewConversionEntry(userId, 9, "EUR", "TRY", decimal.NewFromInt(5), decimal.NewFromInt(100))
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+			Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), FrozenAt: &frozenAt}}, nil)
+		mockLedgerService.EXPECT().GetUserBalance(userId, "EUR").Return(decimal.NewFromInt(5), nil)
+		mockAccountRepository.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, "EUR", gomock.Any()).Return(nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, FrozenAt: &frozenAt}}, nil)
+
+		_, err := accService.CaptureHold(4, entry)
+		assert.ErrorIs(t, err, ErrAccountFrozen)
+	})
+}
+
+func TestAccountService_ReleaseHold_FrozenAccount(t *testing.T) {
+	accService, mockAccountRepository, _ := newTestHoldService(t)
+	userId := uint(1)
+	frozenAt := time.Now()
+
+	mockAccountRepository.EXPECT().GetHoldForUpdate(uint(4)).
+		Return(&Hold{Id: 4, UserId: userId, CurrencyCode: "EUR", Amount: decimal.NewFromInt(5), Status: HoldStatusActive}, nil)
+	mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"EUR"}).
+		Return([]Account{{CurrencyCode: "EUR", UserId: userId, Balance: decimal.NewFromInt(10), HeldBalance: decimal.NewFromInt(5), FrozenAt: &frozenAt}}, nil)
+	mockAccountRepository.EXPECT().UpdateUserHeldBalance(userId, "EUR", gomock.Any()).Return(nil)
+	mockAccountRepository.EXPECT().UpdateHold(gomock.Any()).Return(nil)
+
+	hold, err := accService.ReleaseHold(4)
+	assert.Nil(t, err)
+	assert.Equal(t, HoldStatusReleased, hold.Status)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/account (interfaces: IDefaultCurrencyResolver)
+
+// Package account is a generated GoMock package.
+package account
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIDefaultCurrencyResolver is a mock of IDefaultCurrencyResolver interface.
+type MockIDefaultCurrencyResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDefaultCurrencyResolverMockRecorder
+}
+
+// MockIDefaultCurrencyResolverMockRecorder is the mock recorder for MockIDefaultCurrencyResolver.
+type MockIDefaultCurrencyResolverMockRecorder struct {
+	mock *MockIDefaultCurrencyResolver
+}
+
+// NewMockIDefaultCurrencyResolver creates a new mock instance.
+func NewMockIDefaultCurrencyResolver(ctrl *gomock.Controller) *MockIDefaultCurrencyResolver {
+	mock := &MockIDefaultCurrencyResolver{ctrl: ctrl}
+	mock.recorder = &MockIDefaultCurrencyResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDefaultCurrencyResolver) EXPECT() *MockIDefaultCurrencyResolverMockRecorder {
+	return m.recorder
+}
+
+// GetUserDefaultCurrency mocks base method.
+func (m *MockIDefaultCurrencyResolver) GetUserDefaultCurrency(arg0 uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDefaultCurrency", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDefaultCurrency indicates an expected call of GetUserDefaultCurrency.
+func (mr *MockIDefaultCurrencyResolverMockRecorder) GetUserDefaultCurrency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDefaultCurrency", reflect.TypeOf((*MockIDefaultCurrencyResolver)(nil).GetUserDefaultCurrency), arg0)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/account (interfaces: IOfferResolver)
+
+// Package account is a generated GoMock package.
+package account
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOfferResolver is a mock of IOfferResolver interface.
+type MockIOfferResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOfferResolverMockRecorder
+}
+
+// MockIOfferResolverMockRecorder is the mock recorder for MockIOfferResolver.
+type MockIOfferResolverMockRecorder struct {
+	mock *MockIOfferResolver
+}
+
+// NewMockIOfferResolver creates a new mock instance.
+func NewMockIOfferResolver(ctrl *gomock.Controller) *MockIOfferResolver {
+	mock := &MockIOfferResolver{ctrl: ctrl}
+	mock.recorder = &MockIOfferResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOfferResolver) EXPECT() *MockIOfferResolverMockRecorder {
+	return m.recorder
+}
+
+// GetUserConversionOffers mocks base method.
+func (m *MockIOfferResolver) GetUserConversionOffers(arg0 uint, arg1 []uint) ([]ConversionOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserConversionOffers", arg0, arg1)
+	ret0, _ := ret[0].([]ConversionOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserConversionOffers indicates an expected call of GetUserConversionOffers.
+func (mr *MockIOfferResolverMockRecorder) GetUserConversionOffers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserConversionOffers", reflect.TypeOf((*MockIOfferResolver)(nil).GetUserConversionOffers), arg0, arg1)
+}
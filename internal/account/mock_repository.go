@@ -6,8 +6,11 @@ package account
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	gorm "gorm.io/gorm"
 )
 
 // MockIAccountRepository is a mock of IAccountRepository interface.
@@ -33,6 +36,20 @@ func (m *MockIAccountRepository) EXPECT() *MockIAccountRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CloseAccount mocks base method.
+func (m *MockIAccountRepository) CloseAccount(arg0 uint, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseAccount indicates an expected call of CloseAccount.
+func (mr *MockIAccountRepositoryMockRecorder) CloseAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAccount", reflect.TypeOf((*MockIAccountRepository)(nil).CloseAccount), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockIAccountRepository) CreateAccount(arg0 Account) (*Account, error) {
 	m.ctrl.T.Helper()
@@ -48,11 +65,71 @@ func (mr *MockIAccountRepositoryMockRecorder) CreateAccount(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockIAccountRepository)(nil).CreateAccount), arg0)
 }
 
+// CreateHold mocks base method.
+func (m *MockIAccountRepository) CreateHold(arg0 Hold) (*Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", arg0)
+	ret0, _ := ret[0].(*Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockIAccountRepositoryMockRecorder) CreateHold(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockIAccountRepository)(nil).CreateHold), arg0)
+}
+
+// GetClosedUserAccount mocks base method.
+func (m *MockIAccountRepository) GetClosedUserAccount(arg0 uint, arg1 string) (*Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedUserAccount", arg0, arg1)
+	ret0, _ := ret[0].(*Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosedUserAccount indicates an expected call of GetClosedUserAccount.
+func (mr *MockIAccountRepositoryMockRecorder) GetClosedUserAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedUserAccount", reflect.TypeOf((*MockIAccountRepository)(nil).GetClosedUserAccount), arg0, arg1)
+}
+
+// GetHoldForUpdate mocks base method.
+func (m *MockIAccountRepository) GetHoldForUpdate(arg0 uint) (*Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldForUpdate", arg0)
+	ret0, _ := ret[0].(*Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldForUpdate indicates an expected call of GetHoldForUpdate.
+func (mr *MockIAccountRepositoryMockRecorder) GetHoldForUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldForUpdate", reflect.TypeOf((*MockIAccountRepository)(nil).GetHoldForUpdate), arg0)
+}
+
+// GetUserAccountsForUpdate mocks base method.
+func (m *MockIAccountRepository) GetUserAccountsForUpdate(arg0 uint, arg1 []string) ([]Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccountsForUpdate", arg0, arg1)
+	ret0, _ := ret[0].([]Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAccountsForUpdate indicates an expected call of GetUserAccountsForUpdate.
+func (mr *MockIAccountRepositoryMockRecorder) GetUserAccountsForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccountsForUpdate", reflect.TypeOf((*MockIAccountRepository)(nil).GetUserAccountsForUpdate), arg0, arg1)
+}
+
 // GetUserBalanceOnGivenCurrencyAccount mocks base method.
-func (m *MockIAccountRepository) GetUserBalanceOnGivenCurrencyAccount(arg0 uint, arg1 string) (float64, error) {
+func (m *MockIAccountRepository) GetUserBalanceOnGivenCurrencyAccount(arg0 uint, arg1 string) (decimal.Decimal, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserBalanceOnGivenCurrencyAccount", arg0, arg1)
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(decimal.Decimal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -77,6 +154,21 @@ func (mr *MockIAccountRepositoryMockRecorder) IsUserHasAccountOnGivenCurrency(ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserHasAccountOnGivenCurrency", reflect.TypeOf((*MockIAccountRepository)(nil).IsUserHasAccountOnGivenCurrency), arg0, arg1)
 }
 
+// ListAccounts mocks base method.
+func (m *MockIAccountRepository) ListAccounts() ([]Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts")
+	ret0, _ := ret[0].([]Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockIAccountRepositoryMockRecorder) ListAccounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockIAccountRepository)(nil).ListAccounts))
+}
+
 // ListUserAccounts mocks base method.
 func (m *MockIAccountRepository) ListUserAccounts(arg0 uint) ([]Account, error) {
 	m.ctrl.T.Helper()
@@ -106,8 +198,50 @@ func (mr *MockIAccountRepositoryMockRecorder) Migration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockIAccountRepository)(nil).Migration))
 }
 
+// RestoreAccount mocks base method.
+func (m *MockIAccountRepository) RestoreAccount(arg0 uint, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAccount indicates an expected call of RestoreAccount.
+func (mr *MockIAccountRepositoryMockRecorder) RestoreAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAccount", reflect.TypeOf((*MockIAccountRepository)(nil).RestoreAccount), arg0, arg1)
+}
+
+// UpdateAccountFrozenAt mocks base method.
+func (m *MockIAccountRepository) UpdateAccountFrozenAt(arg0 uint, arg1 string, arg2 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountFrozenAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountFrozenAt indicates an expected call of UpdateAccountFrozenAt.
+func (mr *MockIAccountRepositoryMockRecorder) UpdateAccountFrozenAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountFrozenAt", reflect.TypeOf((*MockIAccountRepository)(nil).UpdateAccountFrozenAt), arg0, arg1, arg2)
+}
+
+// UpdateHold mocks base method.
+func (m *MockIAccountRepository) UpdateHold(arg0 Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHold", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHold indicates an expected call of UpdateHold.
+func (mr *MockIAccountRepositoryMockRecorder) UpdateHold(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHold", reflect.TypeOf((*MockIAccountRepository)(nil).UpdateHold), arg0)
+}
+
 // UpdateUserBalanceOnGivenCurrencyAccount mocks base method.
-func (m *MockIAccountRepository) UpdateUserBalanceOnGivenCurrencyAccount(arg0 uint, arg1 string, arg2 float64) error {
+func (m *MockIAccountRepository) UpdateUserBalanceOnGivenCurrencyAccount(arg0 uint, arg1 string, arg2 decimal.Decimal) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateUserBalanceOnGivenCurrencyAccount", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
@@ -119,3 +253,31 @@ func (mr *MockIAccountRepositoryMockRecorder) UpdateUserBalanceOnGivenCurrencyAc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserBalanceOnGivenCurrencyAccount", reflect.TypeOf((*MockIAccountRepository)(nil).UpdateUserBalanceOnGivenCurrencyAccount), arg0, arg1, arg2)
 }
+
+// UpdateUserHeldBalance mocks base method.
+func (m *MockIAccountRepository) UpdateUserHeldBalance(arg0 uint, arg1 string, arg2 decimal.Decimal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserHeldBalance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserHeldBalance indicates an expected call of UpdateUserHeldBalance.
+func (mr *MockIAccountRepositoryMockRecorder) UpdateUserHeldBalance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserHeldBalance", reflect.TypeOf((*MockIAccountRepository)(nil).UpdateUserHeldBalance), arg0, arg1, arg2)
+}
+
+// WithTx mocks base method.
+func (m *MockIAccountRepository) WithTx(arg0 *gorm.DB) IAccountRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IAccountRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIAccountRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIAccountRepository)(nil).WithTx), arg0)
+}
@@ -8,6 +8,9 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	ledger "github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	decimal "github.com/shopspring/decimal"
+	gorm "gorm.io/gorm"
 )
 
 // MockIAccountService is a mock of IAccountService interface.
@@ -33,26 +36,100 @@ func (m *MockIAccountService) EXPECT() *MockIAccountServiceMockRecorder {
 	return m.recorder
 }
 
+// ApplyJournalEntry mocks base method.
+func (m *MockIAccountService) ApplyJournalEntry(arg0 ledger.JournalEntry) (*ledger.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyJournalEntry", arg0)
+	ret0, _ := ret[0].(*ledger.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyJournalEntry indicates an expected call of ApplyJournalEntry.
+func (mr *MockIAccountServiceMockRecorder) ApplyJournalEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyJournalEntry", reflect.TypeOf((*MockIAccountService)(nil).ApplyJournalEntry), arg0)
+}
+
+// CaptureHold mocks base method.
+func (m *MockIAccountService) CaptureHold(arg0 uint, arg1 ledger.JournalEntry) (*ledger.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", arg0, arg1)
+	ret0, _ := ret[0].(*ledger.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockIAccountServiceMockRecorder) CaptureHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockIAccountService)(nil).CaptureHold), arg0, arg1)
+}
+
+// CloseAccount mocks base method.
+func (m *MockIAccountService) CloseAccount(arg0 uint, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseAccount indicates an expected call of CloseAccount.
+func (mr *MockIAccountServiceMockRecorder) CloseAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAccount", reflect.TypeOf((*MockIAccountService)(nil).CloseAccount), arg0, arg1)
+}
+
+// CreateHold mocks base method.
+func (m *MockIAccountService) CreateHold(arg0 uint, arg1 string, arg2 decimal.Decimal, arg3 string, arg4 uint) (*Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockIAccountServiceMockRecorder) CreateHold(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockIAccountService)(nil).CreateHold), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CreateUserAccount mocks base method.
-func (m *MockIAccountService) CreateUserAccount(arg0 uint, arg1 string, arg2 bool) (*Account, error) {
+func (m *MockIAccountService) CreateUserAccount(arg0 uint, arg1 string) (*Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserAccount", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CreateUserAccount", arg0, arg1)
 	ret0, _ := ret[0].(*Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUserAccount indicates an expected call of CreateUserAccount.
-func (mr *MockIAccountServiceMockRecorder) CreateUserAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockIAccountServiceMockRecorder) CreateUserAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserAccount", reflect.TypeOf((*MockIAccountService)(nil).CreateUserAccount), arg0, arg1)
+}
+
+// FreezeAccount mocks base method.
+func (m *MockIAccountService) FreezeAccount(arg0 uint, arg1 string) (*WalletAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeAccount", arg0, arg1)
+	ret0, _ := ret[0].(*WalletAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeAccount indicates an expected call of FreezeAccount.
+func (mr *MockIAccountServiceMockRecorder) FreezeAccount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserAccount", reflect.TypeOf((*MockIAccountService)(nil).CreateUserAccount), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeAccount", reflect.TypeOf((*MockIAccountService)(nil).FreezeAccount), arg0, arg1)
 }
 
 // GetUserBalanceOnGivenCurrencyAccount mocks base method.
-func (m *MockIAccountService) GetUserBalanceOnGivenCurrencyAccount(arg0 uint, arg1 string) (float64, error) {
+func (m *MockIAccountService) GetUserBalanceOnGivenCurrencyAccount(arg0 uint, arg1 string) (decimal.Decimal, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserBalanceOnGivenCurrencyAccount", arg0, arg1)
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(decimal.Decimal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -92,16 +169,110 @@ func (mr *MockIAccountServiceMockRecorder) ListUserAccounts(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAccounts", reflect.TypeOf((*MockIAccountService)(nil).ListUserAccounts), arg0)
 }
 
-// UpdateUserBalanceOnGivenCurrencyAccount mocks base method.
-func (m *MockIAccountService) UpdateUserBalanceOnGivenCurrencyAccount(arg0 uint, arg1 string, arg2 float64) error {
+// ListUserTransactions mocks base method.
+func (m *MockIAccountService) ListUserTransactions(arg0 uint, arg1 TransactionListRequest) (*TransactionListResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserBalanceOnGivenCurrencyAccount", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ListUserTransactions", arg0, arg1)
+	ret0, _ := ret[0].(*TransactionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserTransactions indicates an expected call of ListUserTransactions.
+func (mr *MockIAccountServiceMockRecorder) ListUserTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTransactions", reflect.TypeOf((*MockIAccountService)(nil).ListUserTransactions), arg0, arg1)
+}
+
+// LockUserAccounts mocks base method.
+func (m *MockIAccountService) LockUserAccounts(arg0 uint, arg1 ...string) (map[string]decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LockUserAccounts", varargs...)
+	ret0, _ := ret[0].(map[string]decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockUserAccounts indicates an expected call of LockUserAccounts.
+func (mr *MockIAccountServiceMockRecorder) LockUserAccounts(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUserAccounts", reflect.TypeOf((*MockIAccountService)(nil).LockUserAccounts), varargs...)
+}
+
+// OpenAccount mocks base method.
+func (m *MockIAccountService) OpenAccount(arg0 uint, arg1 string) (*WalletAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAccount", arg0, arg1)
+	ret0, _ := ret[0].(*WalletAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenAccount indicates an expected call of OpenAccount.
+func (mr *MockIAccountServiceMockRecorder) OpenAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAccount", reflect.TypeOf((*MockIAccountService)(nil).OpenAccount), arg0, arg1)
+}
+
+// ReconcileLedger mocks base method.
+func (m *MockIAccountService) ReconcileLedger() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileLedger")
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUserBalanceOnGivenCurrencyAccount indicates an expected call of UpdateUserBalanceOnGivenCurrencyAccount.
-func (mr *MockIAccountServiceMockRecorder) UpdateUserBalanceOnGivenCurrencyAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ReconcileLedger indicates an expected call of ReconcileLedger.
+func (mr *MockIAccountServiceMockRecorder) ReconcileLedger() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLedger", reflect.TypeOf((*MockIAccountService)(nil).ReconcileLedger))
+}
+
+// ReleaseHold mocks base method.
+func (m *MockIAccountService) ReleaseHold(arg0 uint) (*Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", arg0)
+	ret0, _ := ret[0].(*Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockIAccountServiceMockRecorder) ReleaseHold(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockIAccountService)(nil).ReleaseHold), arg0)
+}
+
+// UnfreezeAccount mocks base method.
+func (m *MockIAccountService) UnfreezeAccount(arg0 uint, arg1 string) (*WalletAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfreezeAccount", arg0, arg1)
+	ret0, _ := ret[0].(*WalletAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnfreezeAccount indicates an expected call of UnfreezeAccount.
+func (mr *MockIAccountServiceMockRecorder) UnfreezeAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfreezeAccount", reflect.TypeOf((*MockIAccountService)(nil).UnfreezeAccount), arg0, arg1)
+}
+
+// WithTx mocks base method.
+func (m *MockIAccountService) WithTx(arg0 *gorm.DB) IAccountService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IAccountService)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIAccountServiceMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserBalanceOnGivenCurrencyAccount", reflect.TypeOf((*MockIAccountService)(nil).UpdateUserBalanceOnGivenCurrencyAccount), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIAccountService)(nil).WithTx), arg0)
 }
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/account (interfaces: IStatementService)
+
+// Package account is a generated GoMock package.
+package account
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIStatementService is a mock of IStatementService interface.
+type MockIStatementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatementServiceMockRecorder
+}
+
+// MockIStatementServiceMockRecorder is the mock recorder for MockIStatementService.
+type MockIStatementServiceMockRecorder struct {
+	mock *MockIStatementService
+}
+
+// NewMockIStatementService creates a new mock instance.
+func NewMockIStatementService(ctrl *gomock.Controller) *MockIStatementService {
+	mock := &MockIStatementService{ctrl: ctrl}
+	mock.recorder = &MockIStatementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatementService) EXPECT() *MockIStatementServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserStatement mocks base method.
+func (m *MockIStatementService) GetUserStatement(arg0 uint, arg1 StatementRequest) (*Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStatement", arg0, arg1)
+	ret0, _ := ret[0].(*Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStatement indicates an expected call of GetUserStatement.
+func (mr *MockIStatementServiceMockRecorder) GetUserStatement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStatement", reflect.TypeOf((*MockIStatementService)(nil).GetUserStatement), arg0, arg1)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/account (interfaces: ISummaryService)
+
+// Package account is a generated GoMock package.
+package account
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISummaryService is a mock of ISummaryService interface.
+type MockISummaryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISummaryServiceMockRecorder
+}
+
+// MockISummaryServiceMockRecorder is the mock recorder for MockISummaryService.
+type MockISummaryServiceMockRecorder struct {
+	mock *MockISummaryService
+}
+
+// NewMockISummaryService creates a new mock instance.
+func NewMockISummaryService(ctrl *gomock.Controller) *MockISummaryService {
+	mock := &MockISummaryService{ctrl: ctrl}
+	mock.recorder = &MockISummaryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISummaryService) EXPECT() *MockISummaryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserSummary mocks base method.
+func (m *MockISummaryService) GetUserSummary(arg0 uint, arg1 SummaryRequest) (*AccountSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSummary", arg0, arg1)
+	ret0, _ := ret[0].(*AccountSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSummary indicates an expected call of GetUserSummary.
+func (mr *MockISummaryServiceMockRecorder) GetUserSummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSummary", reflect.TypeOf((*MockISummaryService)(nil).GetUserSummary), arg0, arg1)
+}
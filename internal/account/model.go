@@ -5,21 +5,164 @@ import (
 	"time"
 
 	// External imports
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
 // Account Gorm model
 type Account struct {
-	CurrencyCode string `gorm:"primaryKey;autoIncrement:false"`
-	UserId       uint   `gorm:"primaryKey;autoIncrement:false"`
-	Balance      float64
-	CreatedAt    time.Time      `json:"created_at,omitempty"`
-	UpdatedAt    time.Time      `json:"updated_at,omitempty"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	CurrencyCode string          `gorm:"primaryKey;autoIncrement:false"`
+	UserId       uint            `gorm:"primaryKey;autoIncrement:false"`
+	Balance      decimal.Decimal `gorm:"type:numeric;not null"`
+	HeldBalance  decimal.Decimal `gorm:"type:numeric;not null;default:0"` // Part of the balance held for pending operations
+	FrozenAt     *time.Time      // Set while the account is frozen by an admin, debits and credits are rejected
+	CreatedAt    time.Time       `json:"created_at,omitempty"`
+	UpdatedAt    time.Time       `json:"updated_at,omitempty"`
+	DeletedAt    gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// availableBalance is the part of the balance which is not held and can be spent
+func (a Account) availableBalance() decimal.Decimal {
+	return a.Balance.Sub(a.HeldBalance)
+}
+
+// toWalletAccount is the http response of the account
+func (a Account) toWalletAccount() WalletAccount {
+	return WalletAccount{
+		CurrencyCode:     a.CurrencyCode,
+		Balance:          a.Balance,
+		AvailableBalance: a.availableBalance(),
+		HeldBalance:      a.HeldBalance,
+		Frozen:           a.FrozenAt != nil,
+	}
+}
+
+// HoldStatus is the lifecycle state of a hold
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "ACTIVE"   // Amount is held on the account
+	HoldStatusReleased HoldStatus = "RELEASED" // Amount was given back to the available balance
+	HoldStatusCaptured HoldStatus = "CAPTURED" // Amount was spent by a journal entry
+)
+
+// Hold Gorm model, earmarks an amount on an account for a pending operation so it can not be spent elsewhere
+type Hold struct {
+	Id             uint            `gorm:"primaryKey;autoIncrement"`
+	UserId         uint            `gorm:"not null;index"`
+	CurrencyCode   string          `gorm:"not null"`
+	Amount         decimal.Decimal `gorm:"type:numeric;not null"`
+	Status         HoldStatus      `gorm:"type:varchar(16);not null;index"`
+	ReferenceType  string          `gorm:"type:varchar(32)"` // Type of the pending operation, e.g. LIMIT_ORDER
+	ReferenceId    uint            // ID of the pending operation
+	CapturedAmount decimal.Decimal `gorm:"type:numeric;not null;default:0"` // Amount the capturing entry spent, the rest was released
+	ClosedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 // WalletAccount http response
 type WalletAccount struct {
-	CurrencyCode string  `json:"currency_code"`
-	Balance      float64 `json:"balance"`
+	CurrencyCode     string          `json:"currency_code"`
+	Balance          decimal.Decimal `json:"balance" swaggertype:"string" example:"100.50"`          // Total balance including the held amounts
+	AvailableBalance decimal.Decimal `json:"available_balance" swaggertype:"string" example:"80.50"` // Balance which can be spent
+	HeldBalance      decimal.Decimal `json:"held_balance" swaggertype:"string" example:"20.00"`      // Balance held for pending operations
+	Frozen           bool            `json:"frozen" example:"false"`                                 // Debits and credits are rejected while the account is frozen
+}
+
+// AccountRequest http request
+type AccountRequest struct {
+	CurrencyCode string `json:"currency_code" extensions:"x-order=1" example:"EUR" validate:"required" valid:"required~currency_code|invalid"` // Currency of the account
+}
+
+// SummaryRequest http query
+type SummaryRequest struct {
+	CurrencyCode string `form:"currency"` // Currency to value the balances in, default currency of the user when empty
+}
+
+// AccountValuation http response
+type AccountValuation struct {
+	CurrencyCode string          `json:"currency_code" extensions:"x-order=1" example:"USD"`                    // Currency of the account
+	Balance      decimal.Decimal `json:"balance" extensions:"x-order=2" swaggertype:"string" example:"100.00"`  // Total balance including the held amounts
+	MidRate      decimal.Decimal `json:"mid_rate" extensions:"x-order=3" swaggertype:"string" example:"18.634"` // Mid rate from the account currency to the valuation currency
+	Value        decimal.Decimal `json:"value" extensions:"x-order=4" swaggertype:"string" example:"1863.40"`   // Balance in the valuation currency
+	Share        decimal.Decimal `json:"share" extensions:"x-order=5" swaggertype:"string" example:"65.12"`     // Percentage of the net worth
+}
+
+// AccountSummary http response
+type AccountSummary struct {
+	CurrencyCode string             `json:"currency_code" extensions:"x-order=1" example:"TRY"`                      // Valuation currency
+	NetWorth     decimal.Decimal    `json:"net_worth" extensions:"x-order=2" swaggertype:"string" example:"2861.40"` // Total value of the accounts in the valuation currency
+	Accounts     []AccountValuation `json:"accounts" extensions:"x-order=3"`                                         // Accounts by value, largest first
+	ValuedAt     time.Time          `json:"valued_at" extensions:"x-order=4" example:"2022-12-01T10:00:00+03:00"`    // Time the rates were read at
+}
+
+// TransactionListRequest http query
+type TransactionListRequest struct {
+	CurrencyCode string     `form:"currency"`                                      // Only transactions on given currency
+	Type         string     `form:"type"`                                          // REGISTRATION_BONUS, BONUS_CLAWBACK, CONVERSION_DEBIT, CONVERSION_CREDIT, TRANSFER_OUT, TRANSFER_IN, DEPOSIT, WITHDRAWAL or OPENING_BALANCE
+	Start        *time.Time `form:"start" time_format:"2006-01-02T15:04:05Z07:00"` // Inclusive start of the date range in RFC3339
+	End          *time.Time `form:"end" time_format:"2006-01-02T15:04:05Z07:00"`   // Exclusive end of the date range in RFC3339
+	Cursor       string     `form:"cursor"`                                        // Next cursor of the previous page
+	Limit        int        `form:"limit"`                                         // Page size, 20 by default and at most 100
+}
+
+// Transaction http response
+type Transaction struct {
+	Id           uint            `json:"id" extensions:"x-order=1" example:"12"`                                // ID of the ledger posting
+	Type         string          `json:"type" extensions:"x-order=2" example:"CONVERSION_DEBIT"`                // Type of the transaction
+	CurrencyCode string          `json:"currency_code" extensions:"x-order=3" example:"TRY"`                    // Currency of the account
+	Amount       decimal.Decimal `json:"amount" extensions:"x-order=4" swaggertype:"string" example:"-100.00"`  // Change on the balance, negative when money left the account
+	OfferId      *uint           `json:"offer_id,omitempty" extensions:"x-order=5" example:"4"`                 // ID of the originating exchange offer
+	LimitOrderId *uint           `json:"limit_order_id,omitempty" extensions:"x-order=6" example:"2"`           // ID of the originating limit order
+	TransferId   *uint           `json:"transfer_id,omitempty" extensions:"x-order=7" example:"3"`              // ID of the originating transfer
+	PaymentId    *uint           `json:"payment_id,omitempty" extensions:"x-order=8" example:"5"`               // ID of the originating deposit or withdrawal
+	CreatedAt    time.Time       `json:"created_at" extensions:"x-order=9" example:"2022-12-01T10:00:00+03:00"` // Time of the transaction
+}
+
+// TransactionListResponse http response
+type TransactionListResponse struct {
+	Transactions []Transaction `json:"transactions" extensions:"x-order=1"`                       // Transactions newest first
+	NextCursor   string        `json:"next_cursor,omitempty" extensions:"x-order=2" example:"12"` // Cursor of the next page, empty on the last page
+}
+
+// StatementRequest http query
+type StatementRequest struct {
+	CurrencyCode string `form:"currency"` // Currency of the account
+	Month        string `form:"month"`    // Month of the statement as YYYY-MM, the current month by default
+	Format       string `form:"format"`   // csv or pdf, csv by default
+}
+
+// StatementLine is one movement on the account during the statement period
+type StatementLine struct {
+	Id          uint
+	Type        string
+	Reference   string // Originating offer, limit order, transfer or payment
+	Description string
+	Amount      decimal.Decimal // Change on the balance, negative when money left the account
+	Balance     decimal.Decimal // Balance after the movement
+	CreatedAt   time.Time
+}
+
+// Statement lists the movements on one account of the user during a month between its opening and closing balance
+type Statement struct {
+	UserId         uint
+	CurrencyCode   string
+	Month          string
+	Start          time.Time // Inclusive start of the period
+	End            time.Time // Exclusive end of the period
+	OpeningBalance decimal.Decimal
+	TotalCredits   decimal.Decimal
+	TotalDebits    decimal.Decimal
+	ClosingBalance decimal.Decimal
+	Lines          []StatementLine // Movements oldest first
+	GeneratedAt    time.Time
+}
+
+// ConversionOffer is the exchange offer a conversion on a statement was made with
+type ConversionOffer struct {
+	OfferId          uint
+	FromCurrencyCode string
+	ToCurrencyCode   string
+	ExchangeRate     decimal.Decimal
 }
@@ -3,17 +3,31 @@ package account
 import (
 	// Go imports
 	"errors"
+	"time"
 
 	// External imports
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IAccountRepository interface {
 	CreateAccount(account Account) (*Account, error)
+	GetClosedUserAccount(userId uint, currencyCode string) (*Account, error)
+	RestoreAccount(userId uint, currencyCode string) error
+	CloseAccount(userId uint, currencyCode string) error
+	UpdateAccountFrozenAt(userId uint, currencyCode string, frozenAt *time.Time) error
 	ListUserAccounts(userId uint) ([]Account, error)
+	ListAccounts() ([]Account, error)
 	IsUserHasAccountOnGivenCurrency(userId uint, currencyCode string) bool
-	GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (float64, error)
-	UpdateUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string, balance float64) error
+	GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (decimal.Decimal, error)
+	UpdateUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string, balance decimal.Decimal) error
+	UpdateUserHeldBalance(userId uint, currencyCode string, heldBalance decimal.Decimal) error
+	GetUserAccountsForUpdate(userId uint, currencyCodes []string) ([]Account, error)
+	CreateHold(hold Hold) (*Hold, error)
+	GetHoldForUpdate(id uint) (*Hold, error)
+	UpdateHold(hold Hold) error
+	WithTx(tx *gorm.DB) IAccountRepository
 	Migration() error
 }
 
@@ -27,8 +41,12 @@ func NewAccountRepository(db *gorm.DB) IAccountRepository {
 	}
 }
 
+func (r *accountRepository) WithTx(tx *gorm.DB) IAccountRepository {
+	return NewAccountRepository(tx)
+}
+
 func (r *accountRepository) Migration() error {
-	return r.db.AutoMigrate(Account{})
+	return r.db.AutoMigrate(Account{}, Hold{})
 }
 
 func (r *accountRepository) CreateAccount(account Account) (*Account, error) {
@@ -38,6 +56,28 @@ func (r *accountRepository) CreateAccount(account Account) (*Account, error) {
 	return &account, nil
 }
 
+// GetClosedUserAccount finds the soft deleted account of the user, it still holds the primary key of the currency
+func (r *accountRepository) GetClosedUserAccount(userId uint, currencyCode string) (*Account, error) {
+	var account *Account
+	if err := r.db.Unscoped().Where("user_id =?", userId).Where("currency_code =?", currencyCode).Where("deleted_at IS NOT NULL").First(&account).Error; err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (r *accountRepository) RestoreAccount(userId uint, currencyCode string) error {
+	return r.db.Unscoped().Model(&Account{}).Where("user_id =?", userId).Where("currency_code =?", currencyCode).Update("deleted_at", nil).Error
+}
+
+// CloseAccount soft deletes the account, its ledger history is kept
+func (r *accountRepository) CloseAccount(userId uint, currencyCode string) error {
+	return r.db.Where("user_id =?", userId).Where("currency_code =?", currencyCode).Delete(&Account{}).Error
+}
+
+func (r *accountRepository) UpdateAccountFrozenAt(userId uint, currencyCode string, frozenAt *time.Time) error {
+	return r.db.Model(&Account{}).Where("user_id =?", userId).Where("currency_code =?", currencyCode).Update("frozen_at", frozenAt).Error
+}
+
 func (r *accountRepository) ListUserAccounts(userId uint) ([]Account, error) {
 	var accounts []Account
 	if err := r.db.Where("user_id =?", userId).Find(&accounts).Error; err != nil {
@@ -46,6 +86,14 @@ func (r *accountRepository) ListUserAccounts(userId uint) ([]Account, error) {
 	return accounts, nil
 }
 
+func (r *accountRepository) ListAccounts() ([]Account, error) {
+	var accounts []Account
+	if err := r.db.Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
 func (r *accountRepository) IsUserHasAccountOnGivenCurrency(userId uint, currencyCode string) bool {
 	var account *Account
 	if err := r.db.Where("user_id =?", userId).Where("currency_code =?", currencyCode).First(&account).Error; err != nil {
@@ -59,19 +107,52 @@ func (r *accountRepository) IsUserHasAccountOnGivenCurrency(userId uint, currenc
 	return true
 }
 
-func (r *accountRepository) GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (float64, error) {
+// GetUserBalanceOnGivenCurrencyAccount returns the available balance, the held amounts can not be spent
+func (r *accountRepository) GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (decimal.Decimal, error) {
 	var account *Account
-	if err := r.db.Select("balance").Where("user_id =?", userId).Where("currency_code =?", currencyCode).First(&account).Error; err != nil {
-		return 0, err
+	if err := r.db.Select("balance", "held_balance").Where("user_id =?", userId).Where("currency_code =?", currencyCode).First(&account).Error; err != nil {
+		return decimal.Zero, err
 	}
 
 	if account == nil {
-		return 0, errors.New("account not found on given currency")
+		return decimal.Zero, errors.New("account not found on given currency")
 	}
 
-	return account.Balance, nil
+	return account.availableBalance(), nil
 }
 
-func (r *accountRepository) UpdateUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string, balance float64) error {
+func (r *accountRepository) UpdateUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string, balance decimal.Decimal) error {
 	return r.db.Model(&Account{}).Where("user_id =?", userId).Where("currency_code =?", currencyCode).Update("balance", balance).Error
 }
+
+func (r *accountRepository) UpdateUserHeldBalance(userId uint, currencyCode string, heldBalance decimal.Decimal) error {
+	return r.db.Model(&Account{}).Where("user_id =?", userId).Where("currency_code =?", currencyCode).Update("held_balance", heldBalance).Error
+}
+
+// GetUserAccountsForUpdate locks the rows ordered by currency code, so concurrent callers always acquire them in the same order
+func (r *accountRepository) GetUserAccountsForUpdate(userId uint, currencyCodes []string) ([]Account, error) {
+	var accounts []Account
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id =?", userId).Where("currency_code IN ?", currencyCodes).Order("currency_code").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) CreateHold(hold Hold) (*Hold, error) {
+	if err := r.db.Create(&hold).Error; err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *accountRepository) GetHoldForUpdate(id uint) (*Hold, error) {
+	var hold *Hold
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id =?", id).First(&hold).Error; err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+func (r *accountRepository) UpdateHold(hold Hold) error {
+	return r.db.Save(&hold).Error
+}
@@ -8,6 +8,7 @@ import (
 
 	// External imports
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	// Internal imports
@@ -21,16 +22,16 @@ func TestAccountRepository_CreateAccount(t *testing.T) {
 	a := Account{
 		CurrencyCode: "EUR",
 		UserId:       uint(1),
-		Balance:      300,
+		Balance:      decimal.NewFromInt(300),
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectExec(
-		regexp.QuoteMeta(`INSERT INTO "accounts" ("currency_code","user_id","balance","created_at","updated_at","deleted_at")
-	 												VALUES ($1,$2,$3,$4,$5,$6)`)).
-		WithArgs(a.CurrencyCode, a.UserId, a.Balance, a.CreatedAt, a.UpdatedAt, nil).
+		regexp.QuoteMeta(`INSERT INTO "accounts" ("currency_code","user_id","balance","held_balance","frozen_at","created_at","updated_at","deleted_at")
+	 												VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).
+		WithArgs(a.CurrencyCode, a.UserId, a.Balance, a.HeldBalance, nil, a.CreatedAt, a.UpdatedAt, nil).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	mock.ExpectCommit()
@@ -47,20 +48,20 @@ func TestAccountRepository_GetUserBalanceOnGivenCurrencyAccount(t *testing.T) {
 	userId := uint(1)
 	currencyCode := "TRY"
 
-	expected := float64(100)
+	expected := decimal.NewFromInt(100)
 
 	rows := sqlmock.
-		NewRows([]string{"user_id", "currency_code", "balance"}).
-		AddRow(userId, currencyCode, expected)
+		NewRows([]string{"user_id", "currency_code", "balance", "held_balance"}).
+		AddRow(userId, currencyCode, decimal.NewFromInt(130), decimal.NewFromInt(30))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "balance" FROM "accounts" WHERE user_id =$1 AND currency_code =$2 AND "accounts"."deleted_at" IS NULL ORDER BY "accounts"."currency_code" LIMIT 1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "balance","held_balance" FROM "accounts" WHERE user_id =$1 AND currency_code =$2 AND "accounts"."deleted_at" IS NULL ORDER BY "accounts"."currency_code" LIMIT 1`)).
 		WithArgs(userId, currencyCode).WillReturnRows(rows)
 
 	actual, err := r.GetUserBalanceOnGivenCurrencyAccount(userId, currencyCode)
 
 	assert.Nil(t, err)
 	assert.Nil(t, mock.ExpectationsWereMet())
-	assert.Equal(t, expected, actual)
+	assert.True(t, expected.Equal(actual))
 }
 
 func TestAccountRepository_IsUserHasAccountOnGivenCurrency(t *testing.T) {
@@ -89,21 +90,21 @@ func TestAccountRepository_ListUserAccounts(t *testing.T) {
 		{
 			CurrencyCode: "TRY",
 			UserId:       userId,
-			Balance:      float64(370),
+			Balance:      decimal.NewFromInt(370),
 			CreatedAt:    time.Now().Local().AddDate(0, 0, -50),
 			UpdatedAt:    time.Now().Local().AddDate(0, 0, -20),
 		},
 		{
 			CurrencyCode: "USD",
 			UserId:       userId,
-			Balance:      float64(125),
+			Balance:      decimal.NewFromInt(125),
 			CreatedAt:    time.Now().Local().AddDate(0, 0, -20),
 			UpdatedAt:    time.Now().Local().AddDate(0, 0, -7),
 		},
 		{
 			CurrencyCode: "USD",
 			UserId:       userId,
-			Balance:      float64(125),
+			Balance:      decimal.NewFromInt(125),
 			CreatedAt:    time.Now().Local(),
 			UpdatedAt:    time.Now().Local(),
 		},
@@ -130,7 +131,7 @@ func TestAccountRepository_UpdateUserBalanceOnGivenCurrencyAccount(t *testing.T)
 	r := NewAccountRepository(db)
 	userId := uint(1)
 	currencyCode := "TRY"
-	balance := float64(2500)
+	balance := decimal.NewFromInt(2500)
 
 	mock.MatchExpectationsInOrder(false)
 	mock.ExpectBegin()
@@ -145,3 +146,56 @@ func TestAccountRepository_UpdateUserBalanceOnGivenCurrencyAccount(t *testing.T)
 	errExpectations := mock.ExpectationsWereMet()
 	assert.Nil(t, errExpectations)
 }
+
+func TestAccountRepository_GetUserAccountsForUpdate(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewAccountRepository(db)
+	userId := uint(1)
+
+	rows := sqlmock.
+		NewRows([]string{"currency_code", "user_id", "balance"}).
+		AddRow("TRY", userId, "150.00").
+		AddRow("USD", userId, "10.00")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE user_id =$1 AND currency_code IN ($2,$3) AND "accounts"."deleted_at" IS NULL ORDER BY currency_code FOR UPDATE`)).
+		WithArgs(userId, "USD", "TRY").WillReturnRows(rows)
+
+	accounts, err := r.GetUserAccountsForUpdate(userId, []string{"USD", "TRY"})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, "TRY", accounts[0].CurrencyCode)
+}
+
+func TestAccountRepository_CloseAccount(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewAccountRepository(db)
+	userId := uint(1)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "accounts" SET "deleted_at"=$1 WHERE user_id =$2 AND currency_code =$3 AND "accounts"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), userId, "EUR").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := r.CloseAccount(userId, "EUR")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountRepository_GetClosedUserAccount(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewAccountRepository(db)
+	userId := uint(1)
+
+	rows := sqlmock.
+		NewRows([]string{"currency_code", "user_id", "balance", "deleted_at"}).
+		AddRow("EUR", userId, "0", time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE user_id =$1 AND currency_code =$2 AND deleted_at IS NOT NULL ORDER BY "accounts"."currency_code" LIMIT 1`)).
+		WithArgs(userId, "EUR").WillReturnRows(rows)
+
+	account, err := r.GetClosedUserAccount(userId, "EUR")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "EUR", account.CurrencyCode)
+}
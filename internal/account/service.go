@@ -2,48 +2,107 @@ package account
 
 import (
 	// Go imports
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
 	// Internal imports
 	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+const (
+	defaultTransactionPageSize = 20
+	maxTransactionPageSize     = 100
+)
+
+var (
+	ErrNotEnoughBalance         = errors.New("not enough balance")
+	ErrInvalidTransactionFilter = errors.New("invalid transaction filter")
+	ErrAccountNotFound          = errors.New("account not found")
+	ErrAccountExists            = errors.New("account already exists")
+	ErrAccountNotEmpty          = errors.New("account is not empty")
+	ErrAccountFrozen            = errors.New("account is frozen")
 )
 
 type IAccountService interface {
-	CreateUserAccount(userId uint, currencyCode string, isOnRegistration bool) (*Account, error)
+	CreateUserAccount(userId uint, currencyCode string) (*Account, error)
 	ListUserAccounts(userId uint) ([]WalletAccount, error)
+	ListUserTransactions(userId uint, request TransactionListRequest) (*TransactionListResponse, error)
 	IsUserHasAccountOnGivenCurrency(userId uint, currencyCode string) bool
-	GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (float64, error)
-	UpdateUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string, balance float64) error
+	GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (decimal.Decimal, error)
+	ApplyJournalEntry(entry ledger.JournalEntry) (*ledger.JournalEntry, error)
+	ReconcileLedger() error
+	LockUserAccounts(userId uint, currencyCodes ...string) (map[string]decimal.Decimal, error)
+	CreateHold(userId uint, currencyCode string, amount decimal.Decimal, referenceType string, referenceId uint) (*Hold, error)
+	ReleaseHold(holdId uint) (*Hold, error)
+	CaptureHold(holdId uint, entry ledger.JournalEntry) (*ledger.JournalEntry, error)
+	OpenAccount(userId uint, currencyCode string) (*WalletAccount, error)
+	CloseAccount(userId uint, currencyCode string) error
+	FreezeAccount(userId uint, currencyCode string) (*WalletAccount, error)
+	UnfreezeAccount(userId uint, currencyCode string) (*WalletAccount, error)
+	WithTx(tx *gorm.DB) IAccountService
 }
 
 type accountService struct {
-	config      config.Config
-	accountRepo IAccountRepository
+	config        config.Config
+	accountRepo   IAccountRepository
+	ledgerService ledger.ILedgerService
+	unitOfWork    uow.IUnitOfWork
+}
+
+func NewAccountService(accountRepository IAccountRepository, ledgerService ledger.ILedgerService, unitOfWork uow.IUnitOfWork, config config.Config) IAccountService {
+	return &accountService{accountRepo: accountRepository, ledgerService: ledgerService, unitOfWork: unitOfWork, config: config}
 }
 
-func NewAccountService(accountRepository IAccountRepository, config config.Config) IAccountService {
-	return &accountService{accountRepo: accountRepository, config: config}
+func (s *accountService) WithTx(tx *gorm.DB) IAccountService {
+	return s.withTx(tx)
 }
 
-func (s *accountService) CreateUserAccount(userId uint, currencyCode string, isOnRegistration bool) (*Account, error) {
-	var balance float64 = 0
-	if isOnRegistration {
-		balance = 10000
+func (s *accountService) withTx(tx *gorm.DB) *accountService {
+	return &accountService{
+		accountRepo:   s.accountRepo.WithTx(tx),
+		ledgerService: s.ledgerService.WithTx(tx),
+		unitOfWork:    uow.NewUnitOfWork(tx),
+		config:        s.config,
 	}
+}
 
+// CreateUserAccount opens an empty account, money only comes in through ledger entries such as the onboarding credit
+func (s *accountService) CreateUserAccount(userId uint, currencyCode string) (*Account, error) {
 	account := Account{
 		CurrencyCode: strings.ToUpper(currencyCode),
 		UserId:       userId,
-		Balance:      balance,
+		Balance:      decimal.Zero,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	if _, err := s.accountRepo.CreateAccount(account); err != nil {
+	// A closed account is opened again, the soft deleted row still holds the currency of the user
+	closed, err := s.accountRepo.GetClosedUserAccount(userId, account.CurrencyCode)
+	if err == nil {
+		if err = s.accountRepo.RestoreAccount(userId, account.CurrencyCode); err != nil {
+			return nil, err
+		}
+		closed.DeletedAt = gorm.DeletedAt{}
+		return closed, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
 
+	if _, err = s.accountRepo.CreateAccount(account); err != nil {
+		return nil, err
+	}
 	return &account, nil
 }
 
@@ -59,24 +118,226 @@ func (s *accountService) ListUserAccounts(userId uint) ([]WalletAccount, error)
 
 	var respondAccounts []WalletAccount
 	for _, account := range accounts {
-		respondAccounts = append(respondAccounts, WalletAccount{
-			CurrencyCode: account.CurrencyCode,
-			Balance:      account.Balance,
-		})
+		respondAccounts = append(respondAccounts, account.toWalletAccount())
 	}
 
 	return respondAccounts, err
 }
 
-func (s *accountService) GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (float64, error) {
+// ListUserTransactions pages through the postings on the user's accounts newest first
+func (s *accountService) ListUserTransactions(userId uint, request TransactionListRequest) (*TransactionListResponse, error) {
+	filter := ledger.UserPostingFilter{
+		UserId:          userId,
+		CurrencyCode:    request.CurrencyCode,
+		TransactionType: ledger.TransactionType(strings.ToUpper(request.Type)),
+		Start:           request.Start,
+		End:             request.End,
+		Limit:           request.Limit,
+	}
+
+	if filter.TransactionType != "" && !filter.TransactionType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown type %s", ErrInvalidTransactionFilter, request.Type)
+	}
+
+	if filter.Start != nil && filter.End != nil && !filter.Start.Before(*filter.End) {
+		return nil, fmt.Errorf("%w: start must be before end", ErrInvalidTransactionFilter)
+	}
+
+	if request.Cursor != "" {
+		cursor, err := strconv.ParseUint(request.Cursor, 10, 64)
+		if err != nil || cursor == 0 {
+			return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidTransactionFilter)
+		}
+		filter.BeforeId = uint(cursor)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransactionPageSize
+	}
+
+	if filter.Limit > maxTransactionPageSize {
+		filter.Limit = maxTransactionPageSize
+	}
+
+	// Fetch one more posting to know whether there is a next page
+	pageSize := filter.Limit
+	filter.Limit++
+	postings, err := s.ledgerService.ListUserPostings(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransactionListResponse{Transactions: []Transaction{}}
+	if len(postings) > pageSize {
+		postings = postings[:pageSize]
+		response.NextCursor = strconv.FormatUint(uint64(postings[pageSize-1].Id), 10)
+	}
+
+	for _, posting := range postings {
+		transaction := Transaction{
+			Id:           posting.Id,
+			Type:         string(posting.TransactionType()),
+			CurrencyCode: posting.CurrencyCode,
+			Amount:       posting.SignedAmount(),
+			CreatedAt:    posting.CreatedAt,
+		}
+
+		referenceId := posting.ReferenceId
+		switch posting.ReferenceType {
+		case ledger.ReferenceTypeOffer:
+			transaction.OfferId = &referenceId
+		case ledger.ReferenceTypeLimitOrder:
+			transaction.LimitOrderId = &referenceId
+		case ledger.ReferenceTypeTransfer:
+			transaction.TransferId = &referenceId
+		case ledger.ReferenceTypePayment:
+			transaction.PaymentId = &referenceId
+		}
+
+		response.Transactions = append(response.Transactions, transaction)
+	}
+
+	return response, nil
+}
+
+func (s *accountService) GetUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string) (decimal.Decimal, error) {
 	return s.accountRepo.GetUserBalanceOnGivenCurrencyAccount(userId, currencyCode)
 }
 
-func (s *accountService) UpdateUserBalanceOnGivenCurrencyAccount(userId uint, currencyCode string, amount float64) error {
-	existingBalance, err := s.GetUserBalanceOnGivenCurrencyAccount(userId, currencyCode)
+// ApplyJournalEntry records the entry on the ledger and moves the balances of the user accounts it posts to.
+// Account balances are verified against the ledger after the change, any mismatch rolls the entry back.
+func (s *accountService) ApplyJournalEntry(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+	var recorded *ledger.JournalEntry
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		var err error
+		recorded, err = s.withTx(tx).applyJournalEntry(entry, nil)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return recorded, nil
+}
+
+// applyJournalEntry has to run inside a transaction, capturedHold is the hold the entry captures if any
+func (s *accountService) applyJournalEntry(entry ledger.JournalEntry, capturedHold *Hold) (*ledger.JournalEntry, error) {
+	recorded, err := s.ledgerService.Record(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	type accountKey struct {
+		userId       uint
+		currencyCode string
+	}
+
+	// Sum the postings per user account first, an entry may post to the same account more than once
+	var keys []accountKey
+	changes := make(map[accountKey]decimal.Decimal)
+	for _, posting := range recorded.Postings {
+		if posting.AccountKind != ledger.AccountKindUser {
+			continue
+		}
+
+		key := accountKey{userId: posting.UserId, currencyCode: posting.CurrencyCode}
+		if _, ok := changes[key]; !ok {
+			keys = append(keys, key)
+		}
+		changes[key] = changes[key].Add(posting.SignedAmount())
+	}
+
+	for _, key := range keys {
+		if err = s.applyBalanceChange(key.userId, key.currencyCode, changes[key], capturedHold); err != nil {
+			return nil, err
+		}
+	}
+
+	return recorded, nil
+}
+
+func (s *accountService) applyBalanceChange(userId uint, currencyCode string, change decimal.Decimal, capturedHold *Hold) error {
+	account, err := s.lockAccount(userId, currencyCode)
+	if err != nil {
+		return err
+	}
+
+	if account.FrozenAt != nil && (capturedHold == nil || !capturedHold.heldBeforeFreeze(*account)) {
+		return fmt.Errorf("%w: %s account of user %d", ErrAccountFrozen, currencyCode, userId)
+	}
+
+	// Held amounts can not be spent by a debit, only by capturing their hold
+	balance := currency.Round(account.Balance.Add(change), currencyCode)
+	if balance.IsNegative() || (change.IsNegative() && balance.LessThan(account.HeldBalance)) {
+		return ErrNotEnoughBalance
+	}
+
+	ledgerBalance, err := s.ledgerService.GetUserBalance(userId, currencyCode)
+	if err != nil {
+		return err
+	}
+
+	if !ledgerBalance.Equal(balance) {
+		return fmt.Errorf("%s balance %s of user %d does not match ledger balance %s", currencyCode, balance.String(), userId, ledgerBalance.String())
+	}
+
+	return s.accountRepo.UpdateUserBalanceOnGivenCurrencyAccount(userId, currencyCode, balance)
+}
+
+// ReconcileLedger records opening balance entries for account balances which are not backed by the ledger,
+// e.g. accounts created before the ledger existed. Account balances are not changed.
+func (s *accountService) ReconcileLedger() error {
+	accounts, err := s.accountRepo.ListAccounts()
 	if err != nil {
 		return err
 	}
-	existingBalance += amount
-	return s.accountRepo.UpdateUserBalanceOnGivenCurrencyAccount(userId, currencyCode, existingBalance)
+
+	ledgerBalances, err := s.ledgerService.ListUserBalances()
+	if err != nil {
+		return err
+	}
+
+	balances := make(map[string]decimal.Decimal, len(ledgerBalances))
+	for _, ledgerBalance := range ledgerBalances {
+		balances[fmt.Sprintf("%d:%s", ledgerBalance.UserId, ledgerBalance.CurrencyCode)] = ledgerBalance.Balance
+	}
+
+	for _, account := range accounts {
+		difference := account.Balance.Sub(balances[fmt.Sprintf("%d:%s", account.UserId, account.CurrencyCode)])
+		if difference.IsZero() {
+			continue
+		}
+
+		if _, err = s.ledgerService.Record(ledger.NewOpeningBalanceEntry(account.UserId, account.CurrencyCode, difference)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LockUserAccounts locks the user's accounts on given currencies until the surrounding transaction ends
+// and returns their available balances by currency code
+func (s *accountService) LockUserAccounts(userId uint, currencyCodes ...string) (map[string]decimal.Decimal, error) {
+	codes := make([]string, 0, len(currencyCodes))
+	for _, currencyCode := range currencyCodes {
+		codes = append(codes, strings.ToUpper(currencyCode))
+	}
+
+	accounts, err := s.accountRepo.GetUserAccountsForUpdate(userId, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]decimal.Decimal, len(accounts))
+	for _, account := range accounts {
+		balances[account.CurrencyCode] = account.availableBalance()
+	}
+
+	for _, currencyCode := range codes {
+		if _, ok := balances[currencyCode]; !ok {
+			return nil, fmt.Errorf("%s %w", currencyCode, ErrAccountNotFound)
+		}
+	}
+
+	return balances, nil
 }
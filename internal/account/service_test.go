@@ -2,25 +2,29 @@ package account
 
 import (
 	// Go imports
-	"errors"
 	"testing"
+	"time"
 
 	// External imports
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 
 	// Internal imports
 	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
 )
 
 func TestAccountService_GetUserBalanceOnGivenCurrencyAccount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockAccountRepository := NewMockIAccountRepository(ctrl)
-	accService := NewAccountService(mockAccountRepository, config.Config{})
+	accService := NewAccountService(mockAccountRepository, ledger.NewMockILedgerService(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{})
 
 	userId := uint(1)
 	currencyCode := "USD"
-	balance := float64(50)
+	balance := decimal.NewFromInt(50)
 	mockAccountRepository.EXPECT().GetUserBalanceOnGivenCurrencyAccount(userId, currencyCode).Return(balance, nil)
 
 	actualBalance, err := accService.GetUserBalanceOnGivenCurrencyAccount(userId, currencyCode)
@@ -31,7 +35,7 @@ func TestAccountService_GetUserBalanceOnGivenCurrencyAccount(t *testing.T) {
 func TestAccountService_IsUserHasAccountOnGivenCurrency(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockAccountRepository := NewMockIAccountRepository(ctrl)
-	accService := NewAccountService(mockAccountRepository, config.Config{})
+	accService := NewAccountService(mockAccountRepository, ledger.NewMockILedgerService(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{})
 	userId := uint(1)
 	currencyCode := "USD"
 	t.Run("user has account on given currency", func(t *testing.T) {
@@ -47,26 +51,173 @@ func TestAccountService_IsUserHasAccountOnGivenCurrency(t *testing.T) {
 	})
 }
 
-func TestAccountService_UpdateUserBalanceOnGivenCurrencyAccount(t *testing.T) {
+func TestAccountService_ApplyJournalEntry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockAccountRepository := NewMockIAccountRepository(ctrl)
-	accService := NewAccountService(mockAccountRepository, config.Config{})
+	mockLedgerService := ledger.NewMockILedgerService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	accService := NewAccountService(mockAccountRepository, mockLedgerService, mockUnitOfWork, config.Config{})
 
 	userId := uint(1)
-	currencyCode := "USD"
-	balance := float64(50)
+	entry := ledger.NewConversionEntry(userId, uint(4), "TRY", "USD", decimal.RequireFromString("100.00"), decimal.RequireFromString("5.40"))
+
+	expectTransaction := func() {
+		mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+			return fn(nil)
+		})
+		mockAccountRepository.EXPECT().WithTx(gomock.Any()).Return(mockAccountRepository)
+		mockLedgerService.EXPECT().WithTx(gomock.Any()).Return(mockLedgerService)
+	}
+
+	t.Run("unbalanced entry is rejected", func(t *testing.T) {
+		expectTransaction()
+		mockLedgerService.EXPECT().Record(entry).Return(nil, ledger.ErrUnbalancedEntry)
+		_, err := accService.ApplyJournalEntry(entry)
+		assert.ErrorIs(t, err, ledger.ErrUnbalancedEntry)
+	})
+
+	t.Run("not enough balance", func(t *testing.T) {
+		expectTransaction()
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(50)}}, nil)
+		_, err := accService.ApplyJournalEntry(entry)
+		assert.Equal(t, ErrNotEnoughBalance, err)
+	})
 
-	t.Run("account not found on given currency", func(t *testing.T) {
-		mockAccountRepository.EXPECT().GetUserBalanceOnGivenCurrencyAccount(userId, currencyCode).Return(float64(0), errors.New("account not found"))
-		err := accService.UpdateUserBalanceOnGivenCurrencyAccount(userId, currencyCode, balance)
+	t.Run("balance does not match ledger", func(t *testing.T) {
+		expectTransaction()
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(150)}}, nil)
+		mockLedgerService.EXPECT().GetUserBalance(userId, "TRY").Return(decimal.NewFromInt(40), nil)
+		_, err := accService.ApplyJournalEntry(entry)
 		assert.NotNil(t, err)
 	})
 
-	t.Run("successfully updated balance", func(t *testing.T) {
-		mockAccountRepository.EXPECT().GetUserBalanceOnGivenCurrencyAccount(userId, currencyCode).Return(balance, nil)
-		mockAccountRepository.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, currencyCode, balance).Return(nil)
-		balance -= balance
-		err := accService.UpdateUserBalanceOnGivenCurrencyAccount(userId, currencyCode, balance)
+	t.Run("user balances follow the ledger", func(t *testing.T) {
+		expectTransaction()
+		mockLedgerService.EXPECT().Record(entry).Return(&entry, nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(150)}}, nil)
+		mockLedgerService.EXPECT().GetUserBalance(userId, "TRY").Return(decimal.NewFromInt(50), nil)
+		mockAccountRepository.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, "TRY", decimal.RequireFromString("50.00")).Return(nil)
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"USD"}).
+			Return([]Account{{CurrencyCode: "USD", UserId: userId, Balance: decimal.NewFromInt(1)}}, nil)
+		mockLedgerService.EXPECT().GetUserBalance(userId, "USD").Return(decimal.RequireFromString("6.4"), nil)
+		mockAccountRepository.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, "USD", decimal.RequireFromString("6.40")).Return(nil)
+		_, err := accService.ApplyJournalEntry(entry)
+		assert.Nil(t, err)
+	})
+}
+
+func TestAccountService_ReconcileLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAccountRepository := NewMockIAccountRepository(ctrl)
+	mockLedgerService := ledger.NewMockILedgerService(ctrl)
+	accService := NewAccountService(mockAccountRepository, mockLedgerService, uow.NewMockIUnitOfWork(ctrl), config.Config{})
+
+	mockAccountRepository.EXPECT().ListAccounts().Return([]Account{
+		{CurrencyCode: "TRY", UserId: 1, Balance: decimal.NewFromInt(100)},
+		{CurrencyCode: "USD", UserId: 1, Balance: decimal.NewFromInt(20)},
+	}, nil)
+	mockLedgerService.EXPECT().ListUserBalances().Return([]ledger.UserBalance{
+		{UserId: 1, CurrencyCode: "TRY", Balance: decimal.NewFromInt(100)},
+	}, nil)
+	mockLedgerService.EXPECT().Record(gomock.Any()).DoAndReturn(func(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+		assert.Equal(t, ledger.EntryTypeOpeningBalance, entry.Type)
+		assert.Equal(t, "USD", entry.Postings[1].CurrencyCode)
+		assert.True(t, entry.Postings[1].SignedAmount().Equal(decimal.NewFromInt(20)))
+		return &entry, nil
+	})
+
+	err := accService.ReconcileLedger()
+	assert.Nil(t, err)
+}
+
+func TestAccountService_LockUserAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAccountRepository := NewMockIAccountRepository(ctrl)
+	accService := NewAccountService(mockAccountRepository, ledger.NewMockILedgerService(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{})
+	userId := uint(1)
+
+	t.Run("account missing on one currency", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY", "USD"}).
+			Return([]Account{{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(10)}}, nil)
+		_, err := accService.LockUserAccounts(userId, "try", "usd")
+		assert.NotNil(t, err)
+		assert.Equal(t, "USD account not found", err.Error())
+	})
+
+	t.Run("balances by currency code", func(t *testing.T) {
+		mockAccountRepository.EXPECT().GetUserAccountsForUpdate(userId, []string{"TRY", "USD"}).
+			Return([]Account{
+				{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(10)},
+				{CurrencyCode: "USD", UserId: userId, Balance: decimal.NewFromInt(3)},
+			}, nil)
+		balances, err := accService.LockUserAccounts(userId, "TRY", "USD")
+		assert.Nil(t, err)
+		assert.True(t, balances["USD"].Equal(decimal.NewFromInt(3)))
+	})
+}
+
+func TestAccountService_ListUserTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLedgerService := ledger.NewMockILedgerService(ctrl)
+	accService := NewAccountService(NewMockIAccountRepository(ctrl), mockLedgerService, uow.NewMockIUnitOfWork(ctrl), config.Config{})
+	userId := uint(1)
+	createdAt := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := accService.ListUserTransactions(userId, TransactionListRequest{Type: "REFUND"})
+		assert.ErrorIs(t, err, ErrInvalidTransactionFilter)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := accService.ListUserTransactions(userId, TransactionListRequest{Cursor: "abc"})
+		assert.ErrorIs(t, err, ErrInvalidTransactionFilter)
+	})
+
+	t.Run("start after end", func(t *testing.T) {
+		end := createdAt.Add(-time.Hour)
+		_, err := accService.ListUserTransactions(userId, TransactionListRequest{Start: &createdAt, End: &end})
+		assert.ErrorIs(t, err, ErrInvalidTransactionFilter)
+	})
+
+	t.Run("last page has no next cursor", func(t *testing.T) {
+		mockLedgerService.EXPECT().ListUserPostings(ledger.UserPostingFilter{UserId: userId, Limit: defaultTransactionPageSize + 1}).
+			Return([]ledger.UserPosting{
+				{Id: 2, EntryType: ledger.EntryTypeRegistrationBonus, CurrencyCode: "TRY", Direction: ledger.DirectionCredit, Amount: decimal.NewFromInt(10000), CreatedAt: createdAt},
+			}, nil)
+		response, err := accService.ListUserTransactions(userId, TransactionListRequest{})
+		assert.Nil(t, err)
+		assert.Empty(t, response.NextCursor)
+		assert.Len(t, response.Transactions, 1)
+		assert.Equal(t, string(ledger.TransactionTypeRegistrationBonus), response.Transactions[0].Type)
+		assert.Nil(t, response.Transactions[0].OfferId)
+	})
+
+	t.Run("conversion links to its offer and pages with cursor", func(t *testing.T) {
+		mockLedgerService.EXPECT().ListUserPostings(ledger.UserPostingFilter{
+			UserId:          userId,
+			CurrencyCode:    "TRY",
+			TransactionType: ledger.TransactionTypeConversionDebit,
+			BeforeId:        10,
+			Limit:           2,
+		}).Return([]ledger.UserPosting{
+			{Id: 8, EntryType: ledger.EntryTypeConversion, ReferenceType: ledger.ReferenceTypeOffer, ReferenceId: 4, CurrencyCode: "TRY", Direction: ledger.DirectionDebit, Amount: decimal.NewFromInt(100), CreatedAt: createdAt},
+			{Id: 6, EntryType: ledger.EntryTypeConversion, ReferenceType: ledger.ReferenceTypeOffer, ReferenceId: 3, CurrencyCode: "TRY", Direction: ledger.DirectionDebit, Amount: decimal.NewFromInt(50), CreatedAt: createdAt},
+		}, nil)
+		response, err := accService.ListUserTransactions(userId, TransactionListRequest{
+			CurrencyCode: "TRY",
+			Type:         "conversion_debit",
+			Cursor:       "10",
+			Limit:        1,
+		})
 		assert.Nil(t, err)
+		assert.Equal(t, "8", response.NextCursor)
+		assert.Len(t, response.Transactions, 1)
+		assert.Equal(t, uint(4), *response.Transactions[0].OfferId)
+		assert.True(t, response.Transactions[0].Amount.Equal(decimal.NewFromInt(-100)))
 	})
 }
@@ -0,0 +1,164 @@
+package account
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+)
+
+const statementMonthLayout = "2006-01"
+
+var ErrInvalidStatementRequest = errors.New("invalid statement request")
+
+// IOfferResolver gives the exchange offers the conversions on a statement were made with, the exchange service
+// satisfies it. It is declared here since the exchange package depends on this one.
+type IOfferResolver interface {
+	GetUserConversionOffers(userId uint, offerIds []uint) ([]ConversionOffer, error)
+}
+
+type IStatementService interface {
+	GetUserStatement(userId uint, request StatementRequest) (*Statement, error)
+}
+
+type statementService struct {
+	ledgerService   ledger.ILedgerService
+	offerResolver   IOfferResolver
+	currencyService currency.Service
+	clock           clock.IClock
+}
+
+func NewStatementService(ledgerService ledger.ILedgerService, offerResolver IOfferResolver, currencyService currency.Service, clock clock.IClock) IStatementService {
+	return &statementService{ledgerService: ledgerService, offerResolver: offerResolver, currencyService: currencyService, clock: clock}
+}
+
+// GetUserStatement lists the movements on the user's account in the currency during the month, the current month
+// by default. The statement is read from the ledger, so it covers closed accounts too.
+func (s *statementService) GetUserStatement(userId uint, request StatementRequest) (*Statement, error) {
+	currencyCode := strings.ToUpper(strings.TrimSpace(request.CurrencyCode))
+	if currencyCode == "" {
+		return nil, fmt.Errorf("%w: currency is required", ErrInvalidStatementRequest)
+	}
+
+	if !s.currencyService.CheckIsCurrencyCodeExist(currencyCode) {
+		return nil, fmt.Errorf("%w: unknown currency %s", ErrInvalidStatementRequest, currencyCode)
+	}
+
+	now := s.clock.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if month := strings.TrimSpace(request.Month); month != "" {
+		parsed, err := time.ParseInLocation(statementMonthLayout, month, now.Location())
+		if err != nil {
+			return nil, fmt.Errorf("%w: month must be in YYYY-MM format", ErrInvalidStatementRequest)
+		}
+
+		if parsed.After(now) {
+			return nil, fmt.Errorf("%w: month %s has not started yet", ErrInvalidStatementRequest, month)
+		}
+		start = parsed
+	}
+	end := start.AddDate(0, 1, 0)
+
+	openingBalance, err := s.ledgerService.GetUserBalanceBefore(userId, currencyCode, start)
+	if err != nil {
+		return nil, err
+	}
+
+	postings, err := s.ledgerService.ListUserPostings(ledger.UserPostingFilter{
+		UserId:       userId,
+		CurrencyCode: currencyCode,
+		Start:        &start,
+		End:          &end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offers, err := s.conversionOffers(userId, postings)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &Statement{
+		UserId:         userId,
+		CurrencyCode:   currencyCode,
+		Month:          start.Format(statementMonthLayout),
+		Start:          start,
+		End:            end,
+		OpeningBalance: openingBalance,
+		TotalCredits:   decimal.Zero,
+		TotalDebits:    decimal.Zero,
+		Lines:          make([]StatementLine, 0, len(postings)),
+		GeneratedAt:    now,
+	}
+
+	// Postings are listed newest first, the statement runs the balance forward from the opening balance
+	balance := openingBalance
+	for i := len(postings) - 1; i >= 0; i-- {
+		posting := postings[i]
+		amount := posting.SignedAmount()
+		balance = balance.Add(amount)
+		if amount.IsNegative() {
+			statement.TotalDebits = statement.TotalDebits.Add(amount.Neg())
+		} else {
+			statement.TotalCredits = statement.TotalCredits.Add(amount)
+		}
+
+		line := StatementLine{
+			Id:        posting.Id,
+			Type:      string(posting.TransactionType()),
+			Amount:    amount,
+			Balance:   balance,
+			CreatedAt: posting.CreatedAt,
+		}
+
+		if posting.ReferenceType != "" {
+			line.Reference = fmt.Sprintf("%s %d", posting.ReferenceType, posting.ReferenceId)
+		}
+
+		if offer, ok := offers[posting.ReferenceId]; ok && posting.ReferenceType == ledger.ReferenceTypeOffer {
+			line.Description = fmt.Sprintf("%s to %s at %s", offer.FromCurrencyCode, offer.ToCurrencyCode, offer.ExchangeRate.String())
+		}
+
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.ClosingBalance = balance
+
+	return statement, nil
+}
+
+// conversionOffers finds the offers behind the conversions among the postings by their id
+func (s *statementService) conversionOffers(userId uint, postings []ledger.UserPosting) (map[uint]ConversionOffer, error) {
+	var offerIds []uint
+	seen := make(map[uint]bool)
+	for _, posting := range postings {
+		if posting.ReferenceType == ledger.ReferenceTypeOffer && !seen[posting.ReferenceId] {
+			seen[posting.ReferenceId] = true
+			offerIds = append(offerIds, posting.ReferenceId)
+		}
+	}
+
+	offers := make(map[uint]ConversionOffer, len(offerIds))
+	if len(offerIds) == 0 {
+		return offers, nil
+	}
+
+	conversionOffers, err := s.offerResolver.GetUserConversionOffers(userId, offerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, offer := range conversionOffers {
+		offers[offer.OfferId] = offer
+	}
+	return offers, nil
+}
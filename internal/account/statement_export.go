@@ -0,0 +1,96 @@
+package account
+
+import (
+	// Go imports
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/pdf"
+)
+
+const (
+	StatementFormatCsv = "csv"
+	StatementFormatPdf = "pdf"
+)
+
+var statementCsvHeader = []string{"date", "id", "type", "reference", "description", "amount", "balance"}
+
+// CSV renders the statement as one row per movement between an opening and a closing balance row
+func (s Statement) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{
+		statementCsvHeader,
+		{s.Start.Format(time.RFC3339), "", "", "", "Opening balance", "", s.formatAmount(s.OpeningBalance)},
+	}
+
+	for _, line := range s.Lines {
+		rows = append(rows, []string{
+			line.CreatedAt.Format(time.RFC3339),
+			strconv.FormatUint(uint64(line.Id), 10),
+			line.Type,
+			line.Reference,
+			line.Description,
+			s.formatAmount(line.Amount),
+			s.formatAmount(line.Balance),
+		})
+	}
+	rows = append(rows, []string{s.End.Format(time.RFC3339), "", "", "", "Closing balance", "", s.formatAmount(s.ClosingBalance)})
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PDF renders the statement as a printable document with the totals of the period
+func (s Statement) PDF() []byte {
+	const dateLayout = "2006-01-02"
+	document := pdf.NewDocument()
+	document.AddLine("ACCOUNT STATEMENT")
+	document.AddLine("")
+	document.AddLine(fmt.Sprintf("User: %d", s.UserId))
+	document.AddLine(fmt.Sprintf("Account: %s", s.CurrencyCode))
+	document.AddLine(fmt.Sprintf("Period: %s - %s", s.Start.Format(dateLayout), s.End.AddDate(0, 0, -1).Format(dateLayout)))
+	document.AddLine(fmt.Sprintf("Generated: %s", s.GeneratedAt.Format("2006-01-02 15:04 MST")))
+	document.AddLine("")
+	document.AddLine(fmt.Sprintf("%-60s %28s", "Opening balance", s.formatAmount(s.OpeningBalance)))
+	document.AddLine("")
+	document.AddLine(fmt.Sprintf("%-16s  %-20s  %-16s  %14s  %14s", "DATE", "TYPE", "REFERENCE", "AMOUNT", "BALANCE"))
+
+	if len(s.Lines) == 0 {
+		document.AddLine("No movements in this period")
+	}
+
+	for _, line := range s.Lines {
+		document.AddLine(fmt.Sprintf("%-16s  %-20s  %-16s  %14s  %14s", line.CreatedAt.Format("2006-01-02 15:04"), line.Type,
+			line.Reference, s.formatAmount(line.Amount), s.formatAmount(line.Balance)))
+		if line.Description != "" {
+			document.AddLine(fmt.Sprintf("%-16s  %s", "", line.Description))
+		}
+	}
+
+	document.AddLine("")
+	document.AddLine(fmt.Sprintf("%-60s %28s", "Total credits", s.formatAmount(s.TotalCredits)))
+	document.AddLine(fmt.Sprintf("%-60s %28s", "Total debits", s.formatAmount(s.TotalDebits)))
+	document.AddLine(fmt.Sprintf("%-60s %28s", "Closing balance", s.formatAmount(s.ClosingBalance)))
+	return document.Bytes()
+}
+
+// FileName is the name the statement is downloaded with in the given format
+func (s Statement) FileName(format string) string {
+	return fmt.Sprintf("statement-%s-%s.%s", s.CurrencyCode, s.Month, format)
+}
+
+// formatAmount shows every amount with the minor units of the account currency
+func (s Statement) formatAmount(amount decimal.Decimal) string {
+	return amount.StringFixed(currency.MinorUnits(s.CurrencyCode))
+}
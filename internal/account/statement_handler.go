@@ -0,0 +1,95 @@
+package account
+
+import (
+	// Go imports
+	"fmt"
+	"net/http"
+	"strings"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/common"
+)
+
+type StatementHandler interface {
+	Statement(c *gin.Context)
+	StatementRoutes(router *gin.RouterGroup)
+}
+
+type statementHandler struct {
+	statementService IStatementService
+}
+
+func NewStatementHandler(statementService IStatementService) StatementHandler {
+	return &statementHandler{statementService: statementService}
+}
+
+func (h *statementHandler) StatementRoutes(router *gin.RouterGroup) {
+	router.GET("/statement", h.Statement)
+}
+
+// Statement godoc
+// @Summary Get Account Statement
+// @Description Download the monthly statement of an account with its opening balance, every movement and its closing balance,
+// @Description conversions show the offer they were made with
+// @Tags Account
+// @Accept  json
+// @Produce  text/csv
+// @Produce  application/pdf
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query StatementRequest true "query params"
+// @Success 200 {file} file "Statement file"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/statement [get]
+func (h *statementHandler) Statement(c *gin.Context) {
+	var req StatementRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = StatementFormatCsv
+	}
+
+	if format != StatementFormatCsv && format != StatementFormatPdf {
+		helper.Error(c, http.StatusBadRequest, errors.ErrAccountStatementError.Error(), "format must be csv or pdf")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	statement, err := h.statementService.GetUserStatement(userId, req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidStatementRequest) {
+			helper.Error(c, http.StatusBadRequest, errors.ErrAccountStatementError.Error(), err.Error())
+			return
+		}
+		helper.Error(c, http.StatusInternalServerError, errors.ErrAccountStatementError.Error(), err.Error())
+		return
+	}
+
+	contentType, body := "application/pdf", statement.PDF()
+	if format == StatementFormatCsv {
+		if body, err = statement.CSV(); err != nil {
+			helper.Error(c, http.StatusInternalServerError, errors.ErrAccountStatementError.Error(), err.Error())
+			return
+		}
+		contentType = "text/csv"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", statement.FileName(format)))
+	c.Data(http.StatusOK, contentType, body)
+}
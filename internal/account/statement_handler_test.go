@@ -0,0 +1,80 @@
+package account
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementHandler_Statement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStatementService := NewMockIStatementService(ctrl)
+	httpHandler := NewStatementHandler(mockStatementService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uint(1)
+	router.GET("/statement", func(c *gin.Context) {
+		c.Set("user_id", userId)
+		httpHandler.Statement(c)
+	})
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/statement"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	statement := &Statement{
+		UserId:         userId,
+		CurrencyCode:   "TRY",
+		Month:          "2022-11",
+		Start:          time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		End:            time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: decimal.NewFromInt(1000),
+		ClosingBalance: decimal.NewFromInt(1000),
+	}
+
+	t.Run("csv by default", func(t *testing.T) {
+		mockStatementService.EXPECT().GetUserStatement(userId, StatementRequest{CurrencyCode: "TRY", Month: "2022-11"}).Return(statement, nil)
+		w := get("?currency=TRY&month=2022-11")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv"))
+		assert.Equal(t, `attachment; filename="statement-TRY-2022-11.csv"`, w.Header().Get("Content-Disposition"))
+		assert.Contains(t, w.Body.String(), "Opening balance,,1000.00")
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		mockStatementService.EXPECT().GetUserStatement(userId, StatementRequest{CurrencyCode: "TRY", Month: "2022-11", Format: "PDF"}).Return(statement, nil)
+		w := get("?currency=TRY&month=2022-11&format=PDF")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("?currency=TRY&format=xlsx").Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		mockStatementService.EXPECT().GetUserStatement(userId, StatementRequest{Month: "2022-11"}).
+			Return(nil, fmt.Errorf("%w: currency is required", ErrInvalidStatementRequest))
+		assert.Equal(t, http.StatusBadRequest, get("?month=2022-11").Code)
+	})
+
+	t.Run("ledger can not be read", func(t *testing.T) {
+		mockStatementService.EXPECT().GetUserStatement(userId, StatementRequest{CurrencyCode: "TRY"}).Return(nil, errors.New("connection refused"))
+		assert.Equal(t, http.StatusInternalServerError, get("?currency=TRY").Code)
+	})
+}
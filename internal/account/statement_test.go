@@ -0,0 +1,146 @@
+package account
+
+import (
+	// Go imports
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+)
+
+func TestStatementService_GetUserStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLedgerService := ledger.NewMockILedgerService(ctrl)
+	mockOfferResolver := NewMockIOfferResolver(ctrl)
+	mockClock := clock.NewMockIClock(ctrl)
+	now := time.Date(2022, 12, 15, 10, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now).AnyTimes()
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	currencyService.Cache.Set("TRY", "TRY", cache.NoExpiration)
+	statementService := NewStatementService(mockLedgerService, mockOfferResolver, currencyService, mockClock)
+	userId := uint(1)
+	start := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("currency is required", func(t *testing.T) {
+		_, err := statementService.GetUserStatement(userId, StatementRequest{Month: "2022-11"})
+		assert.ErrorIs(t, err, ErrInvalidStatementRequest)
+	})
+
+	t.Run("unknown currency", func(t *testing.T) {
+		_, err := statementService.GetUserStatement(userId, StatementRequest{CurrencyCode: "ABC"})
+		assert.ErrorIs(t, err, ErrInvalidStatementRequest)
+	})
+
+	t.Run("invalid month", func(t *testing.T) {
+		_, err := statementService.GetUserStatement(userId, StatementRequest{CurrencyCode: "TRY", Month: "11-2022"})
+		assert.ErrorIs(t, err, ErrInvalidStatementRequest)
+	})
+
+	t.Run("month has not started", func(t *testing.T) {
+		_, err := statementService.GetUserStatement(userId, StatementRequest{CurrencyCode: "TRY", Month: "2023-01"})
+		assert.ErrorIs(t, err, ErrInvalidStatementRequest)
+	})
+
+	t.Run("movements run from the opening to the closing balance", func(t *testing.T) {
+		mockLedgerService.EXPECT().GetUserBalanceBefore(userId, "TRY", start).Return(decimal.NewFromInt(1000), nil)
+		mockLedgerService.EXPECT().ListUserPostings(ledger.UserPostingFilter{UserId: userId, CurrencyCode: "TRY", Start: &start, End: &end}).
+			Return([]ledger.UserPosting{
+				{Id: 9, EntryType: ledger.EntryTypeTransfer, ReferenceType: ledger.ReferenceTypeTransfer, ReferenceId: 3, CurrencyCode: "TRY", Direction: ledger.DirectionCredit, Amount: decimal.NewFromInt(50)},
+				{Id: 7, EntryType: ledger.EntryTypeConversion, ReferenceType: ledger.ReferenceTypeOffer, ReferenceId: 4, CurrencyCode: "TRY", Direction: ledger.DirectionDebit, Amount: decimal.RequireFromString("200.50")},
+			}, nil)
+		mockOfferResolver.EXPECT().GetUserConversionOffers(userId, []uint{4}).
+			Return([]ConversionOffer{{OfferId: 4, FromCurrencyCode: "TRY", ToCurrencyCode: "USD", ExchangeRate: decimal.RequireFromString("0.0537")}}, nil)
+
+		statement, err := statementService.GetUserStatement(userId, StatementRequest{CurrencyCode: "try", Month: "2022-11"})
+		assert.Nil(t, err)
+		assert.Equal(t, "2022-11", statement.Month)
+		assert.Equal(t, end, statement.End)
+		assert.True(t, statement.OpeningBalance.Equal(decimal.NewFromInt(1000)))
+		assert.True(t, statement.ClosingBalance.Equal(decimal.RequireFromString("849.50")))
+		assert.True(t, statement.TotalCredits.Equal(decimal.NewFromInt(50)))
+		assert.True(t, statement.TotalDebits.Equal(decimal.RequireFromString("200.50")))
+		assert.Len(t, statement.Lines, 2)
+		assert.Equal(t, uint(7), statement.Lines[0].Id)
+		assert.Equal(t, "CONVERSION_DEBIT", statement.Lines[0].Type)
+		assert.Equal(t, "OFFER 4", statement.Lines[0].Reference)
+		assert.Equal(t, "TRY to USD at 0.0537", statement.Lines[0].Description)
+		assert.True(t, statement.Lines[0].Balance.Equal(decimal.RequireFromString("799.50")))
+		assert.Equal(t, "TRANSFER 3", statement.Lines[1].Reference)
+	})
+
+	t.Run("current month by default", func(t *testing.T) {
+		mockLedgerService.EXPECT().GetUserBalanceBefore(userId, "TRY", end).Return(decimal.NewFromInt(10), nil)
+		mockLedgerService.EXPECT().ListUserPostings(gomock.Any()).Return(nil, nil)
+
+		statement, err := statementService.GetUserStatement(userId, StatementRequest{CurrencyCode: "TRY"})
+		assert.Nil(t, err)
+		assert.Equal(t, "2022-12", statement.Month)
+		assert.Empty(t, statement.Lines)
+		assert.True(t, statement.ClosingBalance.Equal(decimal.NewFromInt(10)))
+	})
+
+	t.Run("ledger can not be read", func(t *testing.T) {
+		mockLedgerService.EXPECT().GetUserBalanceBefore(userId, "TRY", start).Return(decimal.Zero, errors.New("connection refused"))
+		_, err := statementService.GetUserStatement(userId, StatementRequest{CurrencyCode: "TRY", Month: "2022-11"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestStatement_Export(t *testing.T) {
+	statement := Statement{
+		UserId:         1,
+		CurrencyCode:   "TRY",
+		Month:          "2022-11",
+		Start:          time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		End:            time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: decimal.NewFromInt(1000),
+		TotalCredits:   decimal.Zero,
+		TotalDebits:    decimal.RequireFromString("200.5"),
+		ClosingBalance: decimal.RequireFromString("799.5"),
+		Lines: []StatementLine{{
+			Id:          7,
+			Type:        "CONVERSION_DEBIT",
+			Reference:   "OFFER 4",
+			Description: "TRY to USD at 0.0537",
+			Amount:      decimal.RequireFromString("-200.5"),
+			Balance:     decimal.RequireFromString("799.5"),
+			CreatedAt:   time.Date(2022, 11, 3, 9, 30, 0, 0, time.UTC),
+		}},
+		GeneratedAt: time.Date(2022, 12, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		out, err := statement.CSV()
+		assert.Nil(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"date,id,type,reference,description,amount,balance",
+			"2022-11-01T00:00:00Z,,,,Opening balance,,1000.00",
+			"2022-11-03T09:30:00Z,7,CONVERSION_DEBIT,OFFER 4,TRY to USD at 0.0537,-200.50,799.50",
+			"2022-12-01T00:00:00Z,,,,Closing balance,,799.50",
+		}, "\n")+"\n", string(out))
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		out := string(statement.PDF())
+		assert.True(t, strings.HasPrefix(out, "%PDF-"))
+		assert.Contains(t, out, "Period: 2022-11-01 - 2022-11-30")
+		assert.Contains(t, out, "OFFER 4")
+		assert.Contains(t, out, "799.50")
+	})
+
+	t.Run("file name", func(t *testing.T) {
+		assert.Equal(t, "statement-TRY-2022-11.pdf", statement.FileName(StatementFormatPdf))
+	})
+}
@@ -0,0 +1,100 @@
+package account
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	// External imports
+	"github.com/shopspring/decimal"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+var (
+	ErrInvalidValuationCurrency = errors.New("invalid valuation currency")
+	ErrValuationUnavailable     = errors.New("balance can not be valued")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// IRateConverter gives the mid rate between two currencies, exchange.IRateConverter satisfies it.
+// It is declared here since the exchange package depends on this one.
+type IRateConverter interface {
+	GetMidRate(fromCurrencyCode, toCurrencyCode string) (decimal.Decimal, error)
+}
+
+// IDefaultCurrencyResolver returns the default currency of a user, the user service satisfies it
+type IDefaultCurrencyResolver interface {
+	GetUserDefaultCurrency(userId uint) (string, error)
+}
+
+type ISummaryService interface {
+	GetUserSummary(userId uint, request SummaryRequest) (*AccountSummary, error)
+}
+
+type summaryService struct {
+	accountRepo      IAccountRepository
+	rateConverter    IRateConverter
+	currencyResolver IDefaultCurrencyResolver
+	currencyService  currency.Service
+	clock            clock.IClock
+}
+
+func NewSummaryService(accountRepository IAccountRepository, rateConverter IRateConverter, currencyResolver IDefaultCurrencyResolver, currencyService currency.Service, clock clock.IClock) ISummaryService {
+	return &summaryService{accountRepo: accountRepository, rateConverter: rateConverter, currencyResolver: currencyResolver, currencyService: currencyService, clock: clock}
+}
+
+// GetUserSummary values every account of the user at the mid rate in the requested currency, or in the user's
+// default currency, and sums them up to the net worth
+func (s *summaryService) GetUserSummary(userId uint, request SummaryRequest) (*AccountSummary, error) {
+	currencyCode := strings.ToUpper(strings.TrimSpace(request.CurrencyCode))
+	if currencyCode == "" {
+		defaultCurrencyCode, err := s.currencyResolver.GetUserDefaultCurrency(userId)
+		if err != nil {
+			return nil, err
+		}
+		currencyCode = strings.ToUpper(defaultCurrencyCode)
+	} else if ok := s.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidValuationCurrency, currencyCode)
+	}
+
+	accounts, err := s.accountRepo.ListUserAccounts(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AccountSummary{CurrencyCode: currencyCode, NetWorth: decimal.Zero, Accounts: []AccountValuation{}, ValuedAt: s.clock.Now()}
+	for _, account := range accounts {
+		valuation := AccountValuation{CurrencyCode: account.CurrencyCode, Balance: account.Balance, MidRate: decimal.Zero, Value: decimal.Zero, Share: decimal.Zero}
+
+		// An empty account is worth nothing whatever the rate, so a missing rate does not fail the summary
+		if !account.Balance.IsZero() {
+			rate, err := s.rateConverter.GetMidRate(account.CurrencyCode, currencyCode)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s in %s: %s", ErrValuationUnavailable, account.CurrencyCode, currencyCode, err.Error())
+			}
+			valuation.MidRate = rate
+			valuation.Value = currency.Round(account.Balance.Mul(rate), currencyCode)
+		}
+
+		summary.NetWorth = summary.NetWorth.Add(valuation.Value)
+		summary.Accounts = append(summary.Accounts, valuation)
+	}
+
+	if summary.NetWorth.IsPositive() {
+		for i := range summary.Accounts {
+			summary.Accounts[i].Share = summary.Accounts[i].Value.Mul(hundred).Div(summary.NetWorth).Round(2)
+		}
+	}
+
+	sort.SliceStable(summary.Accounts, func(i, j int) bool {
+		return summary.Accounts[i].Value.GreaterThan(summary.Accounts[j].Value)
+	})
+
+	return summary, nil
+}
@@ -0,0 +1,76 @@
+package account
+
+import (
+	// Go imports
+	"net/http"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/common"
+)
+
+type SummaryHandler interface {
+	Summary(c *gin.Context)
+	SummaryRoutes(router *gin.RouterGroup)
+}
+
+type summaryHandler struct {
+	summaryService ISummaryService
+}
+
+func NewSummaryHandler(summaryService ISummaryService) SummaryHandler {
+	return &summaryHandler{summaryService: summaryService}
+}
+
+func (h *summaryHandler) SummaryRoutes(router *gin.RouterGroup) {
+	router.GET("/summary", h.Summary)
+}
+
+// Summary godoc
+// @Summary Get Account Summary
+// @Description Value every balance of the user at the current mid rate in the default currency of the user or in the requested currency,
+// @Description with the net worth and the share of each currency in it
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query SummaryRequest false "query params"
+// @Success 200 {object} helper.Response{data=AccountSummary} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Failure 503 {object} helper.Response{error=helper.ResponseError} "Service Unavailable"
+// @Router /account/summary [get]
+func (h *summaryHandler) Summary(c *gin.Context) {
+	var req SummaryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	summary, err := h.summaryService.GetUserSummary(userId, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidValuationCurrency):
+			helper.Error(c, http.StatusBadRequest, errors.ErrAccountSummaryError.Error(), err.Error())
+		case errors.Is(err, ErrValuationUnavailable):
+			helper.Error(c, http.StatusServiceUnavailable, errors.ErrAccountSummaryError.Error(), err.Error())
+		default:
+			helper.Error(c, http.StatusInternalServerError, errors.ErrAccountSummaryError.Error(), err.Error())
+		}
+		return
+	}
+
+	helper.Success(c, summary)
+}
@@ -0,0 +1,61 @@
+package account
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryHandler_Summary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSummaryService := NewMockISummaryService(ctrl)
+	httpHandler := NewSummaryHandler(mockSummaryService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uint(1)
+	router.GET("/summary", func(c *gin.Context) {
+		c.Set("user_id", userId)
+		httpHandler.Summary(c)
+	})
+
+	summary := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/summary"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("summary in the requested currency", func(t *testing.T) {
+		mockSummaryService.EXPECT().GetUserSummary(userId, SummaryRequest{CurrencyCode: "USD"}).
+			Return(&AccountSummary{CurrencyCode: "USD", NetWorth: decimal.RequireFromString("153.70"), Accounts: []AccountValuation{}}, nil)
+		w := summary("?currency=USD")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"net_worth":"153.7"`)
+	})
+
+	t.Run("unknown valuation currency", func(t *testing.T) {
+		mockSummaryService.EXPECT().GetUserSummary(userId, SummaryRequest{CurrencyCode: "ABC"}).
+			Return(nil, fmt.Errorf("%w: ABC", ErrInvalidValuationCurrency))
+		assert.Equal(t, http.StatusBadRequest, summary("?currency=ABC").Code)
+	})
+
+	t.Run("rate is not available", func(t *testing.T) {
+		mockSummaryService.EXPECT().GetUserSummary(userId, SummaryRequest{}).
+			Return(nil, fmt.Errorf("%w: TRY in USD: rate not found", ErrValuationUnavailable))
+		assert.Equal(t, http.StatusServiceUnavailable, summary("").Code)
+	})
+
+	t.Run("accounts can not be read", func(t *testing.T) {
+		mockSummaryService.EXPECT().GetUserSummary(userId, SummaryRequest{}).Return(nil, errors.New("connection refused"))
+		assert.Equal(t, http.StatusInternalServerError, summary("").Code)
+	})
+}
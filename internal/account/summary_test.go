@@ -0,0 +1,91 @@
+package account
+
+import (
+	// Go imports
+	"errors"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+func TestSummaryService_GetUserSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAccountRepository := NewMockIAccountRepository(ctrl)
+	mockRateConverter := NewMockIRateConverter(ctrl)
+	mockCurrencyResolver := NewMockIDefaultCurrencyResolver(ctrl)
+	mockClock := clock.NewMockIClock(ctrl)
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now).AnyTimes()
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	currencyService.Cache.Set("USD", "USD", cache.NoExpiration)
+	summaryService := NewSummaryService(mockAccountRepository, mockRateConverter, mockCurrencyResolver, currencyService, mockClock)
+	userId := uint(1)
+
+	accounts := []Account{
+		{CurrencyCode: "TRY", UserId: userId, Balance: decimal.NewFromInt(1000)},
+		{CurrencyCode: "USD", UserId: userId, Balance: decimal.NewFromInt(100), HeldBalance: decimal.NewFromInt(40)},
+		{CurrencyCode: "EUR", UserId: userId, Balance: decimal.Zero},
+	}
+
+	t.Run("valued in the default currency", func(t *testing.T) {
+		mockCurrencyResolver.EXPECT().GetUserDefaultCurrency(userId).Return("try", nil)
+		mockAccountRepository.EXPECT().ListUserAccounts(userId).Return(accounts, nil)
+		mockRateConverter.EXPECT().GetMidRate("TRY", "TRY").Return(decimal.NewFromInt(1), nil)
+		mockRateConverter.EXPECT().GetMidRate("USD", "TRY").Return(decimal.RequireFromString("18.634"), nil)
+
+		summary, err := summaryService.GetUserSummary(userId, SummaryRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, "TRY", summary.CurrencyCode)
+		assert.Equal(t, now, summary.ValuedAt)
+		assert.True(t, summary.NetWorth.Equal(decimal.RequireFromString("2863.40")))
+		assert.Len(t, summary.Accounts, 3)
+		assert.Equal(t, "USD", summary.Accounts[0].CurrencyCode)
+		assert.True(t, summary.Accounts[0].Value.Equal(decimal.RequireFromString("1863.40")))
+		assert.True(t, summary.Accounts[0].Share.Equal(decimal.RequireFromString("65.08")))
+		assert.True(t, summary.Accounts[1].Share.Equal(decimal.RequireFromString("34.92")))
+		assert.Equal(t, "EUR", summary.Accounts[2].CurrencyCode)
+		assert.True(t, summary.Accounts[2].Share.IsZero())
+	})
+
+	t.Run("valued in the requested currency", func(t *testing.T) {
+		mockAccountRepository.EXPECT().ListUserAccounts(userId).Return(accounts[:2], nil)
+		mockRateConverter.EXPECT().GetMidRate("TRY", "USD").Return(decimal.RequireFromString("0.0537"), nil)
+		mockRateConverter.EXPECT().GetMidRate("USD", "USD").Return(decimal.NewFromInt(1), nil)
+
+		summary, err := summaryService.GetUserSummary(userId, SummaryRequest{CurrencyCode: "usd"})
+		assert.Nil(t, err)
+		assert.Equal(t, "USD", summary.CurrencyCode)
+		assert.True(t, summary.NetWorth.Equal(decimal.RequireFromString("153.70")))
+	})
+
+	t.Run("unknown valuation currency", func(t *testing.T) {
+		_, err := summaryService.GetUserSummary(userId, SummaryRequest{CurrencyCode: "ABC"})
+		assert.ErrorIs(t, err, ErrInvalidValuationCurrency)
+	})
+
+	t.Run("rate is not available", func(t *testing.T) {
+		mockAccountRepository.EXPECT().ListUserAccounts(userId).Return(accounts[:1], nil)
+		mockRateConverter.EXPECT().GetMidRate("TRY", "USD").Return(decimal.Zero, errors.New("rate not found"))
+
+		_, err := summaryService.GetUserSummary(userId, SummaryRequest{CurrencyCode: "USD"})
+		assert.ErrorIs(t, err, ErrValuationUnavailable)
+	})
+
+	t.Run("no accounts", func(t *testing.T) {
+		mockAccountRepository.EXPECT().ListUserAccounts(userId).Return(nil, nil)
+
+		summary, err := summaryService.GetUserSummary(userId, SummaryRequest{CurrencyCode: "USD"})
+		assert.Nil(t, err)
+		assert.True(t, summary.NetWorth.IsZero())
+		assert.Empty(t, summary.Accounts)
+	})
+}
@@ -0,0 +1,22 @@
+package clock
+
+import (
+	// Go imports
+	"time"
+)
+
+// IClock tells the server time. Services read the time through it instead of calling time.Now,
+// so tests can decide what time it is.
+type IClock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func NewClock() IClock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/clock (interfaces: IClock)
+
+// Package clock is a generated GoMock package.
+package clock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIClock is a mock of IClock interface.
+type MockIClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockIClockMockRecorder
+}
+
+// MockIClockMockRecorder is the mock recorder for MockIClock.
+type MockIClockMockRecorder struct {
+	mock *MockIClock
+}
+
+// NewMockIClock creates a new mock instance.
+func NewMockIClock(ctrl *gomock.Controller) *MockIClock {
+	mock := &MockIClock{ctrl: ctrl}
+	mock.recorder = &MockIClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIClock) EXPECT() *MockIClockMockRecorder {
+	return m.recorder
+}
+
+// Now mocks base method.
+func (m *MockIClock) Now() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockIClockMockRecorder) Now() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockIClock)(nil).Now))
+}
@@ -0,0 +1,66 @@
+package currency
+
+import (
+	// Go imports
+	"strings"
+
+	// External imports
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultMinorUnits is used for every currency which is not listed in minorUnits
+	DefaultMinorUnits int32 = 2
+	// RatePrecision is the number of decimal places kept on exchange rates
+	RatePrecision int32 = 6
+)
+
+// minorUnits holds ISO 4217 exponents which differ from DefaultMinorUnits
+var minorUnits = map[string]int32{
+	"BIF": 0,
+	"CLP": 0,
+	"DJF": 0,
+	"GNF": 0,
+	"ISK": 0,
+	"JPY": 0,
+	"KMF": 0,
+	"KRW": 0,
+	"PYG": 0,
+	"RWF": 0,
+	"UGX": 0,
+	"VND": 0,
+	"VUV": 0,
+	"XAF": 0,
+	"XOF": 0,
+	"XPF": 0,
+	"BHD": 3,
+	"IQD": 3,
+	"JOD": 3,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"TND": 3,
+}
+
+// MinorUnits returns the number of decimal places the given currency is settled with
+func MinorUnits(currencyCode string) int32 {
+	if units, ok := minorUnits[strings.ToUpper(currencyCode)]; ok {
+		return units
+	}
+	return DefaultMinorUnits
+}
+
+// Round rounds the amount half away from zero to the minor units of the given currency
+func Round(amount decimal.Decimal, currencyCode string) decimal.Decimal {
+	return amount.Round(MinorUnits(currencyCode))
+}
+
+// RoundUp rounds the amount up to the minor units of the given currency, used when the amount must cover a target
+func RoundUp(amount decimal.Decimal, currencyCode string) decimal.Decimal {
+	return amount.RoundCeil(MinorUnits(currencyCode))
+}
+
+// RoundRate rounds the exchange rate to RatePrecision decimal places
+func RoundRate(rate decimal.Decimal) decimal.Decimal {
+	return rate.Round(RatePrecision)
+}
@@ -0,0 +1,53 @@
+package currency
+
+import (
+	// Go imports
+	"testing"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, int32(2), MinorUnits("TRY"))
+	assert.Equal(t, int32(2), MinorUnits("usd"))
+	assert.Equal(t, int32(0), MinorUnits("JPY"))
+	assert.Equal(t, int32(3), MinorUnits("KWD"))
+}
+
+func TestRound(t *testing.T) {
+	t.Run("two minor units", func(t *testing.T) {
+		amount := decimal.RequireFromString("10.005")
+		assert.True(t, Round(amount, "EUR").Equal(decimal.RequireFromString("10.01")))
+	})
+
+	t.Run("zero minor units", func(t *testing.T) {
+		amount := decimal.RequireFromString("1520.5")
+		assert.True(t, Round(amount, "JPY").Equal(decimal.RequireFromString("1521")))
+	})
+
+	t.Run("three minor units", func(t *testing.T) {
+		amount := decimal.RequireFromString("0.12345")
+		assert.True(t, Round(amount, "KWD").Equal(decimal.RequireFromString("0.123")))
+	})
+
+	t.Run("repeated conversions do not drift", func(t *testing.T) {
+		total := decimal.Zero
+		for i := 0; i < 1000; i++ {
+			total = total.Add(Round(decimal.RequireFromString("0.1"), "USD"))
+		}
+		assert.True(t, total.Equal(decimal.NewFromInt(100)))
+	})
+}
+
+func TestRoundUp(t *testing.T) {
+	assert.True(t, RoundUp(decimal.RequireFromString("10.001"), "EUR").Equal(decimal.RequireFromString("10.01")))
+	assert.True(t, RoundUp(decimal.RequireFromString("1520.1"), "JPY").Equal(decimal.RequireFromString("1521")))
+	assert.True(t, RoundUp(decimal.RequireFromString("10.00"), "EUR").Equal(decimal.RequireFromString("10")))
+}
+
+func TestRoundRate(t *testing.T) {
+	rate := decimal.RequireFromString("18.6312345678")
+	assert.True(t, RoundRate(rate).Equal(decimal.RequireFromString("18.631235")))
+}
@@ -3,6 +3,7 @@ package exchange
 import (
 	// Go imports
 	"net/http"
+	"strconv"
 
 	// External imports
 	"github.com/asaskevich/govalidator"
@@ -11,6 +12,7 @@ import (
 	// Internal imports
 	"github.com/mehmetokdemir/currency-conversion-service/errors"
 	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/common"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
 )
@@ -18,6 +20,13 @@ import (
 type Handler interface {
 	ExchangeRate(c *gin.Context)
 	AcceptOffer(c *gin.Context)
+	RateHistory(c *gin.Context)
+	PlaceLimitOrder(c *gin.Context)
+	ListLimitOrders(c *gin.Context)
+	CancelLimitOrder(c *gin.Context)
+	ListOffers(c *gin.Context)
+	GetOffer(c *gin.Context)
+	CancelOffer(c *gin.Context)
 	ExchangeRoutes(router *gin.RouterGroup)
 }
 
@@ -33,6 +42,13 @@ func NewExchangeHandler(currencyService currency.Service, exchangeService IExcha
 func (h *exchangeHandler) ExchangeRoutes(router *gin.RouterGroup) {
 	router.POST("/rate", h.ExchangeRate)
 	router.POST("/accept/offer", h.AcceptOffer)
+	router.GET("/rates/history", h.RateHistory)
+	router.POST("/limit-orders", h.PlaceLimitOrder)
+	router.GET("/limit-orders", h.ListLimitOrders)
+	router.DELETE("/limit-orders/:id", h.CancelLimitOrder)
+	router.GET("/offers", h.ListOffers)
+	router.GET("/offers/:id", h.GetOffer)
+	router.DELETE("/offers/:id", h.CancelOffer)
 }
 
 // ExchangeRate godoc
@@ -71,6 +87,14 @@ func (h *exchangeHandler) ExchangeRate(c *gin.Context) {
 
 	exchangeRateResponse, err := h.exchangeService.GetExchangeRateOffer(userId, req)
 	if err != nil {
+		if errors.Is(err, ErrRateNotFound) {
+			helper.Error(c, http.StatusNotFound, errors.ErrExchangeOfferError.Error(), err.Error())
+			return
+		}
+		if errors.Is(err, ErrInvalidOfferAmount) {
+			helper.Error(c, http.StatusBadRequest, errors.ErrExchangeOfferError.Error(), err.Error())
+			return
+		}
 		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangeOfferError.Error(), err.Error())
 		return
 	}
@@ -90,6 +114,7 @@ func (h *exchangeHandler) ExchangeRate(c *gin.Context) {
 // @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
 // @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
 // @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Offer Already Used, Expired or Cancelled"
 // @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
 // @Router /exchange/accept/offer [post]
 func (h *exchangeHandler) AcceptOffer(c *gin.Context) {
@@ -114,9 +139,311 @@ func (h *exchangeHandler) AcceptOffer(c *gin.Context) {
 
 	accountsWithBalances, err := h.exchangeService.AcceptExchangeRateOffer(userId, req)
 	if err != nil {
-		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangeOfferAcceptedError.Error(), err.Error())
+		acceptOfferError(c, err)
 		return
 	}
 
 	helper.Success(c, accountsWithBalances)
 }
+
+// RateHistory godoc
+// @Summary Get Exchange Rate History
+// @Description Get the recorded exchange rates of the given currencies as OHLC buckets
+// @Tags Exchange
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query RateHistoryRequest true "query params"
+// @Success 200 {object} helper.Response{data=RateHistoryResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/rates/history [get]
+func (h *exchangeHandler) RateHistory(c *gin.Context) {
+	var req RateHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	history, err := h.exchangeService.GetExchangeRateHistory(req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRateHistoryQuery) {
+			helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+			return
+		}
+		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangeRateHistoryError.Error(), err.Error())
+		return
+	}
+
+	helper.Success(c, history)
+}
+
+// PlaceLimitOrder godoc
+// @Summary Place Limit Order
+// @Description Reserve the amount and convert it once the offered rate reaches the limit rate
+// @Tags Exchange
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request body LimitOrderRequest true "body params"
+// @Success 200 {object} helper.Response{data=LimitOrderResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/limit-orders [post]
+func (h *exchangeHandler) PlaceLimitOrder(c *gin.Context) {
+	var req LimitOrderRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	order, err := h.exchangeService.PlaceLimitOrder(userId, req)
+	if err != nil {
+		limitOrderError(c, err)
+		return
+	}
+
+	helper.Success(c, order)
+}
+
+// ListLimitOrders godoc
+// @Summary List Limit Orders
+// @Description List the limit orders of the user newest first
+// @Tags Exchange
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query LimitOrderListRequest false "query params"
+// @Success 200 {object} helper.Response{data=[]LimitOrderResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/limit-orders [get]
+func (h *exchangeHandler) ListLimitOrders(c *gin.Context) {
+	var req LimitOrderListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	orders, err := h.exchangeService.ListLimitOrders(userId, req)
+	if err != nil {
+		limitOrderError(c, err)
+		return
+	}
+
+	helper.Success(c, orders)
+}
+
+// CancelLimitOrder godoc
+// @Summary Cancel Limit Order
+// @Description Cancel an open limit order and release its reserved amount
+// @Tags Exchange
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param id path int true "ID of the limit order"
+// @Success 200 {object} helper.Response{data=LimitOrderResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Limit Order Not Open"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/limit-orders/{id} [delete]
+func (h *exchangeHandler) CancelLimitOrder(c *gin.Context) {
+	limitOrderId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrLimitOrderError.Error(), "invalid limit order id")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	order, err := h.exchangeService.CancelLimitOrder(userId, uint(limitOrderId))
+	if err != nil {
+		limitOrderError(c, err)
+		return
+	}
+
+	helper.Success(c, order)
+}
+
+// ListOffers godoc
+// @Summary List Offers
+// @Description List the offers of the user newest first, pending offers past their expiry are listed as expired
+// @Tags Exchange
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query OfferListRequest false "query params"
+// @Success 200 {object} helper.Response{data=OfferListResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/offers [get]
+func (h *exchangeHandler) ListOffers(c *gin.Context) {
+	var req OfferListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	offers, err := h.exchangeService.ListOffers(userId, req)
+	if err != nil {
+		offerError(c, err)
+		return
+	}
+
+	helper.Success(c, offers)
+}
+
+// GetOffer godoc
+// @Summary Get Offer
+// @Description Get an offer of the user
+// @Tags Exchange
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param id path int true "ID of the offer"
+// @Success 200 {object} helper.Response{data=OfferDetailResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/offers/{id} [get]
+func (h *exchangeHandler) GetOffer(c *gin.Context) {
+	offerId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrExchangeOfferError.Error(), "invalid offer id")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	offer, err := h.exchangeService.GetUserOffer(userId, uint(offerId))
+	if err != nil {
+		offerError(c, err)
+		return
+	}
+
+	helper.Success(c, offer)
+}
+
+// CancelOffer godoc
+// @Summary Cancel Offer
+// @Description Cancel a pending offer of the user so it can not be accepted
+// @Tags Exchange
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param id path int true "ID of the offer"
+// @Success 200 {object} helper.Response{data=OfferDetailResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Offer Not Pending"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/offers/{id} [delete]
+func (h *exchangeHandler) CancelOffer(c *gin.Context) {
+	offerId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrExchangeOfferError.Error(), "invalid offer id")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	offer, err := h.exchangeService.CancelOffer(userId, uint(offerId))
+	if err != nil {
+		offerError(c, err)
+		return
+	}
+
+	helper.Success(c, offer)
+}
+
+func offerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidOfferFilter):
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+	case errors.Is(err, ErrOfferNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrOfferAlreadyUsed), errors.Is(err, ErrOfferCancelled), errors.Is(err, ErrOfferExpired):
+		helper.Error(c, http.StatusConflict, errors.ErrExchangeOfferClosedError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangeOfferError.Error(), err.Error())
+	}
+}
+
+func acceptOfferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrOfferNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrOfferAlreadyUsed):
+		helper.Error(c, http.StatusConflict, errors.ErrExchangeOfferUsedError.Error(), err.Error())
+	case errors.Is(err, ErrOfferCancelled), errors.Is(err, ErrOfferExpired):
+		helper.Error(c, http.StatusConflict, errors.ErrExchangeOfferClosedError.Error(), err.Error())
+	case errors.Is(err, ErrAmountBelowMarkupTier), errors.Is(err, ErrOfferAmountMismatch), errors.Is(err, account.ErrNotEnoughBalance):
+		helper.Error(c, http.StatusBadRequest, errors.ErrExchangeOfferAcceptedError.Error(), err.Error())
+	case errors.Is(err, account.ErrAccountFrozen):
+		helper.Error(c, http.StatusConflict, errors.ErrAccountFrozenError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangeOfferAcceptedError.Error(), err.Error())
+	}
+}
+
+func limitOrderError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidLimitOrder), errors.Is(err, account.ErrNotEnoughBalance):
+		helper.Error(c, http.StatusBadRequest, errors.ErrLimitOrderError.Error(), err.Error())
+	case errors.Is(err, ErrLimitOrderNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrLimitOrderClosed):
+		helper.Error(c, http.StatusConflict, errors.ErrLimitOrderClosedError.Error(), err.Error())
+	case errors.Is(err, account.ErrAccountFrozen):
+		helper.Error(c, http.StatusConflict, errors.ErrAccountFrozenError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrLimitOrderError.Error(), err.Error())
+	}
+}
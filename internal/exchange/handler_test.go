@@ -13,6 +13,7 @@ import (
 	// External imports
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	// Internal imports
@@ -37,7 +38,7 @@ func TestExchangeHandler_AcceptOffer(t *testing.T) {
 	t.Run("user not in context", func(t *testing.T) {
 		register := AcceptOfferRequest{
 			OfferId: uint(1),
-			Amount:  100,
+			Amount:  decimal.NewFromInt(100),
 		}
 		router.POST("/accept/offer", httpHandler.AcceptOffer)
 		reqBytes, _ := json.Marshal(register)
@@ -54,7 +55,7 @@ func TestExchangeHandler_AcceptOffer(t *testing.T) {
 	t.Run("invalid request", func(t *testing.T) {
 		register := AcceptOfferRequest{
 			OfferId: uint(1),
-			Amount:  0,
+			Amount:  decimal.NewFromInt(0),
 		}
 		router.POST("/accept/offer", httpHandler.AcceptOffer)
 		reqBytes, _ := json.Marshal(register)
@@ -74,7 +75,7 @@ func TestExchangeHandler_AcceptOffer(t *testing.T) {
 	t.Run("offer successfully accepted", func(t *testing.T) {
 		acceptOfferRequest := AcceptOfferRequest{
 			OfferId: uint(1),
-			Amount:  100,
+			Amount:  decimal.NewFromInt(100),
 		}
 		router.POST("/accept/offer", auth(httpHandler.AcceptOffer))
 		reqBytes, _ := json.Marshal(acceptOfferRequest)
@@ -82,15 +83,15 @@ func TestExchangeHandler_AcceptOffer(t *testing.T) {
 		walletAccounts := []account.WalletAccount{
 			{
 				CurrencyCode: "TRY",
-				Balance:      50,
+				Balance:      decimal.NewFromInt(50),
 			},
 			{
 				CurrencyCode: "EUR",
-				Balance:      35,
+				Balance:      decimal.NewFromInt(35),
 			},
 			{
 				CurrencyCode: "USD",
-				Balance:      60,
+				Balance:      decimal.NewFromInt(60),
 			},
 		}
 
@@ -107,6 +108,86 @@ func TestExchangeHandler_AcceptOffer(t *testing.T) {
 	})
 }
 
+func TestExchangeHandler_AcceptOffer_AlreadyUsed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	httpHandler := NewExchangeHandler(currency.Service{}, mockExchangeService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uint(1)
+	router.POST("/accept/offer", func(c *gin.Context) {
+		c.Set("user_id", userId)
+		httpHandler.AcceptOffer(c)
+	})
+
+	acceptOfferRequest := AcceptOfferRequest{
+		OfferId: uint(1),
+		Amount:  decimal.NewFromInt(100),
+	}
+	mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, acceptOfferRequest).Return(nil, ErrOfferAlreadyUsed)
+
+	reqBytes, _ := json.Marshal(acceptOfferRequest)
+	req, err := http.NewRequest(http.MethodPost, "/accept/offer", bytes.NewReader(reqBytes))
+	if err != nil {
+		t.Fatalf("Could not create request: %v\n", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "EXCHANGE_OFFER_ALREADY_USED")
+}
+
+func TestExchangeHandler_AcceptOffer_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	httpHandler := NewExchangeHandler(currency.Service{}, mockExchangeService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uint(1)
+	router.POST("/accept/offer", func(c *gin.Context) {
+		c.Set("user_id", userId)
+		httpHandler.AcceptOffer(c)
+	})
+
+	acceptOfferRequest := AcceptOfferRequest{
+		OfferId: uint(1),
+		Amount:  decimal.NewFromInt(100),
+	}
+	accept := func() *httptest.ResponseRecorder {
+		reqBytes, _ := json.Marshal(acceptOfferRequest)
+		req, _ := http.NewRequest(http.MethodPost, "/accept/offer", bytes.NewReader(reqBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("offer not found", func(t *testing.T) {
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, acceptOfferRequest).Return(nil, fmt.Errorf("%w: 1", ErrOfferNotFound))
+		assert.Equal(t, http.StatusNotFound, accept().Code)
+	})
+
+	t.Run("offer expired", func(t *testing.T) {
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, acceptOfferRequest).Return(nil, ErrOfferExpired)
+		w := accept()
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "EXCHANGE_OFFER_CLOSED")
+	})
+
+	t.Run("offer cancelled", func(t *testing.T) {
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, acceptOfferRequest).Return(nil, ErrOfferCancelled)
+		w := accept()
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "EXCHANGE_OFFER_CLOSED")
+	})
+
+	t.Run("not enough balance", func(t *testing.T) {
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, acceptOfferRequest).Return(nil, account.ErrNotEnoughBalance)
+		assert.Equal(t, http.StatusBadRequest, accept().Code)
+	})
+}
+
 func TestExchangeHandler_ExchangeRate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockExchangeService := NewMockIExchangeService(ctrl)
@@ -170,7 +251,7 @@ func TestExchangeHandler_ExchangeRate(t *testing.T) {
 			OfferId:          uint(1),
 			FromCurrencyCode: offerRequest.FromCurrencyCode,
 			ToCurrencyCode:   offerRequest.ToCurrencyCode,
-			ExchangeRate:     18.50,
+			ExchangeRate:     decimal.RequireFromString("18.50"),
 		}
 
 		mockExchangeService.EXPECT().GetExchangeRateOffer(userId, offerRequest).Return(&expectedResponse, nil)
@@ -184,3 +265,151 @@ func TestExchangeHandler_ExchangeRate(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestExchangeHandler_RateHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	httpHandler := NewExchangeHandler(currency.Service{}, mockExchangeService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/rates/history", httpHandler.RateHistory)
+
+	t.Run("invalid query", func(t *testing.T) {
+		mockExchangeService.EXPECT().GetExchangeRateHistory(RateHistoryRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Interval: "1s"}).
+			Return(nil, ErrInvalidRateHistoryQuery)
+		req, _ := http.NewRequest(http.MethodGet, "/rates/history?from=USD&to=TRY&interval=1s", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("successfully get rate history", func(t *testing.T) {
+		mockExchangeService.EXPECT().GetExchangeRateHistory(gomock.Any()).
+			Return(&RateHistoryResponse{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Interval: "1h0m0s", Buckets: []RateBucket{}}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/rates/history?from=USD&to=TRY&start=2022-12-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestExchangeHandler_LimitOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	httpHandler := NewExchangeHandler(currency.Service{}, mockExchangeService)
+	gin.SetMode(gin.TestMode)
+	userId := uint(1)
+	router := gin.Default()
+	group := router.Group("/exchange")
+	group.Use(func(c *gin.Context) {
+		c.Set("user_id", userId)
+	})
+	httpHandler.ExchangeRoutes(group)
+
+	t.Run("not enough balance to reserve", func(t *testing.T) {
+		mockExchangeService.EXPECT().PlaceLimitOrder(userId, LimitOrderRequest{
+			FromCurrencyCode: "USD",
+			ToCurrencyCode:   "TRY",
+			Amount:           decimal.RequireFromString("100.00"),
+			LimitRate:        decimal.RequireFromString("19.10"),
+		}).Return(nil, account.ErrNotEnoughBalance)
+		body := []byte(`{"from_currency_code": "USD", "to_currency_code": "TRY", "amount": "100.00", "limit_rate": "19.10"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/exchange/limit-orders", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list open orders", func(t *testing.T) {
+		mockExchangeService.EXPECT().ListLimitOrders(userId, LimitOrderListRequest{Status: "OPEN"}).Return([]LimitOrderResponse{}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/limit-orders?status=OPEN", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("cancel with invalid id", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/exchange/limit-orders/abc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("cancel closed order", func(t *testing.T) {
+		mockExchangeService.EXPECT().CancelLimitOrder(userId, uint(2)).Return(nil, ErrLimitOrderClosed)
+		req, _ := http.NewRequest(http.MethodDelete, "/exchange/limit-orders/2", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("successfully cancel order", func(t *testing.T) {
+		mockExchangeService.EXPECT().CancelLimitOrder(userId, uint(2)).Return(&LimitOrderResponse{Id: 2, Status: LimitOrderStatusCancelled}, nil)
+		req, _ := http.NewRequest(http.MethodDelete, "/exchange/limit-orders/2", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestExchangeHandler_Offers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	httpHandler := NewExchangeHandler(currency.Service{}, mockExchangeService)
+	gin.SetMode(gin.TestMode)
+	userId := uint(1)
+	router := gin.Default()
+	group := router.Group("/exchange")
+	group.Use(func(c *gin.Context) {
+		c.Set("user_id", userId)
+	})
+	httpHandler.ExchangeRoutes(group)
+
+	t.Run("list with invalid filter", func(t *testing.T) {
+		mockExchangeService.EXPECT().ListOffers(userId, OfferListRequest{Status: "USED"}).Return(nil, ErrInvalidOfferFilter)
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/offers?status=USED", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list pending offers of a pair", func(t *testing.T) {
+		mockExchangeService.EXPECT().ListOffers(userId, OfferListRequest{Status: "PENDING", FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Limit: 10}).
+			Return(&OfferListResponse{Offers: []OfferDetailResponse{}}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/offers?status=PENDING&from=USD&to=TRY&limit=10", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("get offer of another user", func(t *testing.T) {
+		mockExchangeService.EXPECT().GetUserOffer(userId, uint(4)).Return(nil, ErrOfferNotFound)
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/offers/4", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("cancel with invalid id", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/exchange/offers/abc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("cancel accepted offer", func(t *testing.T) {
+		mockExchangeService.EXPECT().CancelOffer(userId, uint(4)).Return(nil, ErrOfferAlreadyUsed)
+		req, _ := http.NewRequest(http.MethodDelete, "/exchange/offers/4", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("successfully cancel offer", func(t *testing.T) {
+		mockExchangeService.EXPECT().CancelOffer(userId, uint(4)).Return(&OfferDetailResponse{OfferId: 4, Status: OfferStatusCancelled}, nil)
+		req, _ := http.NewRequest(http.MethodDelete, "/exchange/offers/4", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
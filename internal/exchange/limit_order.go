@@ -0,0 +1,277 @@
+package exchange
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+)
+
+const (
+	defaultLimitOrderLifetime = 7 * 24 * time.Hour
+	maxLimitOrderLifetime     = 90 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidLimitOrder  = errors.New("invalid limit order")
+	ErrLimitOrderNotFound = errors.New("limit order not found")
+	ErrLimitOrderClosed   = errors.New("limit order is not open")
+)
+
+// PlaceLimitOrder stores the order and puts a hold on its amount on the user's from account in one transaction
+func (s *exchangeService) PlaceLimitOrder(userId uint, request LimitOrderRequest) (*LimitOrderResponse, error) {
+	fromCurrencyCode := strings.ToUpper(request.FromCurrencyCode)
+	toCurrencyCode := strings.ToUpper(request.ToCurrencyCode)
+	if fromCurrencyCode == toCurrencyCode {
+		return nil, fmt.Errorf("%w: currencies must differ", ErrInvalidLimitOrder)
+	}
+
+	for _, currencyCode := range []string{fromCurrencyCode, toCurrencyCode} {
+		if ok := s.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+			return nil, fmt.Errorf("%w: currency %s not found", ErrInvalidLimitOrder, currencyCode)
+		}
+	}
+
+	amount := currency.Round(request.Amount, fromCurrencyCode)
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidLimitOrder)
+	}
+
+	limitRate := currency.RoundRate(request.LimitRate)
+	if !limitRate.IsPositive() {
+		return nil, fmt.Errorf("%w: limit rate must be positive", ErrInvalidLimitOrder)
+	}
+
+	now := s.clock.Now()
+	expiresAt := now.Add(defaultLimitOrderLifetime)
+	if request.ExpiresAt != nil {
+		expiresAt = *request.ExpiresAt
+	}
+
+	if !expiresAt.After(now) || expiresAt.Sub(now) > maxLimitOrderLifetime {
+		return nil, fmt.Errorf("%w: expiry must be in the next %d days", ErrInvalidLimitOrder, int(maxLimitOrderLifetime.Hours()/24))
+	}
+
+	if ok := s.accountService.IsUserHasAccountOnGivenCurrency(userId, fromCurrencyCode); !ok {
+		return nil, fmt.Errorf("%s account not found", fromCurrencyCode)
+	}
+
+	if ok := s.accountService.IsUserHasAccountOnGivenCurrency(userId, toCurrencyCode); !ok {
+		if _, err := s.accountService.CreateUserAccount(userId, toCurrencyCode); err != nil {
+			return nil, err
+		}
+	}
+
+	var created *LimitOrder
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		var err error
+		created, err = s.exchangeRepo.WithTx(tx).CreateLimitOrder(LimitOrder{
+			UserId:           userId,
+			FromCurrencyCode: fromCurrencyCode,
+			ToCurrencyCode:   toCurrencyCode,
+			Amount:           amount,
+			LimitRate:        limitRate,
+			Status:           LimitOrderStatusOpen,
+			ExpiresAt:        expiresAt,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+		if err != nil {
+			return err
+		}
+
+		hold, err := s.accountService.WithTx(tx).CreateHold(userId, fromCurrencyCode, amount, ledger.ReferenceTypeLimitOrder, created.Id)
+		if err != nil {
+			return err
+		}
+
+		created.HoldId = hold.Id
+		return s.exchangeRepo.WithTx(tx).UpdateLimitOrder(*created)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toLimitOrderResponse(*created)
+	return &response, nil
+}
+
+func (s *exchangeService) ListLimitOrders(userId uint, request LimitOrderListRequest) ([]LimitOrderResponse, error) {
+	status := LimitOrderStatus(strings.ToUpper(request.Status))
+	switch status {
+	case "", LimitOrderStatusOpen, LimitOrderStatusFilled, LimitOrderStatusCancelled, LimitOrderStatusExpired:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %s", ErrInvalidLimitOrder, request.Status)
+	}
+
+	orders, err := s.exchangeRepo.ListUserLimitOrders(userId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []LimitOrderResponse{}
+	for _, order := range orders {
+		responses = append(responses, toLimitOrderResponse(order))
+	}
+
+	return responses, nil
+}
+
+// CancelLimitOrder closes an open order of the user and gives its reserved amount back
+func (s *exchangeService) CancelLimitOrder(userId, limitOrderId uint) (*LimitOrderResponse, error) {
+	var cancelled *LimitOrder
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		order, err := s.exchangeRepo.WithTx(tx).GetLimitOrderForUpdate(limitOrderId)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && order.UserId != userId) {
+			return fmt.Errorf("%w: %d", ErrLimitOrderNotFound, limitOrderId)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if order.Status != LimitOrderStatusOpen {
+			return fmt.Errorf("%w: order is %s", ErrLimitOrderClosed, order.Status)
+		}
+
+		cancelled = order
+		return s.closeLimitOrder(tx, order, LimitOrderStatusCancelled)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toLimitOrderResponse(*cancelled)
+	return &response, nil
+}
+
+// MatchLimitOrders expires the open orders which are past their expiry and fills the ones whose limit rate is reached.
+// A failing order does not stop the others. An order on a frozen account stays open, it is filled once the account
+// is unfrozen or given back when it expires.
+func (s *exchangeService) MatchLimitOrders() error {
+	orders, err := s.exchangeRepo.ListOpenLimitOrders()
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, order := range orders {
+		if err = s.matchLimitOrder(order); err != nil && !errors.Is(err, account.ErrAccountFrozen) {
+			failures = append(failures, fmt.Sprintf("%d: %s", order.Id, err.Error()))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("can not match limit orders: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+func (s *exchangeService) matchLimitOrder(order LimitOrder) error {
+	if !s.clock.Now().Before(order.ExpiresAt) {
+		_, err := s.expireLimitOrder(order.Id)
+		return err
+	}
+
+	offer, _, err := s.quoteOffer(order.UserId, order.FromCurrencyCode, order.ToCurrencyCode, order.Amount, decimal.Zero)
+	if err != nil {
+		return err
+	}
+
+	if offer.ExchangeRate.LessThan(order.LimitRate) {
+		return nil
+	}
+
+	// The amount is converted through an accepted offer as if the user accepted it, the hold is captured by the conversion
+	return s.unitOfWork.Do(func(tx *gorm.DB) error {
+		locked, err := s.lockOpenLimitOrder(tx, order.Id)
+		if err != nil || locked == nil {
+			return err
+		}
+
+		txExchangeRepo := s.exchangeRepo.WithTx(tx)
+		txAccountService := s.accountService.WithTx(tx)
+		createdOffer, err := txExchangeRepo.CreateOffer(*offer)
+		if err != nil {
+			return err
+		}
+
+		if err = s.acceptOffer(txExchangeRepo, txAccountService, locked.UserId, createdOffer.Id, locked.Amount, &locked.HoldId); err != nil {
+			return err
+		}
+
+		filledAt := s.clock.Now()
+		locked.Status = LimitOrderStatusFilled
+		locked.OfferId = &createdOffer.Id
+		locked.FilledRate = decimal.NewNullDecimal(offer.ExchangeRate)
+		locked.FilledAt = &filledAt
+		locked.UpdatedAt = filledAt
+		return txExchangeRepo.UpdateLimitOrder(*locked)
+	})
+}
+
+// expireLimitOrder closes an open order as expired and gives its reserved amount back,
+// it reports false when the order was closed in the meantime
+func (s *exchangeService) expireLimitOrder(limitOrderId uint) (bool, error) {
+	expired := false
+	err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		locked, err := s.lockOpenLimitOrder(tx, limitOrderId)
+		if err != nil || locked == nil {
+			return err
+		}
+
+		expired = true
+		return s.closeLimitOrder(tx, locked, LimitOrderStatusExpired)
+	})
+	return expired && err == nil, err
+}
+
+// lockOpenLimitOrder returns nil when the order was closed while it was being matched
+func (s *exchangeService) lockOpenLimitOrder(tx *gorm.DB, limitOrderId uint) (*LimitOrder, error) {
+	order, err := s.exchangeRepo.WithTx(tx).GetLimitOrderForUpdate(limitOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != LimitOrderStatusOpen {
+		return nil, nil
+	}
+
+	return order, nil
+}
+
+// closeLimitOrder gives the reserved amount back and closes the locked order with the given status
+func (s *exchangeService) closeLimitOrder(tx *gorm.DB, order *LimitOrder, status LimitOrderStatus) error {
+	if _, err := s.accountService.WithTx(tx).ReleaseHold(order.HoldId); err != nil {
+		return err
+	}
+
+	order.Status = status
+	order.UpdatedAt = s.clock.Now()
+	return s.exchangeRepo.WithTx(tx).UpdateLimitOrder(*order)
+}
+
+func toLimitOrderResponse(order LimitOrder) LimitOrderResponse {
+	return LimitOrderResponse{
+		Id:               order.Id,
+		FromCurrencyCode: order.FromCurrencyCode,
+		ToCurrencyCode:   order.ToCurrencyCode,
+		Amount:           order.Amount,
+		LimitRate:        order.LimitRate,
+		Status:           order.Status,
+		ExpiresAt:        order.ExpiresAt,
+		OfferId:          order.OfferId,
+		FilledRate:       order.FilledRate,
+		FilledAt:         order.FilledAt,
+		CreatedAt:        order.CreatedAt,
+	}
+}
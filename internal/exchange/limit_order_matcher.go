@@ -0,0 +1,52 @@
+package exchange
+
+import (
+	// Go imports
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultLimitOrderMatchInterval is used when no match interval is configured
+const DefaultLimitOrderMatchInterval = 30 * time.Second
+
+// LimitOrderMatcher fills and expires the open limit orders on every interval
+type LimitOrderMatcher struct {
+	exchangeService IExchangeService
+	interval        time.Duration
+	done            chan struct{}
+}
+
+func NewLimitOrderMatcher(exchangeService IExchangeService, interval time.Duration) *LimitOrderMatcher {
+	if interval <= 0 {
+		interval = DefaultLimitOrderMatchInterval
+	}
+	return &LimitOrderMatcher{exchangeService: exchangeService, interval: interval, done: make(chan struct{})}
+}
+
+// Start matches the open orders on every interval until the context is done, a match in progress is finished first
+func (m *LimitOrderMatcher) Start(ctx context.Context) {
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := m.exchangeService.MatchLimitOrders(); err != nil {
+				log.Println("limit orders match:", err.Error())
+			}
+		}
+	}()
+}
+
+// Done is closed once the matcher stopped after its context is done
+func (m *LimitOrderMatcher) Done() <-chan struct{} {
+	return m.done
+}
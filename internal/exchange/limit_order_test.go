@@ -0,0 +1,240 @@
+package exchange
+
+import (
+	// Go imports
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+func TestExchangeService_LimitOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), mockUnitOfWork, config.Config{}, clock.NewClock())
+	userId := uint(1)
+
+	mockExchangeRepository.EXPECT().WithTx(gomock.Any()).Return(mockExchangeRepository).AnyTimes()
+	accService.EXPECT().WithTx(gomock.Any()).Return(accService).AnyTimes()
+	expectTransaction := func() {
+		mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+			return fn(nil)
+		})
+	}
+	holdId := uint(5)
+	openOrder := func() LimitOrder {
+		return LimitOrder{
+			Id:               2,
+			UserId:           userId,
+			FromCurrencyCode: "USD",
+			ToCurrencyCode:   "TRY",
+			Amount:           decimal.NewFromInt(100),
+			LimitRate:        decimal.NewFromInt(19),
+			Status:           LimitOrderStatusOpen,
+			ExpiresAt:        time.Now().Add(time.Hour),
+			HoldId:           holdId,
+		}
+	}
+
+	t.Run("place with expiry in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		_, err := exchService.PlaceLimitOrder(userId, LimitOrderRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Amount: decimal.NewFromInt(100), LimitRate: decimal.NewFromInt(19), ExpiresAt: &expiresAt})
+		assert.ErrorIs(t, err, ErrInvalidLimitOrder)
+	})
+
+	t.Run("place holds the amount", func(t *testing.T) {
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		expectTransaction()
+		mockExchangeRepository.EXPECT().CreateLimitOrder(gomock.Any()).DoAndReturn(func(order LimitOrder) (*LimitOrder, error) {
+			assert.Equal(t, LimitOrderStatusOpen, order.Status)
+			order.Id = 2
+			return &order, nil
+		})
+		accService.EXPECT().CreateHold(userId, "USD", decimal.RequireFromString("100.00"), ledger.ReferenceTypeLimitOrder, uint(2)).
+			Return(&account.Hold{Id: holdId}, nil)
+		mockExchangeRepository.EXPECT().UpdateLimitOrder(gomock.Any()).DoAndReturn(func(order LimitOrder) error {
+			assert.Equal(t, holdId, order.HoldId)
+			return nil
+		})
+
+		order, err := exchService.PlaceLimitOrder(userId, LimitOrderRequest{FromCurrencyCode: "usd", ToCurrencyCode: "try", Amount: decimal.RequireFromString("100.001"), LimitRate: decimal.NewFromInt(19)})
+		assert.Nil(t, err)
+		assert.Equal(t, uint(2), order.Id)
+		assert.True(t, order.ExpiresAt.After(time.Now().Add(6*24*time.Hour)))
+	})
+
+	t.Run("cancel order of another user", func(t *testing.T) {
+		expectTransaction()
+		order := openOrder()
+		order.UserId = 7
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(order.Id).Return(&order, nil)
+
+		_, err := exchService.CancelLimitOrder(userId, order.Id)
+		assert.ErrorIs(t, err, ErrLimitOrderNotFound)
+	})
+
+	t.Run("cancel filled order", func(t *testing.T) {
+		expectTransaction()
+		order := openOrder()
+		order.Status = LimitOrderStatusFilled
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(order.Id).Return(&order, nil)
+
+		_, err := exchService.CancelLimitOrder(userId, order.Id)
+		assert.ErrorIs(t, err, ErrLimitOrderClosed)
+	})
+
+	t.Run("place without enough balance", func(t *testing.T) {
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		expectTransaction()
+		mockExchangeRepository.EXPECT().CreateLimitOrder(gomock.Any()).DoAndReturn(func(order LimitOrder) (*LimitOrder, error) {
+			order.Id = 2
+			return &order, nil
+		})
+		accService.EXPECT().CreateHold(userId, "USD", gomock.Any(), ledger.ReferenceTypeLimitOrder, uint(2)).Return(nil, account.ErrNotEnoughBalance)
+
+		_, err := exchService.PlaceLimitOrder(userId, LimitOrderRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Amount: decimal.NewFromInt(100), LimitRate: decimal.NewFromInt(19)})
+		assert.ErrorIs(t, err, account.ErrNotEnoughBalance)
+	})
+
+	t.Run("cancel releases the hold", func(t *testing.T) {
+		expectTransaction()
+		order := openOrder()
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(order.Id).Return(&order, nil)
+		accService.EXPECT().ReleaseHold(holdId).Return(&account.Hold{Id: holdId, Status: account.HoldStatusReleased}, nil)
+		mockExchangeRepository.EXPECT().UpdateLimitOrder(gomock.Any()).DoAndReturn(func(order LimitOrder) error {
+			assert.Equal(t, LimitOrderStatusCancelled, order.Status)
+			return nil
+		})
+
+		response, err := exchService.CancelLimitOrder(userId, order.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, LimitOrderStatusCancelled, response.Status)
+	})
+
+	t.Run("list with unknown status", func(t *testing.T) {
+		_, err := exchService.ListLimitOrders(userId, LimitOrderListRequest{Status: "DONE"})
+		assert.ErrorIs(t, err, ErrInvalidLimitOrder)
+	})
+
+	t.Run("match expires and fills orders", func(t *testing.T) {
+		expired := openOrder()
+		expired.Id = 3
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		notReached := openOrder()
+		notReached.Id = 4
+		notReached.LimitRate = decimal.NewFromInt(20)
+		reached := openOrder()
+		mockExchangeRepository.EXPECT().ListOpenLimitOrders().Return([]LimitOrder{expired, notReached, reached}, nil)
+
+		// Expired order is closed and its hold released
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(expired.Id).Return(&expired, nil)
+		accService.EXPECT().ReleaseHold(holdId).Return(&account.Hold{Id: holdId, Status: account.HoldStatusReleased}, nil)
+		mockExchangeRepository.EXPECT().UpdateLimitOrder(gomock.Any()).DoAndReturn(func(order LimitOrder) error {
+			assert.Equal(t, LimitOrderStatusExpired, order.Status)
+			return nil
+		})
+
+		// Both orders are quoted at 19 which is below the limit of the first one
+		mockExchangeRepository.EXPECT().GetExchangeRate("USD", "TRY").
+			Return(&Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(20), MarkupRate: decimal.NewFromInt(1)}, nil).Times(2)
+		mockExchangeRepository.EXPECT().ListMarkupRules("USD", "TRY").Return(nil, nil).Times(2)
+
+		// Reached order is converted by capturing its hold
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(reached.Id).Return(&reached, nil)
+		var filledOffer Offer
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			offer.Id = 9
+			filledOffer = offer
+			return &offer, nil
+		})
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(uint(9)).DoAndReturn(func(uint) (*Offer, error) {
+			return &filledOffer, nil
+		})
+		// The held amount is not available, the capture spends it
+		accService.EXPECT().LockUserAccounts(userId, "USD", "TRY").Return(map[string]decimal.Decimal{"USD": decimal.Zero, "TRY": decimal.Zero}, nil)
+		conversionEntry := ledger.NewConversionEntry(userId, 9, "USD", "TRY", decimal.NewFromInt(100), decimal.RequireFromString("1900"))
+		accService.EXPECT().CaptureHold(holdId, gomock.Any()).DoAndReturn(func(_ uint, entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+			assert.Equal(t, ledger.EntryTypeConversion, entry.Type)
+			assert.True(t, entry.Postings[3].Amount.Equal(conversionEntry.Postings[3].Amount))
+			return &entry, nil
+		})
+		mockExchangeRepository.EXPECT().UpdateOffer(gomock.Any()).Return(nil)
+		mockExchangeRepository.EXPECT().UpdateLimitOrder(gomock.Any()).DoAndReturn(func(order LimitOrder) error {
+			assert.Equal(t, LimitOrderStatusFilled, order.Status)
+			assert.Equal(t, uint(9), *order.OfferId)
+			assert.True(t, order.FilledRate.Decimal.Equal(decimal.NewFromInt(19)))
+			return nil
+		})
+
+		assert.Nil(t, exchService.MatchLimitOrders())
+	})
+
+	t.Run("match leaves order on a frozen account open", func(t *testing.T) {
+		frozen := openOrder()
+		mockExchangeRepository.EXPECT().ListOpenLimitOrders().Return([]LimitOrder{frozen}, nil)
+		mockExchangeRepository.EXPECT().GetExchangeRate("USD", "TRY").
+			Return(&Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(20), MarkupRate: decimal.NewFromInt(1)}, nil)
+		mockExchangeRepository.EXPECT().ListMarkupRules("USD", "TRY").Return(nil, nil)
+
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(frozen.Id).Return(&frozen, nil)
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			offer.Id = 9
+			return &offer, nil
+		})
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(uint(9)).DoAndReturn(func(uint) (*Offer, error) {
+			return &Offer{Id: 9, FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(19), Status: OfferStatusPending, ExpiresAt: time.Now().Add(time.Minute).Unix()}, nil
+		})
+		accService.EXPECT().LockUserAccounts(userId, "USD", "TRY").Return(map[string]decimal.Decimal{"USD": decimal.Zero, "TRY": decimal.Zero}, nil)
+		accService.EXPECT().CaptureHold(holdId, gomock.Any()).Return(nil, fmt.Errorf("%w: USD account of user %d", account.ErrAccountFrozen, userId))
+
+		assert.Nil(t, exchService.MatchLimitOrders())
+	})
+}
+
+func TestLimitOrderMatcher_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	matcher := NewLimitOrderMatcher(mockExchangeService, time.Millisecond)
+
+	matched := make(chan struct{}, 2)
+	mockExchangeService.EXPECT().MatchLimitOrders().DoAndReturn(func() error {
+		select {
+		case matched <- struct{}{}:
+		default:
+		}
+		return nil
+	}).MinTimes(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	matcher.Start(ctx)
+	<-matched
+	<-matched
+	cancel()
+	<-matcher.Done()
+}
@@ -0,0 +1,163 @@
+package exchange
+
+import (
+	// Go imports
+	"fmt"
+	"sort"
+
+	// External imports
+	"github.com/shopspring/decimal"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+// MarkupType is how the markup of a rule is computed from its value
+type MarkupType string
+
+const (
+	// MarkupTypeAbsolute subtracts the value from the mid rate as it is
+	MarkupTypeAbsolute MarkupType = "ABSOLUTE"
+	// MarkupTypeBasisPoints subtracts the value in hundredths of a percent of the mid rate
+	MarkupTypeBasisPoints MarkupType = "BASIS_POINTS"
+	// MarkupTypePairDefault is reported when the pair has no markup rules and its MarkupRate is used
+	MarkupTypePairDefault MarkupType = "PAIR_DEFAULT"
+)
+
+var basisPointsPerUnit = decimal.NewFromInt(10000)
+
+// IUserSegmentResolver returns the pricing segment of a user, markup rules may differ per segment
+type IUserSegmentResolver interface {
+	GetUserSegment(userId uint) (string, error)
+}
+
+// IMarkupStrategy computes the markup subtracted from the mid rate of an amount in from currency
+type IMarkupStrategy interface {
+	Markup(midRate, amount decimal.Decimal) AppliedMarkup
+}
+
+type absoluteMarkup struct {
+	rule MarkupRule
+}
+
+func (m absoluteMarkup) Markup(_, _ decimal.Decimal) AppliedMarkup {
+	return newAppliedMarkup(m.rule, m.rule.Value)
+}
+
+type basisPointsMarkup struct {
+	rule MarkupRule
+}
+
+func (m basisPointsMarkup) Markup(midRate, _ decimal.Decimal) AppliedMarkup {
+	return newAppliedMarkup(m.rule, currency.RoundRate(midRate.Mul(m.rule.Value).Div(basisPointsPerUnit)))
+}
+
+// tieredMarkup uses the tier with the highest minimum amount the amount reaches, so larger notionals get lower markups
+type tieredMarkup struct {
+	tiers []IMarkupStrategy
+	rules []MarkupRule
+}
+
+func (m tieredMarkup) Markup(midRate, amount decimal.Decimal) AppliedMarkup {
+	selected := 0
+	for i, rule := range m.rules {
+		if amount.GreaterThanOrEqual(rule.MinAmount) {
+			selected = i
+		}
+	}
+	return m.tiers[selected].Markup(midRate, amount)
+}
+
+// pairDefaultMarkup is used on pairs without markup rules, the markup rate of the rate path is subtracted
+type pairDefaultMarkup struct {
+	path ratePath
+}
+
+func (m pairDefaultMarkup) Markup(_, _ decimal.Decimal) AppliedMarkup {
+	markupRate := m.path.MarkupRate()
+	return AppliedMarkup{Type: MarkupTypePairDefault, Value: markupRate, Rate: markupRate, MinAmount: decimal.Zero}
+}
+
+// newMarkupStrategy picks the rules of the user segment, falling back to the rules for every user,
+// and falls back to the markup rate of the pair when there are no rules
+func newMarkupStrategy(rules []MarkupRule, segment string, path ratePath) IMarkupStrategy {
+	var selected []MarkupRule
+	for _, candidate := range []string{segment, ""} {
+		for _, rule := range rules {
+			if rule.Segment == candidate {
+				selected = append(selected, rule)
+			}
+		}
+
+		if len(selected) > 0 {
+			break
+		}
+	}
+
+	if len(selected) == 0 {
+		return pairDefaultMarkup{path: path}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].MinAmount.LessThan(selected[j].MinAmount)
+	})
+
+	// The lowest tier applies to every amount below the next tier
+	selected[0].MinAmount = decimal.Zero
+
+	tiers := make([]IMarkupStrategy, 0, len(selected))
+	for _, rule := range selected {
+		if rule.Type == MarkupTypeBasisPoints {
+			tiers = append(tiers, basisPointsMarkup{rule: rule})
+		} else {
+			tiers = append(tiers, absoluteMarkup{rule: rule})
+		}
+	}
+
+	if len(tiers) == 1 {
+		return tiers[0]
+	}
+
+	return tieredMarkup{tiers: tiers, rules: selected}
+}
+
+func newAppliedMarkup(rule MarkupRule, markupRate decimal.Decimal) AppliedMarkup {
+	return AppliedMarkup{
+		Type:      rule.Type,
+		Value:     rule.Value,
+		Segment:   rule.Segment,
+		MinAmount: rule.MinAmount,
+		Rate:      markupRate,
+	}
+}
+
+// validateMarkupRules checks a rule set of a pair, a segment can not have two tiers starting at the same amount
+func validateMarkupRules(rules []MarkupRule) error {
+	tiers := make(map[string]bool)
+	for _, rule := range rules {
+		switch rule.Type {
+		case MarkupTypeAbsolute:
+			if rule.Value.IsNegative() {
+				return fmt.Errorf("%w: absolute markup can not be negative", ErrInvalidPair)
+			}
+		case MarkupTypeBasisPoints:
+			if rule.Value.IsNegative() || !rule.Value.LessThan(basisPointsPerUnit) {
+				return fmt.Errorf("%w: basis points must be between 0 and 10000", ErrInvalidPair)
+			}
+		default:
+			return fmt.Errorf("%w: unknown markup type %s", ErrInvalidPair, rule.Type)
+		}
+
+		if rule.MinAmount.IsNegative() {
+			return fmt.Errorf("%w: minimum amount can not be negative", ErrInvalidPair)
+		}
+
+		tier := fmt.Sprintf("%s:%s", rule.Segment, rule.MinAmount.String())
+		if tiers[tier] {
+			return fmt.Errorf("%w: more than one tier from %s for segment %q", ErrInvalidPair, rule.MinAmount.String(), rule.Segment)
+		}
+		tiers[tier] = true
+	}
+
+	return nil
+}
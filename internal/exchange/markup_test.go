@@ -0,0 +1,60 @@
+package exchange
+
+import (
+	// Go imports
+	"testing"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMarkupStrategy(t *testing.T) {
+	path := ratePath{legs: []Exchange{{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(20), MarkupRate: decimal.NewFromInt(1)}}}
+	midRate := path.MidRate()
+	rules := []MarkupRule{
+		{Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(50), MinAmount: decimal.NewFromInt(1000)},
+		{Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(100), MinAmount: decimal.NewFromInt(10)},
+		{Segment: "VIP", Type: MarkupTypeAbsolute, Value: decimal.RequireFromString("0.05")},
+	}
+
+	t.Run("pair markup rate without rules", func(t *testing.T) {
+		markup := newMarkupStrategy(nil, "", path).Markup(midRate, decimal.NewFromInt(100))
+		assert.Equal(t, MarkupTypePairDefault, markup.Type)
+		assert.True(t, markup.Rate.Equal(decimal.NewFromInt(1)))
+	})
+
+	t.Run("lowest tier applies below its minimum", func(t *testing.T) {
+		markup := newMarkupStrategy(rules, "", path).Markup(midRate, decimal.NewFromInt(1))
+		assert.True(t, markup.Rate.Equal(decimal.RequireFromString("0.2")))
+		assert.True(t, markup.MinAmount.IsZero())
+	})
+
+	t.Run("higher tier from its minimum", func(t *testing.T) {
+		markup := newMarkupStrategy(rules, "", path).Markup(midRate, decimal.NewFromInt(1000))
+		assert.True(t, markup.Rate.Equal(decimal.RequireFromString("0.1")))
+		assert.True(t, markup.MinAmount.Equal(decimal.NewFromInt(1000)))
+	})
+
+	t.Run("segment rules replace the rules for every user", func(t *testing.T) {
+		markup := newMarkupStrategy(rules, "VIP", path).Markup(midRate, decimal.NewFromInt(5000))
+		assert.Equal(t, MarkupTypeAbsolute, markup.Type)
+		assert.True(t, markup.Rate.Equal(decimal.RequireFromString("0.05")))
+	})
+
+	t.Run("unknown segment falls back to the rules for every user", func(t *testing.T) {
+		markup := newMarkupStrategy(rules, "GOLD", path).Markup(midRate, decimal.NewFromInt(5000))
+		assert.Equal(t, MarkupTypeBasisPoints, markup.Type)
+		assert.Empty(t, markup.Segment)
+	})
+}
+
+func TestValidateMarkupRules(t *testing.T) {
+	valid := MarkupRule{Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(25)}
+
+	assert.Nil(t, validateMarkupRules([]MarkupRule{valid, {Segment: "VIP", Type: MarkupTypeAbsolute, Value: decimal.RequireFromString("0.01")}}))
+	assert.ErrorIs(t, validateMarkupRules([]MarkupRule{valid, valid}), ErrInvalidPair)
+	assert.ErrorIs(t, validateMarkupRules([]MarkupRule{{Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(10000)}}), ErrInvalidPair)
+	assert.ErrorIs(t, validateMarkupRules([]MarkupRule{{Type: MarkupTypeAbsolute, Value: decimal.NewFromInt(-1)}}), ErrInvalidPair)
+	assert.ErrorIs(t, validateMarkupRules([]MarkupRule{{Type: MarkupTypePairDefault}}), ErrInvalidPair)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/exchange (interfaces: IPairService)
+
+// Package exchange is a generated GoMock package.
+package exchange
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPairService is a mock of IPairService interface.
+type MockIPairService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPairServiceMockRecorder
+}
+
+// MockIPairServiceMockRecorder is the mock recorder for MockIPairService.
+type MockIPairServiceMockRecorder struct {
+	mock *MockIPairService
+}
+
+// NewMockIPairService creates a new mock instance.
+func NewMockIPairService(ctrl *gomock.Controller) *MockIPairService {
+	mock := &MockIPairService{ctrl: ctrl}
+	mock.recorder = &MockIPairServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPairService) EXPECT() *MockIPairServiceMockRecorder {
+	return m.recorder
+}
+
+// CreatePair mocks base method.
+func (m *MockIPairService) CreatePair(arg0 uint, arg1 CreatePairRequest) (*PairResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePair", arg0, arg1)
+	ret0, _ := ret[0].(*PairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePair indicates an expected call of CreatePair.
+func (mr *MockIPairServiceMockRecorder) CreatePair(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePair", reflect.TypeOf((*MockIPairService)(nil).CreatePair), arg0, arg1)
+}
+
+// DeletePair mocks base method.
+func (m *MockIPairService) DeletePair(arg0 uint, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePair", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePair indicates an expected call of DeletePair.
+func (mr *MockIPairServiceMockRecorder) DeletePair(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePair", reflect.TypeOf((*MockIPairService)(nil).DeletePair), arg0, arg1, arg2)
+}
+
+// ListMarkupRules mocks base method.
+func (m *MockIPairService) ListMarkupRules(arg0, arg1 string) ([]MarkupRuleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMarkupRules", arg0, arg1)
+	ret0, _ := ret[0].([]MarkupRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMarkupRules indicates an expected call of ListMarkupRules.
+func (mr *MockIPairServiceMockRecorder) ListMarkupRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMarkupRules", reflect.TypeOf((*MockIPairService)(nil).ListMarkupRules), arg0, arg1)
+}
+
+// ListPairAudits mocks base method.
+func (m *MockIPairService) ListPairAudits(arg0, arg1 string) ([]PairAuditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPairAudits", arg0, arg1)
+	ret0, _ := ret[0].([]PairAuditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPairAudits indicates an expected call of ListPairAudits.
+func (mr *MockIPairServiceMockRecorder) ListPairAudits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPairAudits", reflect.TypeOf((*MockIPairService)(nil).ListPairAudits), arg0, arg1)
+}
+
+// ListPairs mocks base method.
+func (m *MockIPairService) ListPairs() ([]PairResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPairs")
+	ret0, _ := ret[0].([]PairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPairs indicates an expected call of ListPairs.
+func (mr *MockIPairServiceMockRecorder) ListPairs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPairs", reflect.TypeOf((*MockIPairService)(nil).ListPairs))
+}
+
+// ReplaceMarkupRules mocks base method.
+func (m *MockIPairService) ReplaceMarkupRules(arg0 uint, arg1, arg2 string, arg3 MarkupRulesRequest) ([]MarkupRuleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMarkupRules", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]MarkupRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceMarkupRules indicates an expected call of ReplaceMarkupRules.
+func (mr *MockIPairServiceMockRecorder) ReplaceMarkupRules(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMarkupRules", reflect.TypeOf((*MockIPairService)(nil).ReplaceMarkupRules), arg0, arg1, arg2, arg3)
+}
+
+// SetPairDisabled mocks base method.
+func (m *MockIPairService) SetPairDisabled(arg0 uint, arg1, arg2 string, arg3 bool) (*PairResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPairDisabled", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*PairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPairDisabled indicates an expected call of SetPairDisabled.
+func (mr *MockIPairServiceMockRecorder) SetPairDisabled(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPairDisabled", reflect.TypeOf((*MockIPairService)(nil).SetPairDisabled), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePair mocks base method.
+func (m *MockIPairService) UpdatePair(arg0 uint, arg1, arg2 string, arg3 UpdatePairRequest) (*PairResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePair", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*PairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePair indicates an expected call of UpdatePair.
+func (mr *MockIPairServiceMockRecorder) UpdatePair(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePair", reflect.TypeOf((*MockIPairService)(nil).UpdatePair), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/exchange (interfaces: IRateConverter)
+
+// Package exchange is a generated GoMock package.
+package exchange
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockIRateConverter is a mock of IRateConverter interface.
+type MockIRateConverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateConverterMockRecorder
+}
+
+// MockIRateConverterMockRecorder is the mock recorder for MockIRateConverter.
+type MockIRateConverterMockRecorder struct {
+	mock *MockIRateConverter
+}
+
+// NewMockIRateConverter creates a new mock instance.
+func NewMockIRateConverter(ctrl *gomock.Controller) *MockIRateConverter {
+	mock := &MockIRateConverter{ctrl: ctrl}
+	mock.recorder = &MockIRateConverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateConverter) EXPECT() *MockIRateConverterMockRecorder {
+	return m.recorder
+}
+
+// GetMidRate mocks base method.
+func (m *MockIRateConverter) GetMidRate(arg0, arg1 string) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMidRate", arg0, arg1)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMidRate indicates an expected call of GetMidRate.
+func (mr *MockIRateConverterMockRecorder) GetMidRate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMidRate", reflect.TypeOf((*MockIRateConverter)(nil).GetMidRate), arg0, arg1)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/exchange (interfaces: IRateProvider)
+
+// Package exchange is a generated GoMock package.
+package exchange
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockIRateProvider is a mock of IRateProvider interface.
+type MockIRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateProviderMockRecorder
+}
+
+// MockIRateProviderMockRecorder is the mock recorder for MockIRateProvider.
+type MockIRateProviderMockRecorder struct {
+	mock *MockIRateProvider
+}
+
+// NewMockIRateProvider creates a new mock instance.
+func NewMockIRateProvider(ctrl *gomock.Controller) *MockIRateProvider {
+	mock := &MockIRateProvider{ctrl: ctrl}
+	mock.recorder = &MockIRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateProvider) EXPECT() *MockIRateProviderMockRecorder {
+	return m.recorder
+}
+
+// GetRates mocks base method.
+func (m *MockIRateProvider) GetRates(arg0 string) (map[string]decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", arg0)
+	ret0, _ := ret[0].(map[string]decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockIRateProviderMockRecorder) GetRates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockIRateProvider)(nil).GetRates), arg0)
+}
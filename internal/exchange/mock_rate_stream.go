@@ -0,0 +1,60 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/exchange (interfaces: IRateStream)
+
+// Package exchange is a generated GoMock package.
+package exchange
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRateStream is a mock of IRateStream interface.
+type MockIRateStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateStreamMockRecorder
+}
+
+// MockIRateStreamMockRecorder is the mock recorder for MockIRateStream.
+type MockIRateStreamMockRecorder struct {
+	mock *MockIRateStream
+}
+
+// NewMockIRateStream creates a new mock instance.
+func NewMockIRateStream(ctrl *gomock.Controller) *MockIRateStream {
+	mock := &MockIRateStream{ctrl: ctrl}
+	mock.recorder = &MockIRateStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateStream) EXPECT() *MockIRateStreamMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockIRateStream) Subscribe(arg0 map[string]bool) *RateSubscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0)
+	ret0, _ := ret[0].(*RateSubscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIRateStreamMockRecorder) Subscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIRateStream)(nil).Subscribe), arg0)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockIRateStream) Unsubscribe(arg0 *RateSubscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", arg0)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockIRateStreamMockRecorder) Unsubscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockIRateStream)(nil).Unsubscribe), arg0)
+}
@@ -6,8 +6,11 @@ package exchange
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	gorm "gorm.io/gorm"
 )
 
 // MockIExchangeRepository is a mock of IExchangeRepository interface.
@@ -33,6 +36,64 @@ func (m *MockIExchangeRepository) EXPECT() *MockIExchangeRepositoryMockRecorder
 	return m.recorder
 }
 
+// ArchiveOffers mocks base method.
+func (m *MockIExchangeRepository) ArchiveOffers(arg0, arg1 time.Time, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveOffers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveOffers indicates an expected call of ArchiveOffers.
+func (mr *MockIExchangeRepositoryMockRecorder) ArchiveOffers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveOffers", reflect.TypeOf((*MockIExchangeRepository)(nil).ArchiveOffers), arg0, arg1, arg2)
+}
+
+// CreateExchange mocks base method.
+func (m *MockIExchangeRepository) CreateExchange(arg0 Exchange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchange", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExchange indicates an expected call of CreateExchange.
+func (mr *MockIExchangeRepositoryMockRecorder) CreateExchange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchange", reflect.TypeOf((*MockIExchangeRepository)(nil).CreateExchange), arg0)
+}
+
+// CreateExchangeAudit mocks base method.
+func (m *MockIExchangeRepository) CreateExchangeAudit(arg0 ExchangeAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchangeAudit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExchangeAudit indicates an expected call of CreateExchangeAudit.
+func (mr *MockIExchangeRepositoryMockRecorder) CreateExchangeAudit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeAudit", reflect.TypeOf((*MockIExchangeRepository)(nil).CreateExchangeAudit), arg0)
+}
+
+// CreateLimitOrder mocks base method.
+func (m *MockIExchangeRepository) CreateLimitOrder(arg0 LimitOrder) (*LimitOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLimitOrder", arg0)
+	ret0, _ := ret[0].(*LimitOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLimitOrder indicates an expected call of CreateLimitOrder.
+func (mr *MockIExchangeRepositoryMockRecorder) CreateLimitOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLimitOrder", reflect.TypeOf((*MockIExchangeRepository)(nil).CreateLimitOrder), arg0)
+}
+
 // CreateOffer mocks base method.
 func (m *MockIExchangeRepository) CreateOffer(arg0 Offer) (*Offer, error) {
 	m.ctrl.T.Helper()
@@ -48,6 +109,64 @@ func (mr *MockIExchangeRepositoryMockRecorder) CreateOffer(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOffer", reflect.TypeOf((*MockIExchangeRepository)(nil).CreateOffer), arg0)
 }
 
+// CreateRateHistory mocks base method.
+func (m *MockIExchangeRepository) CreateRateHistory(arg0 RateHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRateHistory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRateHistory indicates an expected call of CreateRateHistory.
+func (mr *MockIExchangeRepositoryMockRecorder) CreateRateHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRateHistory", reflect.TypeOf((*MockIExchangeRepository)(nil).CreateRateHistory), arg0)
+}
+
+// DeleteExchange mocks base method.
+func (m *MockIExchangeRepository) DeleteExchange(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExchange", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExchange indicates an expected call of DeleteExchange.
+func (mr *MockIExchangeRepositoryMockRecorder) DeleteExchange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExchange", reflect.TypeOf((*MockIExchangeRepository)(nil).DeleteExchange), arg0, arg1)
+}
+
+// ExpireOffers mocks base method.
+func (m *MockIExchangeRepository) ExpireOffers(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOffers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireOffers indicates an expected call of ExpireOffers.
+func (mr *MockIExchangeRepositoryMockRecorder) ExpireOffers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOffers", reflect.TypeOf((*MockIExchangeRepository)(nil).ExpireOffers), arg0)
+}
+
+// GetExchangeForUpdate mocks base method.
+func (m *MockIExchangeRepository) GetExchangeForUpdate(arg0, arg1 string) (*Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeForUpdate indicates an expected call of GetExchangeForUpdate.
+func (mr *MockIExchangeRepositoryMockRecorder) GetExchangeForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeForUpdate", reflect.TypeOf((*MockIExchangeRepository)(nil).GetExchangeForUpdate), arg0, arg1)
+}
+
 // GetExchangeRate mocks base method.
 func (m *MockIExchangeRepository) GetExchangeRate(arg0, arg1 string) (*Exchange, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +182,21 @@ func (mr *MockIExchangeRepositoryMockRecorder) GetExchangeRate(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockIExchangeRepository)(nil).GetExchangeRate), arg0, arg1)
 }
 
+// GetLimitOrderForUpdate mocks base method.
+func (m *MockIExchangeRepository) GetLimitOrderForUpdate(arg0 uint) (*LimitOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitOrderForUpdate", arg0)
+	ret0, _ := ret[0].(*LimitOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimitOrderForUpdate indicates an expected call of GetLimitOrderForUpdate.
+func (mr *MockIExchangeRepositoryMockRecorder) GetLimitOrderForUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitOrderForUpdate", reflect.TypeOf((*MockIExchangeRepository)(nil).GetLimitOrderForUpdate), arg0)
+}
+
 // GetOffer mocks base method.
 func (m *MockIExchangeRepository) GetOffer(arg0 uint) (*Offer, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +212,156 @@ func (mr *MockIExchangeRepositoryMockRecorder) GetOffer(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffer", reflect.TypeOf((*MockIExchangeRepository)(nil).GetOffer), arg0)
 }
 
+// GetOfferForUpdate mocks base method.
+func (m *MockIExchangeRepository) GetOfferForUpdate(arg0 uint) (*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOfferForUpdate", arg0)
+	ret0, _ := ret[0].(*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOfferForUpdate indicates an expected call of GetOfferForUpdate.
+func (mr *MockIExchangeRepositoryMockRecorder) GetOfferForUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfferForUpdate", reflect.TypeOf((*MockIExchangeRepository)(nil).GetOfferForUpdate), arg0)
+}
+
+// ListExchangeAudits mocks base method.
+func (m *MockIExchangeRepository) ListExchangeAudits(arg0, arg1 string) ([]ExchangeAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchangeAudits", arg0, arg1)
+	ret0, _ := ret[0].([]ExchangeAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchangeAudits indicates an expected call of ListExchangeAudits.
+func (mr *MockIExchangeRepositoryMockRecorder) ListExchangeAudits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangeAudits", reflect.TypeOf((*MockIExchangeRepository)(nil).ListExchangeAudits), arg0, arg1)
+}
+
+// ListExchanges mocks base method.
+func (m *MockIExchangeRepository) ListExchanges() ([]Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchanges")
+	ret0, _ := ret[0].([]Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchanges indicates an expected call of ListExchanges.
+func (mr *MockIExchangeRepositoryMockRecorder) ListExchanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchanges", reflect.TypeOf((*MockIExchangeRepository)(nil).ListExchanges))
+}
+
+// ListExpiredLimitOrders mocks base method.
+func (m *MockIExchangeRepository) ListExpiredLimitOrders(arg0 time.Time) ([]LimitOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredLimitOrders", arg0)
+	ret0, _ := ret[0].([]LimitOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredLimitOrders indicates an expected call of ListExpiredLimitOrders.
+func (mr *MockIExchangeRepositoryMockRecorder) ListExpiredLimitOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredLimitOrders", reflect.TypeOf((*MockIExchangeRepository)(nil).ListExpiredLimitOrders), arg0)
+}
+
+// ListMarkupRules mocks base method.
+func (m *MockIExchangeRepository) ListMarkupRules(arg0, arg1 string) ([]MarkupRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMarkupRules", arg0, arg1)
+	ret0, _ := ret[0].([]MarkupRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMarkupRules indicates an expected call of ListMarkupRules.
+func (mr *MockIExchangeRepositoryMockRecorder) ListMarkupRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMarkupRules", reflect.TypeOf((*MockIExchangeRepository)(nil).ListMarkupRules), arg0, arg1)
+}
+
+// ListOpenLimitOrders mocks base method.
+func (m *MockIExchangeRepository) ListOpenLimitOrders() ([]LimitOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenLimitOrders")
+	ret0, _ := ret[0].([]LimitOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenLimitOrders indicates an expected call of ListOpenLimitOrders.
+func (mr *MockIExchangeRepositoryMockRecorder) ListOpenLimitOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenLimitOrders", reflect.TypeOf((*MockIExchangeRepository)(nil).ListOpenLimitOrders))
+}
+
+// ListRateHistory mocks base method.
+func (m *MockIExchangeRepository) ListRateHistory(arg0, arg1 string, arg2, arg3 time.Time) ([]RateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRateHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]RateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRateHistory indicates an expected call of ListRateHistory.
+func (mr *MockIExchangeRepositoryMockRecorder) ListRateHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRateHistory", reflect.TypeOf((*MockIExchangeRepository)(nil).ListRateHistory), arg0, arg1, arg2, arg3)
+}
+
+// ListUserLimitOrders mocks base method.
+func (m *MockIExchangeRepository) ListUserLimitOrders(arg0 uint, arg1 LimitOrderStatus) ([]LimitOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLimitOrders", arg0, arg1)
+	ret0, _ := ret[0].([]LimitOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLimitOrders indicates an expected call of ListUserLimitOrders.
+func (mr *MockIExchangeRepositoryMockRecorder) ListUserLimitOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLimitOrders", reflect.TypeOf((*MockIExchangeRepository)(nil).ListUserLimitOrders), arg0, arg1)
+}
+
+// ListUserOffers mocks base method.
+func (m *MockIExchangeRepository) ListUserOffers(arg0 OfferFilter) ([]Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserOffers", arg0)
+	ret0, _ := ret[0].([]Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserOffers indicates an expected call of ListUserOffers.
+func (mr *MockIExchangeRepositoryMockRecorder) ListUserOffers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOffers", reflect.TypeOf((*MockIExchangeRepository)(nil).ListUserOffers), arg0)
+}
+
+// ListUserOffersByIds mocks base method.
+func (m *MockIExchangeRepository) ListUserOffersByIds(arg0 uint, arg1 []uint) ([]Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserOffersByIds", arg0, arg1)
+	ret0, _ := ret[0].([]Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserOffersByIds indicates an expected call of ListUserOffersByIds.
+func (mr *MockIExchangeRepositoryMockRecorder) ListUserOffersByIds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOffersByIds", reflect.TypeOf((*MockIExchangeRepository)(nil).ListUserOffersByIds), arg0, arg1)
+}
+
 // Migration mocks base method.
 func (m *MockIExchangeRepository) Migration() error {
 	m.ctrl.T.Helper()
@@ -91,3 +375,87 @@ func (mr *MockIExchangeRepositoryMockRecorder) Migration() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockIExchangeRepository)(nil).Migration))
 }
+
+// ReplaceMarkupRules mocks base method.
+func (m *MockIExchangeRepository) ReplaceMarkupRules(arg0, arg1 string, arg2 []MarkupRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMarkupRules", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceMarkupRules indicates an expected call of ReplaceMarkupRules.
+func (mr *MockIExchangeRepositoryMockRecorder) ReplaceMarkupRules(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMarkupRules", reflect.TypeOf((*MockIExchangeRepository)(nil).ReplaceMarkupRules), arg0, arg1, arg2)
+}
+
+// UpdateExchange mocks base method.
+func (m *MockIExchangeRepository) UpdateExchange(arg0 Exchange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExchange", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExchange indicates an expected call of UpdateExchange.
+func (mr *MockIExchangeRepositoryMockRecorder) UpdateExchange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExchange", reflect.TypeOf((*MockIExchangeRepository)(nil).UpdateExchange), arg0)
+}
+
+// UpdateExchangeRate mocks base method.
+func (m *MockIExchangeRepository) UpdateExchangeRate(arg0, arg1 string, arg2 decimal.Decimal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExchangeRate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExchangeRate indicates an expected call of UpdateExchangeRate.
+func (mr *MockIExchangeRepositoryMockRecorder) UpdateExchangeRate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExchangeRate", reflect.TypeOf((*MockIExchangeRepository)(nil).UpdateExchangeRate), arg0, arg1, arg2)
+}
+
+// UpdateLimitOrder mocks base method.
+func (m *MockIExchangeRepository) UpdateLimitOrder(arg0 LimitOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLimitOrder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLimitOrder indicates an expected call of UpdateLimitOrder.
+func (mr *MockIExchangeRepositoryMockRecorder) UpdateLimitOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLimitOrder", reflect.TypeOf((*MockIExchangeRepository)(nil).UpdateLimitOrder), arg0)
+}
+
+// UpdateOffer mocks base method.
+func (m *MockIExchangeRepository) UpdateOffer(arg0 Offer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOffer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOffer indicates an expected call of UpdateOffer.
+func (mr *MockIExchangeRepositoryMockRecorder) UpdateOffer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOffer", reflect.TypeOf((*MockIExchangeRepository)(nil).UpdateOffer), arg0)
+}
+
+// WithTx mocks base method.
+func (m *MockIExchangeRepository) WithTx(arg0 *gorm.DB) IExchangeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IExchangeRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIExchangeRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIExchangeRepository)(nil).WithTx), arg0)
+}
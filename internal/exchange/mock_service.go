@@ -6,9 +6,12 @@ package exchange
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	account "github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	decimal "github.com/shopspring/decimal"
+	gorm "gorm.io/gorm"
 )
 
 // MockIExchangeService is a mock of IExchangeService interface.
@@ -49,8 +52,38 @@ func (mr *MockIExchangeServiceMockRecorder) AcceptExchangeRateOffer(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptExchangeRateOffer", reflect.TypeOf((*MockIExchangeService)(nil).AcceptExchangeRateOffer), arg0, arg1)
 }
 
+// CancelLimitOrder mocks base method.
+func (m *MockIExchangeService) CancelLimitOrder(arg0, arg1 uint) (*LimitOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelLimitOrder", arg0, arg1)
+	ret0, _ := ret[0].(*LimitOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelLimitOrder indicates an expected call of CancelLimitOrder.
+func (mr *MockIExchangeServiceMockRecorder) CancelLimitOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLimitOrder", reflect.TypeOf((*MockIExchangeService)(nil).CancelLimitOrder), arg0, arg1)
+}
+
+// CancelOffer mocks base method.
+func (m *MockIExchangeService) CancelOffer(arg0, arg1 uint) (*OfferDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOffer", arg0, arg1)
+	ret0, _ := ret[0].(*OfferDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOffer indicates an expected call of CancelOffer.
+func (mr *MockIExchangeServiceMockRecorder) CancelOffer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOffer", reflect.TypeOf((*MockIExchangeService)(nil).CancelOffer), arg0, arg1)
+}
+
 // CreateExchangeRateOffer mocks base method.
-func (m *MockIExchangeService) CreateExchangeRateOffer(arg0 uint, arg1, arg2 string, arg3 float64) (uint, error) {
+func (m *MockIExchangeService) CreateExchangeRateOffer(arg0 uint, arg1, arg2 string, arg3 decimal.Decimal) (uint, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateExchangeRateOffer", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(uint)
@@ -64,6 +97,21 @@ func (mr *MockIExchangeServiceMockRecorder) CreateExchangeRateOffer(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRateOffer", reflect.TypeOf((*MockIExchangeService)(nil).CreateExchangeRateOffer), arg0, arg1, arg2, arg3)
 }
 
+// GetExchangeRateHistory mocks base method.
+func (m *MockIExchangeService) GetExchangeRateHistory(arg0 RateHistoryRequest) (*RateHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRateHistory", arg0)
+	ret0, _ := ret[0].(*RateHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRateHistory indicates an expected call of GetExchangeRateHistory.
+func (mr *MockIExchangeServiceMockRecorder) GetExchangeRateHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRateHistory", reflect.TypeOf((*MockIExchangeService)(nil).GetExchangeRateHistory), arg0)
+}
+
 // GetExchangeRateOffer mocks base method.
 func (m *MockIExchangeService) GetExchangeRateOffer(arg0 uint, arg1 OfferRequest) (*OfferResponse, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +126,121 @@ func (mr *MockIExchangeServiceMockRecorder) GetExchangeRateOffer(arg0, arg1 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRateOffer", reflect.TypeOf((*MockIExchangeService)(nil).GetExchangeRateOffer), arg0, arg1)
 }
+
+// GetUserConversionOffers mocks base method.
+func (m *MockIExchangeService) GetUserConversionOffers(arg0 uint, arg1 []uint) ([]account.ConversionOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserConversionOffers", arg0, arg1)
+	ret0, _ := ret[0].([]account.ConversionOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserConversionOffers indicates an expected call of GetUserConversionOffers.
+func (mr *MockIExchangeServiceMockRecorder) GetUserConversionOffers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserConversionOffers", reflect.TypeOf((*MockIExchangeService)(nil).GetUserConversionOffers), arg0, arg1)
+}
+
+// GetUserOffer mocks base method.
+func (m *MockIExchangeService) GetUserOffer(arg0, arg1 uint) (*OfferDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOffer", arg0, arg1)
+	ret0, _ := ret[0].(*OfferDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOffer indicates an expected call of GetUserOffer.
+func (mr *MockIExchangeServiceMockRecorder) GetUserOffer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOffer", reflect.TypeOf((*MockIExchangeService)(nil).GetUserOffer), arg0, arg1)
+}
+
+// ListLimitOrders mocks base method.
+func (m *MockIExchangeService) ListLimitOrders(arg0 uint, arg1 LimitOrderListRequest) ([]LimitOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLimitOrders", arg0, arg1)
+	ret0, _ := ret[0].([]LimitOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLimitOrders indicates an expected call of ListLimitOrders.
+func (mr *MockIExchangeServiceMockRecorder) ListLimitOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLimitOrders", reflect.TypeOf((*MockIExchangeService)(nil).ListLimitOrders), arg0, arg1)
+}
+
+// ListOffers mocks base method.
+func (m *MockIExchangeService) ListOffers(arg0 uint, arg1 OfferListRequest) (*OfferListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOffers", arg0, arg1)
+	ret0, _ := ret[0].(*OfferListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOffers indicates an expected call of ListOffers.
+func (mr *MockIExchangeServiceMockRecorder) ListOffers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOffers", reflect.TypeOf((*MockIExchangeService)(nil).ListOffers), arg0, arg1)
+}
+
+// MatchLimitOrders mocks base method.
+func (m *MockIExchangeService) MatchLimitOrders() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchLimitOrders")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MatchLimitOrders indicates an expected call of MatchLimitOrders.
+func (mr *MockIExchangeServiceMockRecorder) MatchLimitOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchLimitOrders", reflect.TypeOf((*MockIExchangeService)(nil).MatchLimitOrders))
+}
+
+// PlaceLimitOrder mocks base method.
+func (m *MockIExchangeService) PlaceLimitOrder(arg0 uint, arg1 LimitOrderRequest) (*LimitOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceLimitOrder", arg0, arg1)
+	ret0, _ := ret[0].(*LimitOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceLimitOrder indicates an expected call of PlaceLimitOrder.
+func (mr *MockIExchangeServiceMockRecorder) PlaceLimitOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceLimitOrder", reflect.TypeOf((*MockIExchangeService)(nil).PlaceLimitOrder), arg0, arg1)
+}
+
+// SweepOffers mocks base method.
+func (m *MockIExchangeService) SweepOffers(arg0 time.Duration) (*SweepStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepOffers", arg0)
+	ret0, _ := ret[0].(*SweepStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepOffers indicates an expected call of SweepOffers.
+func (mr *MockIExchangeServiceMockRecorder) SweepOffers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepOffers", reflect.TypeOf((*MockIExchangeService)(nil).SweepOffers), arg0)
+}
+
+// WithTx mocks base method.
+func (m *MockIExchangeService) WithTx(arg0 *gorm.DB) IExchangeService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IExchangeService)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIExchangeServiceMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIExchangeService)(nil).WithTx), arg0)
+}
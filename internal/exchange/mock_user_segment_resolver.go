@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/exchange (interfaces: IUserSegmentResolver)
+
+// Package exchange is a generated GoMock package.
+package exchange
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIUserSegmentResolver is a mock of IUserSegmentResolver interface.
+type MockIUserSegmentResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserSegmentResolverMockRecorder
+}
+
+// MockIUserSegmentResolverMockRecorder is the mock recorder for MockIUserSegmentResolver.
+type MockIUserSegmentResolverMockRecorder struct {
+	mock *MockIUserSegmentResolver
+}
+
+// NewMockIUserSegmentResolver creates a new mock instance.
+func NewMockIUserSegmentResolver(ctrl *gomock.Controller) *MockIUserSegmentResolver {
+	mock := &MockIUserSegmentResolver{ctrl: ctrl}
+	mock.recorder = &MockIUserSegmentResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserSegmentResolver) EXPECT() *MockIUserSegmentResolverMockRecorder {
+	return m.recorder
+}
+
+// GetUserSegment mocks base method.
+func (m *MockIUserSegmentResolver) GetUserSegment(arg0 uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSegment", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSegment indicates an expected call of GetUserSegment.
+func (mr *MockIUserSegmentResolverMockRecorder) GetUserSegment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSegment", reflect.TypeOf((*MockIUserSegmentResolver)(nil).GetUserSegment), arg0)
+}
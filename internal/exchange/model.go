@@ -2,49 +2,363 @@ package exchange
 
 import (
 	// Go imports
+	"encoding/json"
 	"time"
 
 	// External imports
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
 type Exchange struct {
-	FromCurrencyCode string  `gorm:"primaryKey;autoIncrement:false"`
-	ToCurrencyCode   string  `gorm:"primaryKey;autoIncrement:false"`
-	ExchangeRate     float64 `gorm:"not null" binding:"required"`
-	MarkupRate       float64
-	CreatedAt        time.Time      `json:"created_at,omitempty"`
-	UpdatedAt        time.Time      `json:"updated_at,omitempty"`
-	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	FromCurrencyCode string          `gorm:"primaryKey;autoIncrement:false"`
+	ToCurrencyCode   string          `gorm:"primaryKey;autoIncrement:false"`
+	ExchangeRate     decimal.Decimal `gorm:"type:numeric;not null" binding:"required"`
+	MarkupRate       decimal.Decimal `gorm:"type:numeric;not null"`
+	FixedFee         decimal.Decimal `gorm:"type:numeric;not null;default:0"` // Fee in to currency deducted from every conversion
+	Disabled         bool            `gorm:"not null;default:false"`          // Disabled pairs are neither quoted nor used for cross rates
+	OfferTTLSeconds  int             `gorm:"not null;default:0"`              // Seconds offers of the pair can be accepted in, the configured offer TTL when zero
+	CreatedAt        time.Time       `json:"created_at,omitempty"`
+	UpdatedAt        time.Time       `json:"updated_at,omitempty"`
+	DeletedAt        gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// MarkupRule is a markup of a pair, rules of the same pair and segment starting at different amounts are tiers.
+// The pair may not be stored on the exchanges table when it is quoted through a cross rate.
+type MarkupRule struct {
+	Id               uint            `gorm:"primaryKey;autoIncrement"`
+	FromCurrencyCode string          `gorm:"not null;index:idx_markup_rules_pair,priority:1"`
+	ToCurrencyCode   string          `gorm:"not null;index:idx_markup_rules_pair,priority:2"`
+	Segment          string          // Empty for every user
+	Type             MarkupType      `gorm:"type:varchar(16);not null"`
+	Value            decimal.Decimal `gorm:"type:numeric;not null"`
+	MinAmount        decimal.Decimal `gorm:"type:numeric;not null"` // In from currency
+	CreatedAt        time.Time
+}
+
+// PairAuditAction is the kind of change an admin made on a pair
+type PairAuditAction string
+
+const (
+	PairAuditActionCreate  PairAuditAction = "CREATE"
+	PairAuditActionUpdate  PairAuditAction = "UPDATE"
+	PairAuditActionDisable PairAuditAction = "DISABLE"
+	PairAuditActionEnable  PairAuditAction = "ENABLE"
+	PairAuditActionDelete  PairAuditAction = "DELETE"
+	PairAuditActionMarkup  PairAuditAction = "MARKUP"
+)
+
+// ExchangeAudit records who changed a pair and how it looked before and after the change
+type ExchangeAudit struct {
+	Id               uint            `gorm:"primaryKey;autoIncrement"`
+	AdminUserId      uint            `gorm:"not null;index"`
+	Action           PairAuditAction `gorm:"type:varchar(16);not null"`
+	FromCurrencyCode string          `gorm:"not null;index:idx_exchange_audits_pair,priority:1"`
+	ToCurrencyCode   string          `gorm:"not null;index:idx_exchange_audits_pair,priority:2"`
+	Before           string          `gorm:"type:text"` // JSON of the pair before the change, empty on create
+	After            string          `gorm:"type:text"` // JSON of the pair after the change, empty on delete
+	CreatedAt        time.Time
+}
+
+// RateHistory is the rate of a pair from the time it is recorded until the next record of the pair
+type RateHistory struct {
+	Id               uint            `gorm:"primaryKey;autoIncrement"`
+	FromCurrencyCode string          `gorm:"not null;index:idx_rate_histories_pair_time,priority:1"`
+	ToCurrencyCode   string          `gorm:"not null;index:idx_rate_histories_pair_time,priority:2"`
+	ExchangeRate     decimal.Decimal `gorm:"type:numeric;not null"`
+	MarkupRate       decimal.Decimal `gorm:"type:numeric;not null"`
+	RecordedAt       time.Time       `gorm:"not null;index:idx_rate_histories_pair_time,priority:3"`
 }
 
 // TODO: New Offer repository
 
+// OfferStatus is the lifecycle state of an offer, an offer can leave pending state only once
+type OfferStatus string
+
+const (
+	OfferStatusPending   OfferStatus = "PENDING"
+	OfferStatusAccepted  OfferStatus = "ACCEPTED"
+	OfferStatusExpired   OfferStatus = "EXPIRED"
+	OfferStatusCancelled OfferStatus = "CANCELLED"
+)
+
 type Offer struct {
-	Id               uint           `gorm:"primaryKey;autoIncrement"`
-	FromCurrencyCode string         `gorm:"not null" binding:"required"`
-	ToCurrencyCode   string         `gorm:"not null" binding:"required"`
-	ExchangeRate     float64        `gorm:"not null" binding:"required"`
-	ExpiresAt        int64          `gorm:"not null" binding:"required"`
-	UserId           uint           `gorm:"not null" binding:"required"`
+	Id               uint                `gorm:"primaryKey;autoIncrement"`
+	FromCurrencyCode string              `gorm:"not null" binding:"required"`
+	ToCurrencyCode   string              `gorm:"not null" binding:"required"`
+	ExchangeRate     decimal.Decimal     `gorm:"type:numeric;not null" binding:"required"`
+	ExpiresAt        int64               `gorm:"not null" binding:"required"`
+	UserId           uint                `gorm:"not null" binding:"required"`
+	Status           OfferStatus         `gorm:"type:varchar(16);not null;default:PENDING;index"`
+	MinAmount        decimal.NullDecimal `gorm:"type:numeric"` // Smallest amount the markup tier of the offer was quoted for
+	SellAmount       decimal.NullDecimal `gorm:"type:numeric"` // Amount in from currency locked into the offer, empty when the offer is only a rate
+	BuyAmount        decimal.NullDecimal `gorm:"type:numeric"` // Amount in to currency locked into the offer, empty when the offer is only a rate
+	FixedFee         decimal.NullDecimal `gorm:"type:numeric"` // Fee in to currency deducted on acceptance, empty when the pair has no fee
+	AcceptedAmount   decimal.NullDecimal `gorm:"type:numeric"`
+	AcceptedAt       *time.Time
 	CreatedAt        time.Time      `json:"created_at,omitempty"`
 	UpdatedAt        time.Time      `json:"updated_at,omitempty"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
+// OfferHistory is a closed offer moved off the offers table after the retention period, kept as it was when archived
+type OfferHistory struct {
+	Offer      `gorm:"embedded"`
+	ArchivedAt time.Time `gorm:"not null"`
+}
+
+// SweepStats is the outcome of a sweep over offers and limit orders
+type SweepStats struct {
+	StartedAt          time.Time `json:"started_at" extensions:"x-order=1" example:"2022-12-01T10:00:00Z"`    // Time the sweep started
+	FinishedAt         time.Time `json:"finished_at" extensions:"x-order=2" example:"2022-12-01T10:00:01Z"`   // Time the sweep finished
+	ExpiredOffers      int64     `json:"expired_offers" extensions:"x-order=3" example:"12"`                  // Pending offers marked as expired
+	ExpiredLimitOrders int       `json:"expired_limit_orders" extensions:"x-order=4" example:"1"`             // Open limit orders expired and released
+	ArchivedOffers     int       `json:"archived_offers" extensions:"x-order=5" example:"250"`                // Closed offers moved to the offer history
+	Error              string    `json:"error,omitempty" extensions:"x-order=6" example:"connection refused"` // Reason the sweep stopped early
+}
+
+// LimitOrderStatus is the lifecycle state of a limit order, an order can leave open state only once
+type LimitOrderStatus string
+
+const (
+	LimitOrderStatusOpen      LimitOrderStatus = "OPEN"
+	LimitOrderStatusFilled    LimitOrderStatus = "FILLED"
+	LimitOrderStatusCancelled LimitOrderStatus = "CANCELLED"
+	LimitOrderStatusExpired   LimitOrderStatus = "EXPIRED"
+)
+
+// LimitOrder converts its amount once the offered rate of the pair reaches the limit rate.
+// The amount is held on the from account while the order is open.
+type LimitOrder struct {
+	Id               uint                `gorm:"primaryKey;autoIncrement"`
+	UserId           uint                `gorm:"not null;index"`
+	FromCurrencyCode string              `gorm:"not null"`
+	ToCurrencyCode   string              `gorm:"not null"`
+	Amount           decimal.Decimal     `gorm:"type:numeric;not null"` // In from currency
+	LimitRate        decimal.Decimal     `gorm:"type:numeric;not null"` // Lowest offered rate the order is filled at
+	Status           LimitOrderStatus    `gorm:"type:varchar(16);not null;index"`
+	ExpiresAt        time.Time           `gorm:"not null"`
+	HoldId           uint                // Hold on the from account
+	OfferId          *uint               // Offer the order was filled through
+	FilledRate       decimal.NullDecimal `gorm:"type:numeric"`
+	FilledAt         *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
 type OfferRequest struct {
-	FromCurrencyCode string `json:"from_currency_code" extensions:"x-order=1" example:"TRY" validate:"required" valid:"required~from_currency_code|invalid"` // From currency code
-	ToCurrencyCode   string `json:"to_currency_code" extensions:"x-order=2" example:"EUR" validate:"required" valid:"required~to_currency_code|invalid"`     // To currency code
+	FromCurrencyCode string          `json:"from_currency_code" extensions:"x-order=1" example:"TRY" validate:"required" valid:"required~from_currency_code|invalid"` // From currency code
+	ToCurrencyCode   string          `json:"to_currency_code" extensions:"x-order=2" example:"EUR" validate:"required" valid:"required~to_currency_code|invalid"`     // To currency code
+	SellAmount       decimal.Decimal `json:"sell_amount" extensions:"x-order=3" swaggertype:"string" example:"1000.00" valid:"optional"`                              // Amount to sell in from currency, locked into the offer
+	BuyAmount        decimal.Decimal `json:"buy_amount" extensions:"x-order=4" swaggertype:"string" example:"500.00" valid:"optional"`                                // Amount to buy in to currency, locked into the offer instead of sell amount
+}
+
+// AppliedMarkup is the markup an offer is quoted with
+type AppliedMarkup struct {
+	Type      MarkupType      `json:"type" extensions:"x-order=1" swaggertype:"string" example:"BASIS_POINTS"` // ABSOLUTE, BASIS_POINTS or PAIR_DEFAULT
+	Value     decimal.Decimal `json:"value" extensions:"x-order=2" swaggertype:"string" example:"50"`          // Value of the markup rule
+	Segment   string          `json:"segment,omitempty" extensions:"x-order=3" example:"VIP"`                  // User segment of the markup rule
+	MinAmount decimal.Decimal `json:"min_amount" extensions:"x-order=4" swaggertype:"string" example:"0"`      // Smallest amount of the markup tier
+	Rate      decimal.Decimal `json:"rate" extensions:"x-order=5" swaggertype:"string" example:"0.11"`         // Markup subtracted from the mid rate
 }
 
 type OfferResponse struct {
-	OfferId          uint    `json:"offer_id" extensions:"x-order=1" example:"4"`             // ID of the exchange rate offer
-	FromCurrencyCode string  `json:"from_currency_code" extensions:"x-order=2" example:"TRY"` // From currency code
-	ToCurrencyCode   string  `json:"to_currency_code" extensions:"x-order=3" example:"EUR"`   // To currency code
-	ExchangeRate     float64 `json:"exchange_rate" extensions:"x-order=4" example:"22.00"`    // Exchange rate with markup rate
+	OfferId          uint                `json:"offer_id" extensions:"x-order=1" example:"4"`                                // ID of the exchange rate offer
+	FromCurrencyCode string              `json:"from_currency_code" extensions:"x-order=2" example:"TRY"`                    // From currency code
+	ToCurrencyCode   string              `json:"to_currency_code" extensions:"x-order=3" example:"EUR"`                      // To currency code
+	ExchangeRate     decimal.Decimal     `json:"exchange_rate" extensions:"x-order=4" swaggertype:"string" example:"22.00"`  // Exchange rate with markup rate
+	Path             []string            `json:"path" extensions:"x-order=5" example:"TRY,USD,EUR"`                          // Currencies the rate is computed through
+	MidRate          decimal.Decimal     `json:"mid_rate" extensions:"x-order=6" swaggertype:"string" example:"22.11"`       // Exchange rate before markup
+	Markup           AppliedMarkup       `json:"markup" extensions:"x-order=7"`                                              // Markup subtracted from the mid rate
+	SellAmount       decimal.NullDecimal `json:"sell_amount" extensions:"x-order=8" swaggertype:"string" example:"10204.09"` // Amount debited in from currency, null when the offer is only a rate
+	BuyAmount        decimal.NullDecimal `json:"buy_amount" extensions:"x-order=9" swaggertype:"string" example:"500.00"`    // Amount credited in to currency, null when the offer is only a rate
+	FixedFee         decimal.Decimal     `json:"fixed_fee" extensions:"x-order=10" swaggertype:"string" example:"1.50"`      // Fee in to currency deducted on acceptance
+	Quote            *Quote              `json:"quote,omitempty" extensions:"x-order=11"`                                    // Breakdown of the credited amount, only on offers with an amount
+	ExpiresAt        time.Time           `json:"expires_at" extensions:"x-order=12" example:"2022-12-01T10:03:00Z"`          // Offer can not be accepted after this time
+	ExpiresIn        int64               `json:"expires_in" extensions:"x-order=13" example:"180"`                           // Seconds left to accept the offer
+}
+
+// Quote breaks down the amount credited by an amount bound offer, every figure is in to currency.
+// Gross amount less markup cost, fixed fee and rounding is the net amount.
+type Quote struct {
+	GrossAmount decimal.Decimal `json:"gross_amount" extensions:"x-order=1" swaggertype:"string" example:"513.28"` // Sell amount at mid rate
+	MarkupCost  decimal.Decimal `json:"markup_cost" extensions:"x-order=2" swaggertype:"string" example:"11.76"`   // Sell amount at markup rate
+	FixedFee    decimal.Decimal `json:"fixed_fee" extensions:"x-order=3" swaggertype:"string" example:"1.50"`      // Fee of the pair
+	Rounding    decimal.Decimal `json:"rounding" extensions:"x-order=4" swaggertype:"string" example:"0.02"`       // Left over by rounding to minor units
+	NetAmount   decimal.Decimal `json:"net_amount" extensions:"x-order=5" swaggertype:"string" example:"500.00"`   // Amount credited on acceptance
 }
 
 type AcceptOfferRequest struct {
-	OfferId uint    `json:"offer_id" extensions:"x-order=1" example:"4" validate:"required" valid:"required~offer_id|invalid"` // ID of the offer
-	Amount  float64 `json:"amount" extensions:"x-order=2" example:"100" validate:"required" valid:"required~amount|invalid"`   // ID of the offer
+	OfferId uint            `json:"offer_id" extensions:"x-order=1" example:"4" validate:"required" valid:"required~offer_id|invalid"` // ID of the offer
+	Amount  decimal.Decimal `json:"amount" extensions:"x-order=2" swaggertype:"string" example:"100.00" valid:"optional"`              // Amount to convert in from currency, may be left out when the offer has a locked amount
+}
+
+// OfferFilter narrows the user's offers, offers are returned newest first
+type OfferFilter struct {
+	UserId           uint
+	Status           OfferStatus // Pending offers past their expiry are filtered as expired
+	FromCurrencyCode string
+	ToCurrencyCode   string
+	Start            *time.Time
+	End              *time.Time
+	Now              int64 // Unix time the expiry of pending offers is compared with
+	BeforeId         uint  // cursor, only offers with a smaller id are returned
+	Limit            int
+}
+
+type OfferListRequest struct {
+	Status           string     `form:"status"`                                        // PENDING, ACCEPTED, EXPIRED or CANCELLED, every offer by default
+	FromCurrencyCode string     `form:"from" example:"USD"`                            // Only offers from given currency
+	ToCurrencyCode   string     `form:"to" example:"TRY"`                              // Only offers to given currency
+	Start            *time.Time `form:"start" time_format:"2006-01-02T15:04:05Z07:00"` // Inclusive start of the creation date range in RFC3339
+	End              *time.Time `form:"end" time_format:"2006-01-02T15:04:05Z07:00"`   // Exclusive end of the creation date range in RFC3339
+	Cursor           string     `form:"cursor"`                                        // Next cursor of the previous page
+	Limit            int        `form:"limit"`                                         // Page size, 20 by default and at most 100
+}
+
+// OfferDetailResponse is a stored offer of the user
+type OfferDetailResponse struct {
+	OfferId          uint                `json:"offer_id" extensions:"x-order=1" example:"4"`                                            // ID of the exchange rate offer
+	FromCurrencyCode string              `json:"from_currency_code" extensions:"x-order=2" example:"TRY"`                                // From currency code
+	ToCurrencyCode   string              `json:"to_currency_code" extensions:"x-order=3" example:"EUR"`                                  // To currency code
+	ExchangeRate     decimal.Decimal     `json:"exchange_rate" extensions:"x-order=4" swaggertype:"string" example:"22.00"`              // Exchange rate with markup rate
+	Status           OfferStatus         `json:"status" extensions:"x-order=5" swaggertype:"string" example:"PENDING"`                   // PENDING, ACCEPTED, EXPIRED or CANCELLED
+	SellAmount       decimal.NullDecimal `json:"sell_amount" extensions:"x-order=6" swaggertype:"string" example:"10204.09"`             // Locked amount in from currency, null when the offer is only a rate
+	BuyAmount        decimal.NullDecimal `json:"buy_amount" extensions:"x-order=7" swaggertype:"string" example:"500.00"`                // Locked amount in to currency, null when the offer is only a rate
+	FixedFee         decimal.NullDecimal `json:"fixed_fee" extensions:"x-order=8" swaggertype:"string" example:"1.50"`                   // Fee in to currency deducted on acceptance, null when the pair has no fee
+	AcceptedAmount   decimal.NullDecimal `json:"accepted_amount,omitempty" extensions:"x-order=9" swaggertype:"string" example:"100.00"` // Amount converted in from currency
+	AcceptedAt       *time.Time          `json:"accepted_at,omitempty" extensions:"x-order=10" example:"2022-12-01T10:01:00Z"`           // Time the offer was accepted
+	ExpiresAt        time.Time           `json:"expires_at" extensions:"x-order=11" example:"2022-12-01T10:03:00Z"`                      // Offer can not be accepted after this time
+	CreatedAt        time.Time           `json:"created_at" extensions:"x-order=12" example:"2022-12-01T10:00:00Z"`                      // Time the offer was quoted
+}
+
+type OfferListResponse struct {
+	Offers     []OfferDetailResponse `json:"offers" extensions:"x-order=1"`                            // Offers newest first
+	NextCursor string                `json:"next_cursor,omitempty" extensions:"x-order=2" example:"4"` // Cursor of the next page, empty on the last page
+}
+
+type RateHistoryRequest struct {
+	FromCurrencyCode string     `form:"from" example:"USD"`                            // From currency code
+	ToCurrencyCode   string     `form:"to" example:"TRY"`                              // To currency code
+	Start            *time.Time `form:"start" time_format:"2006-01-02T15:04:05Z07:00"` // Inclusive start of the range in RFC3339, a day before end by default
+	End              *time.Time `form:"end" time_format:"2006-01-02T15:04:05Z07:00"`   // Exclusive end of the range in RFC3339, now by default
+	Interval         string     `form:"interval" example:"1h"`                         // Bucket length, e.g. 15m, 1h or 24h, 1h by default
+}
+
+type RateBucket struct {
+	Start time.Time       `json:"start" extensions:"x-order=1" example:"2022-12-01T10:00:00Z"`       // Start of the bucket
+	Open  decimal.Decimal `json:"open" extensions:"x-order=2" swaggertype:"string" example:"18.63"`  // First rate of the bucket
+	High  decimal.Decimal `json:"high" extensions:"x-order=3" swaggertype:"string" example:"18.71"`  // Highest rate of the bucket
+	Low   decimal.Decimal `json:"low" extensions:"x-order=4" swaggertype:"string" example:"18.60"`   // Lowest rate of the bucket
+	Close decimal.Decimal `json:"close" extensions:"x-order=5" swaggertype:"string" example:"18.70"` // Last rate of the bucket
+	Count int             `json:"count" extensions:"x-order=6" example:"12"`                         // Number of rate changes in the bucket
+}
+
+type RateHistoryResponse struct {
+	FromCurrencyCode string       `json:"from_currency_code" extensions:"x-order=1" example:"USD"` // From currency code
+	ToCurrencyCode   string       `json:"to_currency_code" extensions:"x-order=2" example:"TRY"`   // To currency code
+	Interval         string       `json:"interval" extensions:"x-order=3" example:"1h0m0s"`        // Bucket length
+	Buckets          []RateBucket `json:"buckets" extensions:"x-order=4"`                          // Buckets with at least one rate, oldest first
+}
+
+type CreatePairRequest struct {
+	FromCurrencyCode string          `json:"from_currency_code" extensions:"x-order=1" example:"USD" validate:"required" valid:"required~from_currency_code|invalid"`             // From currency code
+	ToCurrencyCode   string          `json:"to_currency_code" extensions:"x-order=2" example:"GBP" validate:"required" valid:"required~to_currency_code|invalid"`                 // To currency code
+	ExchangeRate     decimal.Decimal `json:"exchange_rate" extensions:"x-order=3" swaggertype:"string" example:"0.82" validate:"required" valid:"required~exchange_rate|invalid"` // Mid rate of the pair
+	MarkupRate       decimal.Decimal `json:"markup_rate" extensions:"x-order=4" swaggertype:"string" example:"0.01" valid:"optional"`                                             // Markup subtracted from the mid rate
+	FixedFee         decimal.Decimal `json:"fixed_fee" extensions:"x-order=5" swaggertype:"string" example:"1.50" valid:"optional"`                                               // Fee in to currency deducted from every conversion
+	OfferTTLSeconds  int             `json:"offer_ttl_seconds" extensions:"x-order=6" example:"30" valid:"optional"`                                                              // Seconds offers of the pair can be accepted in, the configured offer TTL when zero
+}
+
+type UpdatePairRequest struct {
+	ExchangeRate    decimal.Decimal `json:"exchange_rate" extensions:"x-order=1" swaggertype:"string" example:"0.82" validate:"required" valid:"required~exchange_rate|invalid"` // Mid rate of the pair
+	MarkupRate      decimal.Decimal `json:"markup_rate" extensions:"x-order=2" swaggertype:"string" example:"0.01" valid:"optional"`                                             // Markup subtracted from the mid rate
+	FixedFee        decimal.Decimal `json:"fixed_fee" extensions:"x-order=3" swaggertype:"string" example:"1.50" valid:"optional"`                                               // Fee in to currency deducted from every conversion
+	OfferTTLSeconds int             `json:"offer_ttl_seconds" extensions:"x-order=4" example:"30" valid:"optional"`                                                              // Seconds offers of the pair can be accepted in, the configured offer TTL when zero
+}
+
+type PairResponse struct {
+	FromCurrencyCode string          `json:"from_currency_code" extensions:"x-order=1" example:"USD"`                  // From currency code
+	ToCurrencyCode   string          `json:"to_currency_code" extensions:"x-order=2" example:"GBP"`                    // To currency code
+	ExchangeRate     decimal.Decimal `json:"exchange_rate" extensions:"x-order=3" swaggertype:"string" example:"0.82"` // Mid rate of the pair
+	MarkupRate       decimal.Decimal `json:"markup_rate" extensions:"x-order=4" swaggertype:"string" example:"0.01"`   // Markup subtracted from the mid rate
+	FixedFee         decimal.Decimal `json:"fixed_fee" extensions:"x-order=5" swaggertype:"string" example:"1.50"`     // Fee in to currency deducted from every conversion
+	Disabled         bool            `json:"disabled" extensions:"x-order=6" example:"false"`                          // Whether the pair is disabled
+	OfferTTLSeconds  int             `json:"offer_ttl_seconds" extensions:"x-order=7" example:"30"`                    // Seconds offers of the pair can be accepted in, the configured offer TTL when zero
+	UpdatedAt        time.Time       `json:"updated_at" extensions:"x-order=8" example:"2022-12-01T10:00:00Z"`         // Time of the last change
+}
+
+type PairAuditResponse struct {
+	Id               uint            `json:"id" extensions:"x-order=1" example:"1"`                               // ID of the audit record
+	AdminUserId      uint            `json:"admin_user_id" extensions:"x-order=2" example:"1"`                    // ID of the admin who made the change
+	Action           PairAuditAction `json:"action" extensions:"x-order=3" swaggertype:"string" example:"UPDATE"` // CREATE, UPDATE, DISABLE, ENABLE or DELETE
+	FromCurrencyCode string          `json:"from_currency_code" extensions:"x-order=4" example:"USD"`             // From currency code
+	ToCurrencyCode   string          `json:"to_currency_code" extensions:"x-order=5" example:"GBP"`               // To currency code
+	Before           json.RawMessage `json:"before" extensions:"x-order=6" swaggertype:"object"`                  // Pair before the change
+	After            json.RawMessage `json:"after" extensions:"x-order=7" swaggertype:"object"`                   // Pair after the change
+	CreatedAt        time.Time       `json:"created_at" extensions:"x-order=8" example:"2022-12-01T10:00:00Z"`    // Time of the change
+}
+
+type MarkupRuleRequest struct {
+	Segment   string          `json:"segment" extensions:"x-order=1" example:"VIP" valid:"optional"`                                         // User segment, empty for every user
+	Type      MarkupType      `json:"type" extensions:"x-order=2" swaggertype:"string" example:"BASIS_POINTS" valid:"required~type|invalid"` // ABSOLUTE or BASIS_POINTS
+	Value     decimal.Decimal `json:"value" extensions:"x-order=3" swaggertype:"string" example:"50" valid:"optional"`                       // Markup in rate units or basis points
+	MinAmount decimal.Decimal `json:"min_amount" extensions:"x-order=4" swaggertype:"string" example:"10000" valid:"optional"`               // Smallest amount in from currency the tier applies to
+}
+
+type MarkupRulesRequest struct {
+	Rules []MarkupRuleRequest `json:"rules" extensions:"x-order=1" valid:"optional"` // Markup rules replacing the current rules of the pair, empty to use the pair markup rate
+}
+
+type MarkupRuleResponse struct {
+	Segment   string          `json:"segment" extensions:"x-order=1" example:"VIP"`                            // User segment, empty for every user
+	Type      MarkupType      `json:"type" extensions:"x-order=2" swaggertype:"string" example:"BASIS_POINTS"` // ABSOLUTE or BASIS_POINTS
+	Value     decimal.Decimal `json:"value" extensions:"x-order=3" swaggertype:"string" example:"50"`          // Markup in rate units or basis points
+	MinAmount decimal.Decimal `json:"min_amount" extensions:"x-order=4" swaggertype:"string" example:"10000"`  // Smallest amount in from currency the tier applies to
+}
+
+type LimitOrderRequest struct {
+	FromCurrencyCode string          `json:"from_currency_code" extensions:"x-order=1" example:"USD" validate:"required" valid:"required~from_currency_code|invalid"`        // From currency code
+	ToCurrencyCode   string          `json:"to_currency_code" extensions:"x-order=2" example:"TRY" validate:"required" valid:"required~to_currency_code|invalid"`            // To currency code
+	Amount           decimal.Decimal `json:"amount" extensions:"x-order=3" swaggertype:"string" example:"100.00" validate:"required" valid:"required~amount|invalid"`        // Amount to convert in from currency, reserved until the order is closed
+	LimitRate        decimal.Decimal `json:"limit_rate" extensions:"x-order=4" swaggertype:"string" example:"19.10" validate:"required" valid:"required~limit_rate|invalid"` // Lowest offered rate the order is filled at
+	ExpiresAt        *time.Time      `json:"expires_at" extensions:"x-order=5" example:"2022-12-08T10:00:00Z" valid:"optional"`                                              // Order expires at this time, a week later by default
+}
+
+type LimitOrderListRequest struct {
+	Status string `form:"status"` // OPEN, FILLED, CANCELLED or EXPIRED, every order by default
+}
+
+type LimitOrderResponse struct {
+	Id               uint                `json:"id" extensions:"x-order=1" example:"2"`                                             // ID of the limit order
+	FromCurrencyCode string              `json:"from_currency_code" extensions:"x-order=2" example:"USD"`                           // From currency code
+	ToCurrencyCode   string              `json:"to_currency_code" extensions:"x-order=3" example:"TRY"`                             // To currency code
+	Amount           decimal.Decimal     `json:"amount" extensions:"x-order=4" swaggertype:"string" example:"100.00"`               // Amount to convert in from currency
+	LimitRate        decimal.Decimal     `json:"limit_rate" extensions:"x-order=5" swaggertype:"string" example:"19.10"`            // Lowest offered rate the order is filled at
+	Status           LimitOrderStatus    `json:"status" extensions:"x-order=6" swaggertype:"string" example:"OPEN"`                 // OPEN, FILLED, CANCELLED or EXPIRED
+	ExpiresAt        time.Time           `json:"expires_at" extensions:"x-order=7" example:"2022-12-08T10:00:00Z"`                  // Order expires at this time
+	OfferId          *uint               `json:"offer_id,omitempty" extensions:"x-order=8" example:"4"`                             // Offer the order was filled through
+	FilledRate       decimal.NullDecimal `json:"filled_rate,omitempty" extensions:"x-order=9" swaggertype:"string" example:"19.12"` // Offered rate the order was filled at
+	FilledAt         *time.Time          `json:"filled_at,omitempty" extensions:"x-order=10" example:"2022-12-02T10:00:00Z"`        // Time the order was filled
+	CreatedAt        time.Time           `json:"created_at" extensions:"x-order=11" example:"2022-12-01T10:00:00Z"`                 // Time the order was placed
+}
+
+type RateStreamRequest struct {
+	Pairs string `form:"pairs" example:"USD/TRY,EUR/TRY"` // Comma separated pairs to watch, every pair by default
+	Token string `form:"token"`                           // Auth token for clients which can not set the X-Auth-Token header
+}
+
+// RateUpdate is pushed on a stream when the rate of a pair changes, and for every watched pair on connect
+type RateUpdate struct {
+	FromCurrencyCode string          `json:"from_currency_code" extensions:"x-order=1" example:"USD"`                   // From currency code
+	ToCurrencyCode   string          `json:"to_currency_code" extensions:"x-order=2" example:"TRY"`                     // To currency code
+	ExchangeRate     decimal.Decimal `json:"exchange_rate" extensions:"x-order=3" swaggertype:"string" example:"18.52"` // Rate the pair is quoted at, markup rules may quote some amounts differently
+	Disabled         bool            `json:"disabled" extensions:"x-order=4" example:"false"`                           // Disabled pairs are not quoted
+	UpdatedAt        time.Time       `json:"updated_at" extensions:"x-order=5" example:"2022-12-01T10:00:00Z"`          // Time the pair was last changed
+}
+
+// RateStreamMessage is a message of the WebSocket stream, events are the same as on the event stream
+type RateStreamMessage struct {
+	Event string      `json:"event" extensions:"x-order=1" example:"rate"` // rate or heartbeat
+	Data  *RateUpdate `json:"data,omitempty" extensions:"x-order=2"`       // Updated rate, empty on heartbeat
 }
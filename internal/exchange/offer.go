@@ -0,0 +1,179 @@
+package exchange
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// External imports
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+)
+
+const (
+	defaultOfferPageSize = 20
+	maxOfferPageSize     = 100
+)
+
+var (
+	ErrInvalidOfferFilter = errors.New("invalid offer filter")
+	ErrOfferNotFound      = errors.New("offer not found")
+)
+
+// ListOffers pages through the user's offers newest first
+func (s *exchangeService) ListOffers(userId uint, request OfferListRequest) (*OfferListResponse, error) {
+	filter := OfferFilter{
+		UserId:           userId,
+		Status:           OfferStatus(strings.ToUpper(request.Status)),
+		FromCurrencyCode: strings.ToUpper(request.FromCurrencyCode),
+		ToCurrencyCode:   strings.ToUpper(request.ToCurrencyCode),
+		Start:            request.Start,
+		End:              request.End,
+		Now:              s.clock.Now().Unix(),
+		Limit:            request.Limit,
+	}
+
+	switch filter.Status {
+	case "", OfferStatusPending, OfferStatusAccepted, OfferStatusExpired, OfferStatusCancelled:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %s", ErrInvalidOfferFilter, request.Status)
+	}
+
+	if filter.Start != nil && filter.End != nil && !filter.Start.Before(*filter.End) {
+		return nil, fmt.Errorf("%w: start must be before end", ErrInvalidOfferFilter)
+	}
+
+	if request.Cursor != "" {
+		cursor, err := strconv.ParseUint(request.Cursor, 10, 64)
+		if err != nil || cursor == 0 {
+			return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidOfferFilter)
+		}
+		filter.BeforeId = uint(cursor)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOfferPageSize
+	}
+
+	if filter.Limit > maxOfferPageSize {
+		filter.Limit = maxOfferPageSize
+	}
+
+	// Fetch one more offer to know whether there is a next page
+	pageSize := filter.Limit
+	filter.Limit++
+	offers, err := s.exchangeRepo.ListUserOffers(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OfferListResponse{Offers: []OfferDetailResponse{}}
+	if len(offers) > pageSize {
+		offers = offers[:pageSize]
+		response.NextCursor = strconv.FormatUint(uint64(offers[pageSize-1].Id), 10)
+	}
+
+	for _, offer := range offers {
+		response.Offers = append(response.Offers, toOfferDetailResponse(offer, filter.Now))
+	}
+
+	return response, nil
+}
+
+func (s *exchangeService) GetUserOffer(userId, offerId uint) (*OfferDetailResponse, error) {
+	offer, err := s.exchangeRepo.GetOffer(offerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && offer.UserId != userId) {
+		return nil, fmt.Errorf("%w: %d", ErrOfferNotFound, offerId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := toOfferDetailResponse(*offer, s.clock.Now().Unix())
+	return &response, nil
+}
+
+// CancelOffer withdraws a pending offer of the user so it can not be accepted anymore
+func (s *exchangeService) CancelOffer(userId, offerId uint) (*OfferDetailResponse, error) {
+	var cancelled *Offer
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txExchangeRepo := s.exchangeRepo.WithTx(tx)
+		offer, err := txExchangeRepo.GetOfferForUpdate(offerId)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && offer.UserId != userId) {
+			return fmt.Errorf("%w: %d", ErrOfferNotFound, offerId)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = checkOfferIsAcceptable(*offer, s.clock.Now()); err != nil {
+			return err
+		}
+
+		offer.Status = OfferStatusCancelled
+		offer.UpdatedAt = s.clock.Now()
+		cancelled = offer
+		return txExchangeRepo.UpdateOffer(*offer)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toOfferDetailResponse(*cancelled, s.clock.Now().Unix())
+	return &response, nil
+}
+
+// GetUserConversionOffers returns the offers of the user with the given ids, including archived ones, so a statement
+// can show the rate each conversion was made at
+func (s *exchangeService) GetUserConversionOffers(userId uint, offerIds []uint) ([]account.ConversionOffer, error) {
+	if len(offerIds) == 0 {
+		return nil, nil
+	}
+
+	offers, err := s.exchangeRepo.ListUserOffersByIds(userId, offerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	conversionOffers := make([]account.ConversionOffer, 0, len(offers))
+	for _, offer := range offers {
+		conversionOffers = append(conversionOffers, account.ConversionOffer{
+			OfferId:          offer.Id,
+			FromCurrencyCode: offer.FromCurrencyCode,
+			ToCurrencyCode:   offer.ToCurrencyCode,
+			ExchangeRate:     offer.ExchangeRate,
+		})
+	}
+	return conversionOffers, nil
+}
+
+// effectiveOfferStatus reports a pending offer past its expiry as expired, expiry is not written to the offer
+func effectiveOfferStatus(offer Offer, now int64) OfferStatus {
+	if offer.Status == OfferStatusPending && offer.ExpiresAt < now {
+		return OfferStatusExpired
+	}
+	return offer.Status
+}
+
+func toOfferDetailResponse(offer Offer, now int64) OfferDetailResponse {
+	return OfferDetailResponse{
+		OfferId:          offer.Id,
+		FromCurrencyCode: offer.FromCurrencyCode,
+		ToCurrencyCode:   offer.ToCurrencyCode,
+		ExchangeRate:     offer.ExchangeRate,
+		Status:           effectiveOfferStatus(offer, now),
+		SellAmount:       offer.SellAmount,
+		BuyAmount:        offer.BuyAmount,
+		FixedFee:         offer.FixedFee,
+		AcceptedAmount:   offer.AcceptedAmount,
+		AcceptedAt:       offer.AcceptedAt,
+		ExpiresAt:        time.Unix(offer.ExpiresAt, 0).UTC(),
+		CreatedAt:        offer.CreatedAt,
+	}
+}
@@ -0,0 +1,130 @@
+package exchange
+
+import (
+	// Go imports
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+func TestExchangeService_Offers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	exchService := NewExchangeService(mockExchangeRepository, currency.Service{}, account.NewMockIAccountService(ctrl), NewMockIUserSegmentResolver(ctrl), mockUnitOfWork, config.Config{}, clock.NewClock())
+	userId := uint(1)
+
+	mockExchangeRepository.EXPECT().WithTx(gomock.Any()).Return(mockExchangeRepository).AnyTimes()
+	expectTransaction := func() {
+		mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+			return fn(nil)
+		})
+	}
+	pendingOffer := func(id uint) Offer {
+		return Offer{
+			Id:               id,
+			UserId:           userId,
+			FromCurrencyCode: "USD",
+			ToCurrencyCode:   "TRY",
+			ExchangeRate:     decimal.NewFromInt(19),
+			ExpiresAt:        time.Now().Add(time.Minute).Unix(),
+			Status:           OfferStatusPending,
+		}
+	}
+
+	t.Run("list with unknown status", func(t *testing.T) {
+		_, err := exchService.ListOffers(userId, OfferListRequest{Status: "USED"})
+		assert.ErrorIs(t, err, ErrInvalidOfferFilter)
+	})
+
+	t.Run("list pages and reports expired offers", func(t *testing.T) {
+		expired := pendingOffer(7)
+		expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+		mockExchangeRepository.EXPECT().ListUserOffers(gomock.Any()).DoAndReturn(func(filter OfferFilter) ([]Offer, error) {
+			assert.Equal(t, userId, filter.UserId)
+			assert.Equal(t, "USD", filter.FromCurrencyCode)
+			assert.Equal(t, uint(9), filter.BeforeId)
+			assert.Equal(t, 3, filter.Limit)
+			return []Offer{pendingOffer(8), expired, pendingOffer(6)}, nil
+		})
+
+		response, err := exchService.ListOffers(userId, OfferListRequest{FromCurrencyCode: "usd", Cursor: "9", Limit: 2})
+		assert.Nil(t, err)
+		assert.Len(t, response.Offers, 2)
+		assert.Equal(t, OfferStatusPending, response.Offers[0].Status)
+		assert.Equal(t, OfferStatusExpired, response.Offers[1].Status)
+		assert.Equal(t, "7", response.NextCursor)
+	})
+
+	t.Run("get offer of another user", func(t *testing.T) {
+		offer := pendingOffer(4)
+		offer.UserId = 2
+		mockExchangeRepository.EXPECT().GetOffer(uint(4)).Return(&offer, nil)
+
+		_, err := exchService.GetUserOffer(userId, 4)
+		assert.ErrorIs(t, err, ErrOfferNotFound)
+	})
+
+	t.Run("get offer with expiry in UTC", func(t *testing.T) {
+		offer := pendingOffer(4)
+		mockExchangeRepository.EXPECT().GetOffer(uint(4)).Return(&offer, nil)
+
+		response, err := exchService.GetUserOffer(userId, 4)
+		assert.Nil(t, err)
+		assert.Equal(t, time.UTC, response.ExpiresAt.Location())
+		assert.Equal(t, offer.ExpiresAt, response.ExpiresAt.Unix())
+	})
+
+	t.Run("cancel accepted offer", func(t *testing.T) {
+		expectTransaction()
+		offer := pendingOffer(4)
+		offer.Status = OfferStatusAccepted
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(uint(4)).Return(&offer, nil)
+
+		_, err := exchService.CancelOffer(userId, 4)
+		assert.ErrorIs(t, err, ErrOfferAlreadyUsed)
+	})
+
+	t.Run("cancel pending offer", func(t *testing.T) {
+		expectTransaction()
+		offer := pendingOffer(4)
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(uint(4)).Return(&offer, nil)
+		mockExchangeRepository.EXPECT().UpdateOffer(gomock.Any()).DoAndReturn(func(offer Offer) error {
+			assert.Equal(t, OfferStatusCancelled, offer.Status)
+			return nil
+		})
+
+		response, err := exchService.CancelOffer(userId, 4)
+		assert.Nil(t, err)
+		assert.Equal(t, OfferStatusCancelled, response.Status)
+	})
+
+	t.Run("conversion offers of a statement", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ListUserOffersByIds(userId, []uint{4, 7}).Return([]Offer{pendingOffer(4), pendingOffer(7)}, nil)
+
+		offers, err := exchService.GetUserConversionOffers(userId, []uint{4, 7})
+		assert.Nil(t, err)
+		assert.Len(t, offers, 2)
+		assert.Equal(t, uint(7), offers[1].OfferId)
+		assert.Equal(t, "TRY", offers[1].ToCurrencyCode)
+		assert.True(t, offers[1].ExchangeRate.Equal(decimal.NewFromInt(19)))
+	})
+
+	t.Run("no conversion offers", func(t *testing.T) {
+		offers, err := exchService.GetUserConversionOffers(userId, nil)
+		assert.Nil(t, err)
+		assert.Empty(t, offers)
+	})
+}
@@ -0,0 +1,343 @@
+package exchange
+
+import (
+	// Go imports
+	"net/http"
+
+	// External imports
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/common"
+)
+
+type PairHandler interface {
+	ListPairs(c *gin.Context)
+	CreatePair(c *gin.Context)
+	UpdatePair(c *gin.Context)
+	DisablePair(c *gin.Context)
+	EnablePair(c *gin.Context)
+	DeletePair(c *gin.Context)
+	ListPairAudits(c *gin.Context)
+	ListMarkupRules(c *gin.Context)
+	ReplaceMarkupRules(c *gin.Context)
+	PairRoutes(router *gin.RouterGroup)
+}
+
+type pairHandler struct {
+	pairService IPairService
+}
+
+func NewPairHandler(pairService IPairService) PairHandler {
+	return &pairHandler{pairService: pairService}
+}
+
+func (h *pairHandler) PairRoutes(router *gin.RouterGroup) {
+	router.GET("/pairs", h.ListPairs)
+	router.POST("/pairs", h.CreatePair)
+	router.PUT("/pairs/:from/:to", h.UpdatePair)
+	router.POST("/pairs/:from/:to/disable", h.DisablePair)
+	router.POST("/pairs/:from/:to/enable", h.EnablePair)
+	router.DELETE("/pairs/:from/:to", h.DeletePair)
+	router.GET("/pairs/:from/:to/markups", h.ListMarkupRules)
+	router.PUT("/pairs/:from/:to/markups", h.ReplaceMarkupRules)
+	router.GET("/audits", h.ListPairAudits)
+}
+
+// ListPairs godoc
+// @Summary List Exchange Pairs
+// @Description List every exchange pair including the disabled ones, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Success 200 {object} helper.Response{data=[]PairResponse} "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs [get]
+func (h *pairHandler) ListPairs(c *gin.Context) {
+	pairs, err := h.pairService.ListPairs()
+	if err != nil {
+		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangePairError.Error(), err.Error())
+		return
+	}
+
+	helper.Success(c, pairs)
+}
+
+// CreatePair godoc
+// @Summary Create Exchange Pair
+// @Description Create an exchange pair on currencies known by the currency service, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param request body CreatePairRequest true "body params"
+// @Success 200 {object} helper.Response{data=PairResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Pair Already Exists"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs [post]
+func (h *pairHandler) CreatePair(c *gin.Context) {
+	var req CreatePairRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return
+	}
+
+	adminUserId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	pair, err := h.pairService.CreatePair(adminUserId, req)
+	if err != nil {
+		pairError(c, err)
+		return
+	}
+
+	helper.Success(c, pair)
+}
+
+// UpdatePair godoc
+// @Summary Update Exchange Pair
+// @Description Update the exchange and markup rates of an exchange pair, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param from path string true "From currency code"
+// @Param to path string true "To currency code"
+// @Param request body UpdatePairRequest true "body params"
+// @Success 200 {object} helper.Response{data=PairResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs/{from}/{to} [put]
+func (h *pairHandler) UpdatePair(c *gin.Context) {
+	var req UpdatePairRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return
+	}
+
+	adminUserId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	pair, err := h.pairService.UpdatePair(adminUserId, c.Param("from"), c.Param("to"), req)
+	if err != nil {
+		pairError(c, err)
+		return
+	}
+
+	helper.Success(c, pair)
+}
+
+// DisablePair godoc
+// @Summary Disable Exchange Pair
+// @Description Stop quoting an exchange pair and using it for cross rates, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param from path string true "From currency code"
+// @Param to path string true "To currency code"
+// @Success 200 {object} helper.Response{data=PairResponse} "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs/{from}/{to}/disable [post]
+func (h *pairHandler) DisablePair(c *gin.Context) {
+	h.setPairDisabled(c, true)
+}
+
+// EnablePair godoc
+// @Summary Enable Exchange Pair
+// @Description Quote a disabled exchange pair again, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param from path string true "From currency code"
+// @Param to path string true "To currency code"
+// @Success 200 {object} helper.Response{data=PairResponse} "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs/{from}/{to}/enable [post]
+func (h *pairHandler) EnablePair(c *gin.Context) {
+	h.setPairDisabled(c, false)
+}
+
+func (h *pairHandler) setPairDisabled(c *gin.Context, disabled bool) {
+	adminUserId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	pair, err := h.pairService.SetPairDisabled(adminUserId, c.Param("from"), c.Param("to"), disabled)
+	if err != nil {
+		pairError(c, err)
+		return
+	}
+
+	helper.Success(c, pair)
+}
+
+// DeletePair godoc
+// @Summary Delete Exchange Pair
+// @Description Delete an exchange pair, its audits and rate history are kept, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param from path string true "From currency code"
+// @Param to path string true "To currency code"
+// @Success 200 {object} helper.Response "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs/{from}/{to} [delete]
+func (h *pairHandler) DeletePair(c *gin.Context) {
+	adminUserId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	if err := h.pairService.DeletePair(adminUserId, c.Param("from"), c.Param("to")); err != nil {
+		pairError(c, err)
+		return
+	}
+
+	helper.Success(c, nil)
+}
+
+// ListPairAudits godoc
+// @Summary List Exchange Pair Audits
+// @Description List who changed the exchange pairs and how, newest first, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param from query string false "From currency code"
+// @Param to query string false "To currency code"
+// @Success 200 {object} helper.Response{data=[]PairAuditResponse} "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/audits [get]
+func (h *pairHandler) ListPairAudits(c *gin.Context) {
+	audits, err := h.pairService.ListPairAudits(c.Query("from"), c.Query("to"))
+	if err != nil {
+		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangePairError.Error(), err.Error())
+		return
+	}
+
+	helper.Success(c, audits)
+}
+
+// ListMarkupRules godoc
+// @Summary List Markup Rules
+// @Description List the markup rules of a pair by segment and tier, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param from path string true "From currency code"
+// @Param to path string true "To currency code"
+// @Success 200 {object} helper.Response{data=[]MarkupRuleResponse} "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs/{from}/{to}/markups [get]
+func (h *pairHandler) ListMarkupRules(c *gin.Context) {
+	rules, err := h.pairService.ListMarkupRules(c.Param("from"), c.Param("to"))
+	if err != nil {
+		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangePairError.Error(), err.Error())
+		return
+	}
+
+	helper.Success(c, rules)
+}
+
+// ReplaceMarkupRules godoc
+// @Summary Replace Markup Rules
+// @Description Replace the markup rules of a pair, pairs without rules are marked up by their markup rate, admin only
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of admin user."
+// @Param from path string true "From currency code"
+// @Param to path string true "To currency code"
+// @Param request body MarkupRulesRequest true "body params"
+// @Success 200 {object} helper.Response{data=[]MarkupRuleResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/exchange/pairs/{from}/{to}/markups [put]
+func (h *pairHandler) ReplaceMarkupRules(c *gin.Context) {
+	var req MarkupRulesRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return
+	}
+
+	adminUserId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	rules, err := h.pairService.ReplaceMarkupRules(adminUserId, c.Param("from"), c.Param("to"), req)
+	if err != nil {
+		pairError(c, err)
+		return
+	}
+
+	helper.Success(c, rules)
+}
+
+func pairError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidPair):
+		helper.Error(c, http.StatusBadRequest, errors.ErrExchangePairError.Error(), err.Error())
+	case errors.Is(err, ErrPairNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrPairAlreadyExists):
+		helper.Error(c, http.StatusConflict, errors.ErrExchangePairExistsError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrExchangePairError.Error(), err.Error())
+	}
+}
@@ -0,0 +1,124 @@
+package exchange
+
+import (
+	// Go imports
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/middleware"
+)
+
+func TestPairHandler_CreatePair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPairService := NewMockIPairService(ctrl)
+	httpHandler := NewPairHandler(mockPairService)
+	gin.SetMode(gin.TestMode)
+	adminUserId := uint(9)
+	router := gin.Default()
+	group := router.Group("/admin/exchange")
+	group.Use(func(c *gin.Context) {
+		c.Set("user_id", adminUserId)
+		c.Set("is_admin", c.GetHeader("X-Admin") == "true")
+	}, middleware.AdminMiddleware())
+	httpHandler.PairRoutes(group)
+
+	body := []byte(`{"from_currency_code": "USD", "to_currency_code": "GBP", "exchange_rate": "0.82", "markup_rate": "0.01"}`)
+
+	t.Run("not an admin", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/admin/exchange/pairs", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("pair already exists", func(t *testing.T) {
+		mockPairService.EXPECT().CreatePair(adminUserId, gomock.Any()).Return(nil, ErrPairAlreadyExists)
+		req, _ := http.NewRequest(http.MethodPost, "/admin/exchange/pairs", bytes.NewReader(body))
+		req.Header.Set("X-Admin", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("successfully create pair", func(t *testing.T) {
+		mockPairService.EXPECT().CreatePair(adminUserId, CreatePairRequest{
+			FromCurrencyCode: "USD",
+			ToCurrencyCode:   "GBP",
+			ExchangeRate:     decimal.RequireFromString("0.82"),
+			MarkupRate:       decimal.RequireFromString("0.01"),
+		}).Return(&PairResponse{FromCurrencyCode: "USD", ToCurrencyCode: "GBP"}, nil)
+		req, _ := http.NewRequest(http.MethodPost, "/admin/exchange/pairs", bytes.NewReader(body))
+		req.Header.Set("X-Admin", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("disable unknown pair", func(t *testing.T) {
+		mockPairService.EXPECT().SetPairDisabled(adminUserId, "USD", "JPY", true).Return(nil, ErrPairNotFound)
+		req, _ := http.NewRequest(http.MethodPost, "/admin/exchange/pairs/USD/JPY/disable", nil)
+		req.Header.Set("X-Admin", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid update", func(t *testing.T) {
+		mockPairService.EXPECT().UpdatePair(adminUserId, "USD", "TRY", gomock.Any()).Return(nil, ErrInvalidPair)
+		req, _ := http.NewRequest(http.MethodPut, "/admin/exchange/pairs/USD/TRY", bytes.NewReader([]byte(`{"exchange_rate": "1", "markup_rate": "2"}`)))
+		req.Header.Set("X-Admin", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPairHandler_ReplaceMarkupRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPairService := NewMockIPairService(ctrl)
+	httpHandler := NewPairHandler(mockPairService)
+	gin.SetMode(gin.TestMode)
+	adminUserId := uint(9)
+	router := gin.Default()
+	group := router.Group("/admin/exchange")
+	group.Use(func(c *gin.Context) {
+		c.Set("user_id", adminUserId)
+		c.Set("is_admin", true)
+	}, middleware.AdminMiddleware())
+	httpHandler.PairRoutes(group)
+
+	t.Run("invalid rules", func(t *testing.T) {
+		mockPairService.EXPECT().ReplaceMarkupRules(adminUserId, "USD", "TRY", gomock.Any()).Return(nil, ErrInvalidPair)
+		req, _ := http.NewRequest(http.MethodPut, "/admin/exchange/pairs/USD/TRY/markups", bytes.NewReader([]byte(`{"rules": [{"type": "PERCENT", "value": "1"}]}`)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("successfully replace rules", func(t *testing.T) {
+		mockPairService.EXPECT().ReplaceMarkupRules(adminUserId, "USD", "TRY", MarkupRulesRequest{Rules: []MarkupRuleRequest{
+			{Segment: "VIP", Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(25), MinAmount: decimal.NewFromInt(1000)},
+		}}).Return([]MarkupRuleResponse{{Segment: "VIP", Type: MarkupTypeBasisPoints}}, nil)
+		req, _ := http.NewRequest(http.MethodPut, "/admin/exchange/pairs/USD/TRY/markups", bytes.NewReader([]byte(`{"rules": [{"segment": "VIP", "type": "BASIS_POINTS", "value": "25", "min_amount": "1000"}]}`)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("list rules", func(t *testing.T) {
+		mockPairService.EXPECT().ListMarkupRules("USD", "TRY").Return([]MarkupRuleResponse{}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/admin/exchange/pairs/USD/TRY/markups", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,422 @@
+package exchange
+
+import (
+	// Go imports
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+// maxOfferTTL bounds the offer TTL of a pair, longer quotes would let users wait for the market to move against the rate
+const maxOfferTTL = time.Hour
+
+var (
+	ErrInvalidPair       = errors.New("invalid exchange pair")
+	ErrPairNotFound      = errors.New("exchange pair not found")
+	ErrPairAlreadyExists = errors.New("exchange pair already exists")
+)
+
+// IPairService manages the pairs on the exchanges table for admins, every change is audited
+type IPairService interface {
+	ListPairs() ([]PairResponse, error)
+	CreatePair(adminUserId uint, request CreatePairRequest) (*PairResponse, error)
+	UpdatePair(adminUserId uint, fromCurrencyCode, toCurrencyCode string, request UpdatePairRequest) (*PairResponse, error)
+	SetPairDisabled(adminUserId uint, fromCurrencyCode, toCurrencyCode string, disabled bool) (*PairResponse, error)
+	DeletePair(adminUserId uint, fromCurrencyCode, toCurrencyCode string) error
+	ListPairAudits(fromCurrencyCode, toCurrencyCode string) ([]PairAuditResponse, error)
+	ListMarkupRules(fromCurrencyCode, toCurrencyCode string) ([]MarkupRuleResponse, error)
+	ReplaceMarkupRules(adminUserId uint, fromCurrencyCode, toCurrencyCode string, request MarkupRulesRequest) ([]MarkupRuleResponse, error)
+}
+
+type pairService struct {
+	exchangeRepo    IExchangeRepository
+	currencyService currency.Service
+	unitOfWork      uow.IUnitOfWork
+}
+
+func NewPairService(exchangeRepository IExchangeRepository, currencyService currency.Service, unitOfWork uow.IUnitOfWork) IPairService {
+	return &pairService{exchangeRepo: exchangeRepository, currencyService: currencyService, unitOfWork: unitOfWork}
+}
+
+func (s *pairService) ListPairs() ([]PairResponse, error) {
+	exchanges, err := s.exchangeRepo.ListExchanges()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := []PairResponse{}
+	for _, exchange := range exchanges {
+		pairs = append(pairs, toPairResponse(exchange))
+	}
+
+	return pairs, nil
+}
+
+func (s *pairService) CreatePair(adminUserId uint, request CreatePairRequest) (*PairResponse, error) {
+	fromCurrencyCode := strings.ToUpper(request.FromCurrencyCode)
+	toCurrencyCode := strings.ToUpper(request.ToCurrencyCode)
+	if fromCurrencyCode == toCurrencyCode {
+		return nil, fmt.Errorf("%w: currencies must differ", ErrInvalidPair)
+	}
+
+	for _, currencyCode := range []string{fromCurrencyCode, toCurrencyCode} {
+		if ok := s.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+			return nil, fmt.Errorf("%w: currency %s not found", ErrInvalidPair, currencyCode)
+		}
+	}
+
+	if err := validateRates(request.ExchangeRate, request.MarkupRate, request.FixedFee); err != nil {
+		return nil, err
+	}
+
+	if err := validateOfferTTL(request.OfferTTLSeconds); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	exchange := Exchange{
+		FromCurrencyCode: fromCurrencyCode,
+		ToCurrencyCode:   toCurrencyCode,
+		ExchangeRate:     currency.RoundRate(request.ExchangeRate),
+		MarkupRate:       currency.RoundRate(request.MarkupRate),
+		FixedFee:         currency.Round(request.FixedFee, toCurrencyCode),
+		OfferTTLSeconds:  request.OfferTTLSeconds,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txExchangeRepo := s.exchangeRepo.WithTx(tx)
+		if _, err := txExchangeRepo.GetExchangeForUpdate(fromCurrencyCode, toCurrencyCode); err == nil {
+			return ErrPairAlreadyExists
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := txExchangeRepo.CreateExchange(exchange); err != nil {
+			return err
+		}
+
+		if err := txExchangeRepo.CreateRateHistory(newRateHistory(exchange)); err != nil {
+			return err
+		}
+
+		return s.audit(txExchangeRepo, adminUserId, PairAuditActionCreate, nil, &exchange)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toPairResponse(exchange)
+	return &response, nil
+}
+
+func (s *pairService) UpdatePair(adminUserId uint, fromCurrencyCode, toCurrencyCode string, request UpdatePairRequest) (*PairResponse, error) {
+	if err := validateRates(request.ExchangeRate, request.MarkupRate, request.FixedFee); err != nil {
+		return nil, err
+	}
+
+	if err := validateOfferTTL(request.OfferTTLSeconds); err != nil {
+		return nil, err
+	}
+
+	return s.changePair(adminUserId, fromCurrencyCode, toCurrencyCode, PairAuditActionUpdate, func(exchange *Exchange) {
+		exchange.ExchangeRate = currency.RoundRate(request.ExchangeRate)
+		exchange.MarkupRate = currency.RoundRate(request.MarkupRate)
+		exchange.FixedFee = currency.Round(request.FixedFee, exchange.ToCurrencyCode)
+		exchange.OfferTTLSeconds = request.OfferTTLSeconds
+	})
+}
+
+func (s *pairService) SetPairDisabled(adminUserId uint, fromCurrencyCode, toCurrencyCode string, disabled bool) (*PairResponse, error) {
+	action := PairAuditActionEnable
+	if disabled {
+		action = PairAuditActionDisable
+	}
+
+	return s.changePair(adminUserId, fromCurrencyCode, toCurrencyCode, action, func(exchange *Exchange) {
+		exchange.Disabled = disabled
+	})
+}
+
+func (s *pairService) DeletePair(adminUserId uint, fromCurrencyCode, toCurrencyCode string) error {
+	fromCurrencyCode = strings.ToUpper(fromCurrencyCode)
+	toCurrencyCode = strings.ToUpper(toCurrencyCode)
+
+	return s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txExchangeRepo := s.exchangeRepo.WithTx(tx)
+		exchange, err := s.lockPair(txExchangeRepo, fromCurrencyCode, toCurrencyCode)
+		if err != nil {
+			return err
+		}
+
+		if err = txExchangeRepo.DeleteExchange(fromCurrencyCode, toCurrencyCode); err != nil {
+			return err
+		}
+
+		return s.audit(txExchangeRepo, adminUserId, PairAuditActionDelete, exchange, nil)
+	})
+}
+
+func (s *pairService) ListPairAudits(fromCurrencyCode, toCurrencyCode string) ([]PairAuditResponse, error) {
+	audits, err := s.exchangeRepo.ListExchangeAudits(strings.ToUpper(fromCurrencyCode), strings.ToUpper(toCurrencyCode))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []PairAuditResponse{}
+	for _, audit := range audits {
+		response := PairAuditResponse{
+			Id:               audit.Id,
+			AdminUserId:      audit.AdminUserId,
+			Action:           audit.Action,
+			FromCurrencyCode: audit.FromCurrencyCode,
+			ToCurrencyCode:   audit.ToCurrencyCode,
+			CreatedAt:        audit.CreatedAt,
+		}
+
+		if audit.Before != "" {
+			response.Before = json.RawMessage(audit.Before)
+		}
+
+		if audit.After != "" {
+			response.After = json.RawMessage(audit.After)
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+func (s *pairService) ListMarkupRules(fromCurrencyCode, toCurrencyCode string) ([]MarkupRuleResponse, error) {
+	rules, err := s.exchangeRepo.ListMarkupRules(strings.ToUpper(fromCurrencyCode), strings.ToUpper(toCurrencyCode))
+	if err != nil {
+		return nil, err
+	}
+
+	return toMarkupRuleResponses(rules), nil
+}
+
+// ReplaceMarkupRules replaces every markup rule of the pair. Rules can be set on a pair that is only quoted
+// through a cross rate, so the currencies are checked instead of the exchanges table.
+func (s *pairService) ReplaceMarkupRules(adminUserId uint, fromCurrencyCode, toCurrencyCode string, request MarkupRulesRequest) ([]MarkupRuleResponse, error) {
+	fromCurrencyCode = strings.ToUpper(fromCurrencyCode)
+	toCurrencyCode = strings.ToUpper(toCurrencyCode)
+	if fromCurrencyCode == toCurrencyCode {
+		return nil, fmt.Errorf("%w: currencies must differ", ErrInvalidPair)
+	}
+
+	for _, currencyCode := range []string{fromCurrencyCode, toCurrencyCode} {
+		if ok := s.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+			return nil, fmt.Errorf("%w: currency %s not found", ErrInvalidPair, currencyCode)
+		}
+	}
+
+	now := time.Now()
+	rules := make([]MarkupRule, 0, len(request.Rules))
+	for _, rule := range request.Rules {
+		rules = append(rules, MarkupRule{
+			FromCurrencyCode: fromCurrencyCode,
+			ToCurrencyCode:   toCurrencyCode,
+			Segment:          rule.Segment,
+			Type:             MarkupType(strings.ToUpper(string(rule.Type))),
+			Value:            rule.Value,
+			MinAmount:        currency.Round(rule.MinAmount, fromCurrencyCode),
+			CreatedAt:        now,
+		})
+	}
+
+	if err := validateMarkupRules(rules); err != nil {
+		return nil, err
+	}
+
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txExchangeRepo := s.exchangeRepo.WithTx(tx)
+		previous, err := txExchangeRepo.ListMarkupRules(fromCurrencyCode, toCurrencyCode)
+		if err != nil {
+			return err
+		}
+
+		if err = txExchangeRepo.ReplaceMarkupRules(fromCurrencyCode, toCurrencyCode, rules); err != nil {
+			return err
+		}
+
+		before, err := json.Marshal(toMarkupRuleResponses(previous))
+		if err != nil {
+			return err
+		}
+
+		after, err := json.Marshal(toMarkupRuleResponses(rules))
+		if err != nil {
+			return err
+		}
+
+		return txExchangeRepo.CreateExchangeAudit(ExchangeAudit{
+			AdminUserId:      adminUserId,
+			Action:           PairAuditActionMarkup,
+			FromCurrencyCode: fromCurrencyCode,
+			ToCurrencyCode:   toCurrencyCode,
+			Before:           string(before),
+			After:            string(after),
+			CreatedAt:        now,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return toMarkupRuleResponses(rules), nil
+}
+
+// changePair applies the change on the locked pair, records its rate history when a rate changes and audits it
+func (s *pairService) changePair(adminUserId uint, fromCurrencyCode, toCurrencyCode string, action PairAuditAction, change func(exchange *Exchange)) (*PairResponse, error) {
+	fromCurrencyCode = strings.ToUpper(fromCurrencyCode)
+	toCurrencyCode = strings.ToUpper(toCurrencyCode)
+
+	var changed Exchange
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txExchangeRepo := s.exchangeRepo.WithTx(tx)
+		exchange, err := s.lockPair(txExchangeRepo, fromCurrencyCode, toCurrencyCode)
+		if err != nil {
+			return err
+		}
+
+		changed = *exchange
+		change(&changed)
+		changed.UpdatedAt = time.Now()
+		if err = txExchangeRepo.UpdateExchange(changed); err != nil {
+			return err
+		}
+
+		if !changed.ExchangeRate.Equal(exchange.ExchangeRate) || !changed.MarkupRate.Equal(exchange.MarkupRate) {
+			if err = txExchangeRepo.CreateRateHistory(newRateHistory(changed)); err != nil {
+				return err
+			}
+		}
+
+		return s.audit(txExchangeRepo, adminUserId, action, exchange, &changed)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toPairResponse(changed)
+	return &response, nil
+}
+
+func (s *pairService) lockPair(exchangeRepo IExchangeRepository, fromCurrencyCode, toCurrencyCode string) (*Exchange, error) {
+	exchange, err := exchangeRepo.GetExchangeForUpdate(fromCurrencyCode, toCurrencyCode)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s/%s", ErrPairNotFound, fromCurrencyCode, toCurrencyCode)
+	}
+	return exchange, err
+}
+
+func (s *pairService) audit(exchangeRepo IExchangeRepository, adminUserId uint, action PairAuditAction, before, after *Exchange) error {
+	pair := after
+	if pair == nil {
+		pair = before
+	}
+
+	beforeSnapshot, err := pairSnapshot(before)
+	if err != nil {
+		return err
+	}
+
+	afterSnapshot, err := pairSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	return exchangeRepo.CreateExchangeAudit(ExchangeAudit{
+		AdminUserId:      adminUserId,
+		Action:           action,
+		FromCurrencyCode: pair.FromCurrencyCode,
+		ToCurrencyCode:   pair.ToCurrencyCode,
+		Before:           beforeSnapshot,
+		After:            afterSnapshot,
+		CreatedAt:        time.Now(),
+	})
+}
+
+// pairSnapshot is the JSON of the pair as admins see it, empty when there is no pair
+func pairSnapshot(exchange *Exchange) (string, error) {
+	if exchange == nil {
+		return "", nil
+	}
+
+	content, err := json.Marshal(toPairResponse(*exchange))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func validateRates(exchangeRate, markupRate, fixedFee decimal.Decimal) error {
+	if !exchangeRate.IsPositive() {
+		return fmt.Errorf("%w: exchange rate must be positive", ErrInvalidPair)
+	}
+
+	if markupRate.IsNegative() || !markupRate.LessThan(exchangeRate) {
+		return fmt.Errorf("%w: markup rate must be at least zero and less than exchange rate", ErrInvalidPair)
+	}
+
+	if fixedFee.IsNegative() {
+		return fmt.Errorf("%w: fixed fee can not be negative", ErrInvalidPair)
+	}
+
+	return nil
+}
+
+// validateOfferTTL allows zero for the configured offer TTL
+func validateOfferTTL(offerTTLSeconds int) error {
+	if offerTTLSeconds < 0 || time.Duration(offerTTLSeconds)*time.Second > maxOfferTTL {
+		return fmt.Errorf("%w: offer ttl must be between 0 and %d seconds", ErrInvalidPair, int(maxOfferTTL.Seconds()))
+	}
+
+	return nil
+}
+
+func newRateHistory(exchange Exchange) RateHistory {
+	return RateHistory{
+		FromCurrencyCode: exchange.FromCurrencyCode,
+		ToCurrencyCode:   exchange.ToCurrencyCode,
+		ExchangeRate:     exchange.ExchangeRate,
+		MarkupRate:       exchange.MarkupRate,
+		RecordedAt:       exchange.UpdatedAt,
+	}
+}
+
+func toPairResponse(exchange Exchange) PairResponse {
+	return PairResponse{
+		FromCurrencyCode: exchange.FromCurrencyCode,
+		ToCurrencyCode:   exchange.ToCurrencyCode,
+		ExchangeRate:     exchange.ExchangeRate,
+		MarkupRate:       exchange.MarkupRate,
+		FixedFee:         exchange.FixedFee,
+		Disabled:         exchange.Disabled,
+		OfferTTLSeconds:  exchange.OfferTTLSeconds,
+		UpdatedAt:        exchange.UpdatedAt,
+	}
+}
+
+func toMarkupRuleResponses(rules []MarkupRule) []MarkupRuleResponse {
+	responses := []MarkupRuleResponse{}
+	for _, rule := range rules {
+		responses = append(responses, MarkupRuleResponse{
+			Segment:   rule.Segment,
+			Type:      rule.Type,
+			Value:     rule.Value,
+			MinAmount: rule.MinAmount,
+		})
+	}
+	return responses
+}
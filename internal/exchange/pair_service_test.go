@@ -0,0 +1,220 @@
+package exchange
+
+import (
+	// Go imports
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+func TestPairService_CreatePair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "GBP"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	pairService := NewPairService(mockExchangeRepository, currencyService, mockUnitOfWork)
+	adminUserId := uint(9)
+
+	expectTransaction := func() {
+		mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+			return fn(nil)
+		})
+		mockExchangeRepository.EXPECT().WithTx(gomock.Any()).Return(mockExchangeRepository)
+	}
+
+	t.Run("unknown currency", func(t *testing.T) {
+		_, err := pairService.CreatePair(adminUserId, CreatePairRequest{FromCurrencyCode: "USD", ToCurrencyCode: "AAA", ExchangeRate: decimal.NewFromInt(1)})
+		assert.ErrorIs(t, err, ErrInvalidPair)
+	})
+
+	t.Run("markup not less than rate", func(t *testing.T) {
+		_, err := pairService.CreatePair(adminUserId, CreatePairRequest{FromCurrencyCode: "USD", ToCurrencyCode: "GBP", ExchangeRate: decimal.RequireFromString("0.82"), MarkupRate: decimal.RequireFromString("0.82")})
+		assert.ErrorIs(t, err, ErrInvalidPair)
+	})
+
+	t.Run("negative fixed fee", func(t *testing.T) {
+		_, err := pairService.CreatePair(adminUserId, CreatePairRequest{FromCurrencyCode: "USD", ToCurrencyCode: "GBP", ExchangeRate: decimal.RequireFromString("0.82"), FixedFee: decimal.NewFromInt(-1)})
+		assert.ErrorIs(t, err, ErrInvalidPair)
+	})
+
+	t.Run("offer ttl above the limit", func(t *testing.T) {
+		_, err := pairService.CreatePair(adminUserId, CreatePairRequest{FromCurrencyCode: "USD", ToCurrencyCode: "GBP", ExchangeRate: decimal.RequireFromString("0.82"), OfferTTLSeconds: 7200})
+		assert.ErrorIs(t, err, ErrInvalidPair)
+	})
+
+	t.Run("pair already exists", func(t *testing.T) {
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetExchangeForUpdate("USD", "GBP").Return(&Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "GBP"}, nil)
+		_, err := pairService.CreatePair(adminUserId, CreatePairRequest{FromCurrencyCode: "usd", ToCurrencyCode: "gbp", ExchangeRate: decimal.RequireFromString("0.82")})
+		assert.ErrorIs(t, err, ErrPairAlreadyExists)
+	})
+
+	t.Run("pair created with rate history and audit", func(t *testing.T) {
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetExchangeForUpdate("USD", "GBP").Return(nil, gorm.ErrRecordNotFound)
+		mockExchangeRepository.EXPECT().CreateExchange(gomock.Any()).Return(nil)
+		mockExchangeRepository.EXPECT().CreateRateHistory(gomock.Any()).Return(nil)
+		mockExchangeRepository.EXPECT().CreateExchangeAudit(gomock.Any()).DoAndReturn(func(audit ExchangeAudit) error {
+			assert.Equal(t, adminUserId, audit.AdminUserId)
+			assert.Equal(t, PairAuditActionCreate, audit.Action)
+			assert.Equal(t, "USD", audit.FromCurrencyCode)
+			assert.Empty(t, audit.Before)
+			assert.Contains(t, audit.After, `"exchange_rate":"0.82"`)
+			return nil
+		})
+
+		pair, err := pairService.CreatePair(adminUserId, CreatePairRequest{FromCurrencyCode: "usd", ToCurrencyCode: "gbp", ExchangeRate: decimal.RequireFromString("0.82"), MarkupRate: decimal.RequireFromString("0.01")})
+		assert.Nil(t, err)
+		assert.Equal(t, "GBP", pair.ToCurrencyCode)
+		assert.False(t, pair.Disabled)
+	})
+}
+
+func TestPairService_ChangePair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	pairService := NewPairService(mockExchangeRepository, currency.Service{}, mockUnitOfWork)
+	adminUserId := uint(9)
+	exchange := Exchange{
+		FromCurrencyCode: "USD",
+		ToCurrencyCode:   "TRY",
+		ExchangeRate:     decimal.RequireFromString("18.63"),
+		MarkupRate:       decimal.RequireFromString("0.3"),
+	}
+
+	expectTransaction := func() {
+		mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+			return fn(nil)
+		})
+		mockExchangeRepository.EXPECT().WithTx(gomock.Any()).Return(mockExchangeRepository)
+	}
+
+	t.Run("pair not found", func(t *testing.T) {
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetExchangeForUpdate("USD", "JPY").Return(nil, gorm.ErrRecordNotFound)
+		_, err := pairService.SetPairDisabled(adminUserId, "usd", "jpy", true)
+		assert.ErrorIs(t, err, ErrPairNotFound)
+	})
+
+	t.Run("rate update is recorded on rate history", func(t *testing.T) {
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetExchangeForUpdate("USD", "TRY").Return(&exchange, nil)
+		mockExchangeRepository.EXPECT().UpdateExchange(gomock.Any()).Return(nil)
+		mockExchangeRepository.EXPECT().CreateRateHistory(gomock.Any()).DoAndReturn(func(history RateHistory) error {
+			assert.True(t, history.ExchangeRate.Equal(decimal.RequireFromString("18.7")))
+			return nil
+		})
+		mockExchangeRepository.EXPECT().CreateExchangeAudit(gomock.Any()).DoAndReturn(func(audit ExchangeAudit) error {
+			assert.Equal(t, PairAuditActionUpdate, audit.Action)
+			assert.Contains(t, audit.Before, `"exchange_rate":"18.63"`)
+			assert.Contains(t, audit.After, `"exchange_rate":"18.7"`)
+			return nil
+		})
+
+		pair, err := pairService.UpdatePair(adminUserId, "USD", "TRY", UpdatePairRequest{ExchangeRate: decimal.RequireFromString("18.7"), MarkupRate: decimal.RequireFromString("0.3")})
+		assert.Nil(t, err)
+		assert.True(t, pair.ExchangeRate.Equal(decimal.RequireFromString("18.7")))
+	})
+
+	t.Run("disable does not touch rate history", func(t *testing.T) {
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetExchangeForUpdate("USD", "TRY").Return(&exchange, nil)
+		mockExchangeRepository.EXPECT().UpdateExchange(gomock.Any()).DoAndReturn(func(changed Exchange) error {
+			assert.True(t, changed.Disabled)
+			return nil
+		})
+		mockExchangeRepository.EXPECT().CreateExchangeAudit(gomock.Any()).DoAndReturn(func(audit ExchangeAudit) error {
+			assert.Equal(t, PairAuditActionDisable, audit.Action)
+			return nil
+		})
+
+		pair, err := pairService.SetPairDisabled(adminUserId, "USD", "TRY", true)
+		assert.Nil(t, err)
+		assert.True(t, pair.Disabled)
+	})
+
+	t.Run("delete is audited", func(t *testing.T) {
+		expectTransaction()
+		mockExchangeRepository.EXPECT().GetExchangeForUpdate("USD", "TRY").Return(&exchange, nil)
+		mockExchangeRepository.EXPECT().DeleteExchange("USD", "TRY").Return(nil)
+		mockExchangeRepository.EXPECT().CreateExchangeAudit(gomock.Any()).DoAndReturn(func(audit ExchangeAudit) error {
+			assert.Equal(t, PairAuditActionDelete, audit.Action)
+			assert.Equal(t, "TRY", audit.ToCurrencyCode)
+			assert.Empty(t, audit.After)
+			return nil
+		})
+
+		assert.Nil(t, pairService.DeletePair(adminUserId, "usd", "try"))
+	})
+}
+
+func TestPairService_ListPairAudits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	pairService := NewPairService(mockExchangeRepository, currency.Service{}, uow.NewMockIUnitOfWork(ctrl))
+
+	mockExchangeRepository.EXPECT().ListExchangeAudits("USD", "").Return([]ExchangeAudit{
+		{Id: 2, AdminUserId: 9, Action: PairAuditActionDelete, FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Before: `{"disabled":true}`},
+	}, nil)
+
+	audits, err := pairService.ListPairAudits("usd", "")
+	assert.Nil(t, err)
+	assert.Len(t, audits, 1)
+	assert.JSONEq(t, `{"disabled":true}`, string(audits[0].Before))
+	assert.Nil(t, audits[0].After)
+}
+
+func TestPairService_ReplaceMarkupRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	pairService := NewPairService(mockExchangeRepository, currencyService, mockUnitOfWork)
+	adminUserId := uint(9)
+
+	t.Run("duplicate tier", func(t *testing.T) {
+		rule := MarkupRuleRequest{Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(50)}
+		_, err := pairService.ReplaceMarkupRules(adminUserId, "USD", "TRY", MarkupRulesRequest{Rules: []MarkupRuleRequest{rule, rule}})
+		assert.ErrorIs(t, err, ErrInvalidPair)
+	})
+
+	t.Run("rules replaced and audited", func(t *testing.T) {
+		mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+			return fn(nil)
+		})
+		mockExchangeRepository.EXPECT().WithTx(gomock.Any()).Return(mockExchangeRepository)
+		mockExchangeRepository.EXPECT().ListMarkupRules("USD", "TRY").Return(nil, nil)
+		mockExchangeRepository.EXPECT().ReplaceMarkupRules("USD", "TRY", gomock.Any()).DoAndReturn(func(_, _ string, rules []MarkupRule) error {
+			assert.Len(t, rules, 1)
+			assert.Equal(t, MarkupTypeBasisPoints, rules[0].Type)
+			return nil
+		})
+		mockExchangeRepository.EXPECT().CreateExchangeAudit(gomock.Any()).DoAndReturn(func(audit ExchangeAudit) error {
+			assert.Equal(t, PairAuditActionMarkup, audit.Action)
+			assert.Equal(t, "[]", audit.Before)
+			assert.Contains(t, audit.After, `"type":"BASIS_POINTS"`)
+			return nil
+		})
+
+		rules, err := pairService.ReplaceMarkupRules(adminUserId, "usd", "try", MarkupRulesRequest{Rules: []MarkupRuleRequest{{Type: "basis_points", Value: decimal.NewFromInt(50)}}})
+		assert.Nil(t, err)
+		assert.Len(t, rules, 1)
+	})
+}
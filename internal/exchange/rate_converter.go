@@ -0,0 +1,42 @@
+package exchange
+
+import (
+	// Go imports
+	"strings"
+
+	// External imports
+	"github.com/shopspring/decimal"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+)
+
+// IRateConverter gives the mid-market rate between two currencies without markup or fees,
+// it values amounts in another currency and is never used for trading
+type IRateConverter interface {
+	GetMidRate(fromCurrencyCode, toCurrencyCode string) (decimal.Decimal, error)
+}
+
+type rateConverter struct {
+	exchangeRepo      IExchangeRepository
+	pivotCurrencyCode string
+}
+
+func NewRateConverter(exchangeRepository IExchangeRepository, config config.Config) IRateConverter {
+	return &rateConverter{exchangeRepo: exchangeRepository, pivotCurrencyCode: strings.ToUpper(config.RatePivotCurrency)}
+}
+
+// GetMidRate uses the stored pair or triangulates like offers do, the rate of a currency to itself is one
+func (c *rateConverter) GetMidRate(fromCurrencyCode, toCurrencyCode string) (decimal.Decimal, error) {
+	fromCurrencyCode, toCurrencyCode = strings.ToUpper(fromCurrencyCode), strings.ToUpper(toCurrencyCode)
+	if fromCurrencyCode == toCurrencyCode {
+		return decimal.NewFromInt(1), nil
+	}
+
+	path, err := resolveRatePath(c.exchangeRepo, fromCurrencyCode, toCurrencyCode, c.pivotCurrencyCode)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return path.MidRate(), nil
+}
@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	// Go imports
+	"testing"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+)
+
+func TestRateConverter_GetMidRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	converter := NewRateConverter(mockExchangeRepository, config.Config{RatePivotCurrency: "USD"})
+
+	t.Run("same currency", func(t *testing.T) {
+		rate, err := converter.GetMidRate("usd", "USD")
+		assert.Nil(t, err)
+		assert.True(t, decimal.NewFromInt(1).Equal(rate))
+	})
+
+	t.Run("through the pivot currency", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().GetExchangeRate("EUR", "TRY").Return(nil, gorm.ErrRecordNotFound)
+		mockExchangeRepository.EXPECT().ListExchanges().Return([]Exchange{
+			{FromCurrencyCode: "EUR", ToCurrencyCode: "USD", ExchangeRate: decimal.RequireFromString("1.05"), MarkupRate: decimal.RequireFromString("0.01")},
+			{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(20), MarkupRate: decimal.NewFromInt(1)},
+		}, nil)
+
+		rate, err := converter.GetMidRate("EUR", "TRY")
+		assert.Nil(t, err)
+		assert.True(t, decimal.NewFromInt(21).Equal(rate))
+	})
+
+	t.Run("no rate", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().GetExchangeRate("EUR", "JPY").Return(nil, gorm.ErrRecordNotFound)
+		mockExchangeRepository.EXPECT().ListExchanges().Return(nil, nil)
+
+		_, err := converter.GetMidRate("EUR", "JPY")
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+}
@@ -0,0 +1,182 @@
+package exchange
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+// ratePath is the chain of stored pairs a rate is computed through, a direct pair has a single leg
+type ratePath struct {
+	legs []Exchange
+}
+
+// Currencies returns the currency codes visited from the source to the target currency
+func (p ratePath) Currencies() []string {
+	if len(p.legs) == 0 {
+		return nil
+	}
+
+	currencies := []string{p.legs[0].FromCurrencyCode}
+	for _, leg := range p.legs {
+		currencies = append(currencies, leg.ToCurrencyCode)
+	}
+	return currencies
+}
+
+// MidRate multiplies the leg rates, nothing is rounded until the final rate
+func (p ratePath) MidRate() decimal.Decimal {
+	rate := decimal.NewFromInt(1)
+	for _, leg := range p.legs {
+		rate = rate.Mul(leg.ExchangeRate)
+	}
+	return rate
+}
+
+// MarkupRate is applied once on the mid rate. The markup of a direct pair is used as it is,
+// a cross rate is marked up by the widest relative markup of its legs.
+func (p ratePath) MarkupRate() decimal.Decimal {
+	if len(p.legs) == 1 {
+		return p.legs[0].MarkupRate
+	}
+
+	spread := decimal.Zero
+	for _, leg := range p.legs {
+		if !leg.ExchangeRate.IsPositive() {
+			continue
+		}
+
+		if legSpread := leg.MarkupRate.Div(leg.ExchangeRate); legSpread.GreaterThan(spread) {
+			spread = legSpread
+		}
+	}
+
+	return currency.RoundRate(p.MidRate().Mul(spread))
+}
+
+// FixedFee is the fee of the last leg, the only leg whose fee is in the target currency
+func (p ratePath) FixedFee() decimal.Decimal {
+	if len(p.legs) == 0 {
+		return decimal.Zero
+	}
+	return p.legs[len(p.legs)-1].FixedFee
+}
+
+// OfferTTL is the shortest offer TTL among the legs which have one, zero when no leg has its own TTL
+func (p ratePath) OfferTTL() time.Duration {
+	var ttl time.Duration
+	for _, leg := range p.legs {
+		if leg.OfferTTLSeconds <= 0 {
+			continue
+		}
+
+		if legTTL := time.Duration(leg.OfferTTLSeconds) * time.Second; ttl == 0 || legTTL < ttl {
+			ttl = legTTL
+		}
+	}
+	return ttl
+}
+
+// resolveRatePath uses the stored pair when there is one and triangulates through the other pairs otherwise
+func resolveRatePath(exchangeRepository IExchangeRepository, fromCurrencyCode, toCurrencyCode, pivotCurrencyCode string) (ratePath, error) {
+	exchange, err := exchangeRepository.GetExchangeRate(fromCurrencyCode, toCurrencyCode)
+	if err == nil {
+		return ratePath{legs: []Exchange{*exchange}}, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return ratePath{}, err
+	}
+
+	exchanges, err := exchangeRepository.ListExchanges()
+	if err != nil {
+		return ratePath{}, err
+	}
+
+	path, ok := findRatePath(exchanges, fromCurrencyCode, toCurrencyCode, pivotCurrencyCode)
+	if !ok {
+		return ratePath{}, fmt.Errorf("%w: %s/%s", ErrRateNotFound, fromCurrencyCode, toCurrencyCode)
+	}
+
+	return path, nil
+}
+
+// findRatePath computes a missing pair through the pivot currency when both legs are stored,
+// otherwise through the shortest chain of stored pairs. Disabled pairs are never used as a leg.
+func findRatePath(exchanges []Exchange, fromCurrencyCode, toCurrencyCode, pivotCurrencyCode string) (ratePath, bool) {
+	pairs := make(map[string]map[string]Exchange)
+	for _, exchange := range exchanges {
+		if exchange.Disabled {
+			continue
+		}
+
+		if _, ok := pairs[exchange.FromCurrencyCode]; !ok {
+			pairs[exchange.FromCurrencyCode] = make(map[string]Exchange)
+		}
+		pairs[exchange.FromCurrencyCode][exchange.ToCurrencyCode] = exchange
+	}
+
+	if direct, ok := pairs[fromCurrencyCode][toCurrencyCode]; ok {
+		return ratePath{legs: []Exchange{direct}}, true
+	}
+
+	if pivotCurrencyCode != "" && pivotCurrencyCode != fromCurrencyCode && pivotCurrencyCode != toCurrencyCode {
+		toPivot, ok := pairs[fromCurrencyCode][pivotCurrencyCode]
+		if fromPivot, found := pairs[pivotCurrencyCode][toCurrencyCode]; ok && found {
+			return ratePath{legs: []Exchange{toPivot, fromPivot}}, true
+		}
+	}
+
+	// Breadth first search finds the path with the fewest legs
+	previous := map[string]Exchange{}
+	visited := map[string]bool{fromCurrencyCode: true}
+	queue := []string{fromCurrencyCode}
+	for len(queue) > 0 {
+		currencyCode := queue[0]
+		queue = queue[1:]
+
+		for _, next := range sortedQuoteCurrencies(pairs[currencyCode]) {
+			if visited[next] {
+				continue
+			}
+
+			visited[next] = true
+			previous[next] = pairs[currencyCode][next]
+			if next == toCurrencyCode {
+				return ratePath{legs: walkBack(previous, fromCurrencyCode, toCurrencyCode)}, true
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return ratePath{}, false
+}
+
+// sortedQuoteCurrencies keeps the search deterministic when more than one shortest path exists
+func sortedQuoteCurrencies(quotes map[string]Exchange) []string {
+	codes := make([]string, 0, len(quotes))
+	for code := range quotes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func walkBack(previous map[string]Exchange, fromCurrencyCode, toCurrencyCode string) []Exchange {
+	var legs []Exchange
+	for currencyCode := toCurrencyCode; currencyCode != fromCurrencyCode; {
+		leg := previous[currencyCode]
+		legs = append([]Exchange{leg}, legs...)
+		currencyCode = leg.FromCurrencyCode
+	}
+	return legs
+}
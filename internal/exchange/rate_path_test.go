@@ -0,0 +1,93 @@
+package exchange
+
+import (
+	// Go imports
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testExchanges() []Exchange {
+	return []Exchange{
+		{FromCurrencyCode: "TRY", ToCurrencyCode: "USD", ExchangeRate: decimal.RequireFromString("0.054"), MarkupRate: decimal.RequireFromString("0.0027")},
+		{FromCurrencyCode: "USD", ToCurrencyCode: "EUR", ExchangeRate: decimal.RequireFromString("0.96"), MarkupRate: decimal.RequireFromString("0.0096")},
+		{FromCurrencyCode: "TRY", ToCurrencyCode: "GBP", ExchangeRate: decimal.RequireFromString("0.044"), MarkupRate: decimal.RequireFromString("0.00044")},
+		{FromCurrencyCode: "GBP", ToCurrencyCode: "EUR", ExchangeRate: decimal.RequireFromString("1.16"), MarkupRate: decimal.RequireFromString("0.0116")},
+		{FromCurrencyCode: "EUR", ToCurrencyCode: "JPY", ExchangeRate: decimal.RequireFromString("145"), MarkupRate: decimal.RequireFromString("1.45")},
+	}
+}
+
+func TestFindRatePath(t *testing.T) {
+	t.Run("direct pair", func(t *testing.T) {
+		path, ok := findRatePath(testExchanges(), "TRY", "USD", "USD")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"TRY", "USD"}, path.Currencies())
+		assert.True(t, path.MarkupRate().Equal(decimal.RequireFromString("0.0027")))
+	})
+
+	t.Run("through pivot currency", func(t *testing.T) {
+		path, ok := findRatePath(testExchanges(), "TRY", "EUR", "USD")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"TRY", "USD", "EUR"}, path.Currencies())
+	})
+
+	t.Run("shortest path without pivot", func(t *testing.T) {
+		path, ok := findRatePath(testExchanges(), "TRY", "EUR", "")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"TRY", "GBP", "EUR"}, path.Currencies())
+	})
+
+	t.Run("shortest path when pivot has no legs", func(t *testing.T) {
+		path, ok := findRatePath(testExchanges(), "USD", "JPY", "CHF")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"USD", "EUR", "JPY"}, path.Currencies())
+	})
+
+	t.Run("disabled pair is not used as a leg", func(t *testing.T) {
+		exchanges := testExchanges()
+		exchanges[1].Disabled = true
+		path, ok := findRatePath(exchanges, "TRY", "EUR", "USD")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"TRY", "GBP", "EUR"}, path.Currencies())
+	})
+
+	t.Run("no path", func(t *testing.T) {
+		_, ok := findRatePath(testExchanges(), "JPY", "TRY", "USD")
+		assert.False(t, ok)
+	})
+}
+
+func TestRatePath_MarkupRate(t *testing.T) {
+	path, ok := findRatePath(testExchanges(), "TRY", "EUR", "USD")
+	assert.True(t, ok)
+
+	// 0.054 * 0.96, marked up once by the widest leg markup of 5%
+	assert.True(t, path.MidRate().Equal(decimal.RequireFromString("0.05184")))
+	assert.True(t, path.MarkupRate().Equal(decimal.RequireFromString("0.002592")))
+}
+
+func TestRatePath_FixedFee(t *testing.T) {
+	exchanges := testExchanges()
+	for i := range exchanges {
+		exchanges[i].FixedFee = decimal.NewFromInt(int64(i + 1))
+	}
+
+	path, ok := findRatePath(exchanges, "TRY", "EUR", "USD")
+	assert.True(t, ok)
+	assert.True(t, path.FixedFee().Equal(path.legs[1].FixedFee))
+	assert.True(t, ratePath{}.FixedFee().IsZero())
+}
+
+func TestRatePath_OfferTTL(t *testing.T) {
+	exchanges := testExchanges()
+	exchanges[0].OfferTTLSeconds = 90
+	exchanges[1].OfferTTLSeconds = 30
+
+	path, ok := findRatePath(exchanges, "TRY", "EUR", "USD")
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, path.OfferTTL())
+	assert.Zero(t, ratePath{legs: testExchanges()[:1]}.OfferTTL())
+}
@@ -0,0 +1,164 @@
+package exchange
+
+import (
+	// Go imports
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+)
+
+const (
+	RateProviderDatabase = "db"
+	RateProviderFile     = "file"
+	RateProviderHttp     = "http"
+
+	// DefaultRateProviderUrl is the currency api the currency package fetches currency codes from
+	DefaultRateProviderUrl = "https://cdn.jsdelivr.net/gh/fawazahmed0/currency-api@1/latest"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// IRateProvider is a source of mid-market exchange rates, markup rates are not part of it
+type IRateProvider interface {
+	// GetRates returns the rates from the base currency keyed by quote currency code
+	GetRates(baseCurrencyCode string) (map[string]decimal.Decimal, error)
+}
+
+// NewRateProvider builds the provider selected in config, the exchanges table is used by default
+func NewRateProvider(config config.Config, exchangeRepository IExchangeRepository) (IRateProvider, error) {
+	switch strings.ToLower(config.RateProvider) {
+	case "", RateProviderDatabase:
+		return NewDatabaseRateProvider(exchangeRepository), nil
+	case RateProviderFile:
+		if config.RateProviderFile == "" {
+			return nil, errors.New("rate provider file is not configured")
+		}
+		return NewFileRateProvider(config.RateProviderFile), nil
+	case RateProviderHttp:
+		baseUrl := config.RateProviderUrl
+		if baseUrl == "" {
+			baseUrl = DefaultRateProviderUrl
+		}
+		return NewHttpRateProvider(baseUrl), nil
+	default:
+		return nil, fmt.Errorf("unknown rate provider %s", config.RateProvider)
+	}
+}
+
+type databaseRateProvider struct {
+	exchangeRepo IExchangeRepository
+}
+
+// NewDatabaseRateProvider serves the rates stored on the exchanges table
+func NewDatabaseRateProvider(exchangeRepository IExchangeRepository) IRateProvider {
+	return &databaseRateProvider{exchangeRepo: exchangeRepository}
+}
+
+func (p *databaseRateProvider) GetRates(baseCurrencyCode string) (map[string]decimal.Decimal, error) {
+	exchanges, err := p.exchangeRepo.ListExchanges()
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]decimal.Decimal)
+	for _, exchange := range exchanges {
+		if exchange.FromCurrencyCode == strings.ToUpper(baseCurrencyCode) {
+			rates[exchange.ToCurrencyCode] = exchange.ExchangeRate
+		}
+	}
+
+	return rates, nil
+}
+
+type fileRateProvider struct {
+	path string
+}
+
+// NewFileRateProvider serves the rates of a JSON file keyed by base and quote currency codes,
+// e.g. {"USD": {"TRY": "18.63", "EUR": 0.96}}. The file is read on every call so edits are picked up.
+func NewFileRateProvider(path string) IRateProvider {
+	return &fileRateProvider{path: path}
+}
+
+func (p *fileRateProvider) GetRates(baseCurrencyCode string) (map[string]decimal.Decimal, error) {
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]map[string]decimal.Decimal
+	if err = json.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("can not parse rates file %s: %w", p.path, err)
+	}
+
+	for base, rates := range document {
+		if strings.EqualFold(base, baseCurrencyCode) {
+			return upperCaseRates(rates), nil
+		}
+	}
+
+	return map[string]decimal.Decimal{}, nil
+}
+
+type httpRateProvider struct {
+	baseUrl string
+	client  *resty.Client
+}
+
+// NewHttpRateProvider serves the rates of the currency api, {baseUrl}/currencies/usd.json responds with
+// {"date": "2022-12-01", "usd": {"try": 18.63, "eur": 0.96}}
+func NewHttpRateProvider(baseUrl string) IRateProvider {
+	client := resty.New()
+	client.SetRetryCount(4)
+	client.SetTimeout(10 * time.Second)
+	return &httpRateProvider{baseUrl: strings.TrimSuffix(baseUrl, "/"), client: client}
+}
+
+func (p *httpRateProvider) GetRates(baseCurrencyCode string) (map[string]decimal.Decimal, error) {
+	base := strings.ToLower(baseCurrencyCode)
+	rsp, err := p.client.R().Get(fmt.Sprintf("%s/currencies/%s.json", p.baseUrl, base))
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("currency api responded %d for %s", rsp.StatusCode(), strings.ToUpper(base))
+	}
+
+	var document map[string]json.RawMessage
+	if err = json.Unmarshal(rsp.Body(), &document); err != nil {
+		return nil, err
+	}
+
+	body, ok := document[base]
+	if !ok {
+		return nil, fmt.Errorf("%w: currency api has no rates for %s", ErrRateNotFound, strings.ToUpper(base))
+	}
+
+	// Numbers are parsed into decimals directly so no precision is lost on float conversion
+	var rates map[string]decimal.Decimal
+	if err = json.Unmarshal(body, &rates); err != nil {
+		return nil, err
+	}
+
+	return upperCaseRates(rates), nil
+}
+
+func upperCaseRates(rates map[string]decimal.Decimal) map[string]decimal.Decimal {
+	upperCased := make(map[string]decimal.Decimal, len(rates))
+	for code, rate := range rates {
+		upperCased[strings.ToUpper(code)] = rate
+	}
+	return upperCased
+}
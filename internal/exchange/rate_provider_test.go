@@ -0,0 +1,121 @@
+package exchange
+
+import (
+	// Go imports
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+)
+
+func TestNewRateProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+
+	t.Run("database by default", func(t *testing.T) {
+		provider, err := NewRateProvider(config.Config{}, mockExchangeRepository)
+		assert.Nil(t, err)
+		assert.IsType(t, &databaseRateProvider{}, provider)
+	})
+
+	t.Run("file without path", func(t *testing.T) {
+		_, err := NewRateProvider(config.Config{RateProvider: RateProviderFile}, mockExchangeRepository)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("http with default url", func(t *testing.T) {
+		provider, err := NewRateProvider(config.Config{RateProvider: "HTTP"}, mockExchangeRepository)
+		assert.Nil(t, err)
+		assert.Equal(t, DefaultRateProviderUrl, provider.(*httpRateProvider).baseUrl)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := NewRateProvider(config.Config{RateProvider: "ftp"}, mockExchangeRepository)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestDatabaseRateProvider_GetRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	provider := NewDatabaseRateProvider(mockExchangeRepository)
+
+	mockExchangeRepository.EXPECT().ListExchanges().Return([]Exchange{
+		{FromCurrencyCode: "TRY", ToCurrencyCode: "USD", ExchangeRate: decimal.RequireFromString("0.054")},
+		{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.RequireFromString("18.63")},
+	}, nil)
+
+	rates, err := provider.GetRates("usd")
+	assert.Nil(t, err)
+	assert.Len(t, rates, 1)
+	assert.True(t, rates["TRY"].Equal(decimal.RequireFromString("18.63")))
+}
+
+func TestFileRateProvider_GetRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	provider := NewFileRateProvider(path)
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := provider.GetRates("USD")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("rates of given base", func(t *testing.T) {
+		err := os.WriteFile(path, []byte(`{"usd": {"try": "18.6312", "EUR": 0.96}, "TRY": {"USD": 0.054}}`), 0o600)
+		assert.Nil(t, err)
+
+		rates, err := provider.GetRates("USD")
+		assert.Nil(t, err)
+		assert.Len(t, rates, 2)
+		assert.True(t, rates["TRY"].Equal(decimal.RequireFromString("18.6312")))
+		assert.True(t, rates["EUR"].Equal(decimal.RequireFromString("0.96")))
+	})
+
+	t.Run("base not in file", func(t *testing.T) {
+		rates, err := provider.GetRates("GBP")
+		assert.Nil(t, err)
+		assert.Empty(t, rates)
+	})
+}
+
+func TestHttpRateProvider_GetRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/currencies/usd.json":
+			_, _ = w.Write([]byte(`{"date": "2022-12-01", "usd": {"try": 18.631245, "eur": 0.96}}`))
+		case "/currencies/eur.json":
+			_, _ = w.Write([]byte(`{"date": "2022-12-01"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := &httpRateProvider{baseUrl: server.URL, client: NewHttpRateProvider(server.URL).(*httpRateProvider).client.SetRetryCount(0)}
+
+	t.Run("rates of given base", func(t *testing.T) {
+		rates, err := provider.GetRates("USD")
+		assert.Nil(t, err)
+		assert.True(t, rates["TRY"].Equal(decimal.RequireFromString("18.631245")))
+		assert.True(t, rates["EUR"].Equal(decimal.RequireFromString("0.96")))
+	})
+
+	t.Run("base missing on response", func(t *testing.T) {
+		_, err := provider.GetRates("EUR")
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+
+	t.Run("unknown base", func(t *testing.T) {
+		_, err := provider.GetRates("XYZ")
+		assert.NotNil(t, err)
+	})
+}
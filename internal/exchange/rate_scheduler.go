@@ -0,0 +1,124 @@
+package exchange
+
+import (
+	// Go imports
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+// DefaultRateRefreshInterval is used when no refresh interval is configured
+const DefaultRateRefreshInterval = 5 * time.Minute
+
+// RateScheduler refreshes the exchange rates of every pair on the exchanges table from a rate provider.
+// Markup rates are left as they are.
+type RateScheduler struct {
+	provider     IRateProvider
+	exchangeRepo IExchangeRepository
+	interval     time.Duration
+	done         chan struct{}
+}
+
+func NewRateScheduler(provider IRateProvider, exchangeRepository IExchangeRepository, interval time.Duration) *RateScheduler {
+	if interval <= 0 {
+		interval = DefaultRateRefreshInterval
+	}
+	return &RateScheduler{provider: provider, exchangeRepo: exchangeRepository, interval: interval, done: make(chan struct{})}
+}
+
+// Start refreshes the rates right away and then on every interval until the context is done, a refresh in progress
+// is finished first
+func (s *RateScheduler) Start(ctx context.Context) {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Refresh(); err != nil {
+				log.Println("exchange rates refresh:", err.Error())
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Done is closed once the scheduler stopped after its context is done
+func (s *RateScheduler) Done() <-chan struct{} {
+	return s.done
+}
+
+// Refresh updates every pair whose rate is served by the provider. A failing pair does not stop the others.
+func (s *RateScheduler) Refresh() error {
+	exchanges, err := s.exchangeRepo.ListExchanges()
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	ratesByBase := make(map[string]map[string]decimal.Decimal)
+	for _, exchange := range exchanges {
+		rates, ok := ratesByBase[exchange.FromCurrencyCode]
+		if !ok {
+			rates, err = s.fetchRates(exchange.FromCurrencyCode)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", exchange.FromCurrencyCode, err.Error()))
+			}
+			ratesByBase[exchange.FromCurrencyCode] = rates
+		}
+
+		rate, ok := rates[exchange.ToCurrencyCode]
+		if !ok {
+			if rates != nil {
+				failures = append(failures, fmt.Sprintf("%s/%s: %s", exchange.FromCurrencyCode, exchange.ToCurrencyCode, ErrRateNotFound.Error()))
+			}
+			continue
+		}
+
+		if rate.Equal(exchange.ExchangeRate) {
+			continue
+		}
+
+		if err = s.exchangeRepo.UpdateExchangeRate(exchange.FromCurrencyCode, exchange.ToCurrencyCode, rate); err != nil {
+			failures = append(failures, fmt.Sprintf("%s/%s: %s", exchange.FromCurrencyCode, exchange.ToCurrencyCode, err.Error()))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("can not refresh exchange rates: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+// fetchRates returns the provider rates from the base currency rounded to the rate precision
+func (s *RateScheduler) fetchRates(baseCurrencyCode string) (map[string]decimal.Decimal, error) {
+	rates, err := s.provider.GetRates(baseCurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	rounded := make(map[string]decimal.Decimal, len(rates))
+	for code, rate := range rates {
+		// A zero or negative rate is never quoted, the previous rate is kept instead
+		if rate.IsPositive() {
+			rounded[code] = currency.RoundRate(rate)
+		}
+	}
+
+	return rounded, nil
+}
@@ -0,0 +1,87 @@
+package exchange
+
+import (
+	// Go imports
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateScheduler_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockRateProvider := NewMockIRateProvider(ctrl)
+	scheduler := NewRateScheduler(mockRateProvider, mockExchangeRepository, time.Minute)
+
+	exchanges := []Exchange{
+		{FromCurrencyCode: "TRY", ToCurrencyCode: "EUR", ExchangeRate: decimal.RequireFromString("0.052"), MarkupRate: decimal.RequireFromString("0.007")},
+		{FromCurrencyCode: "TRY", ToCurrencyCode: "USD", ExchangeRate: decimal.RequireFromString("0.054"), MarkupRate: decimal.RequireFromString("0.009")},
+		{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.RequireFromString("18.63"), MarkupRate: decimal.RequireFromString("0.3")},
+	}
+
+	t.Run("changed rates are updated once per base", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ListExchanges().Return(exchanges, nil)
+		mockRateProvider.EXPECT().GetRates("TRY").Return(map[string]decimal.Decimal{
+			"EUR": decimal.RequireFromString("0.052"),
+			"USD": decimal.RequireFromString("0.0536666666"),
+		}, nil)
+		mockRateProvider.EXPECT().GetRates("USD").Return(map[string]decimal.Decimal{
+			"TRY": decimal.RequireFromString("18.7"),
+		}, nil)
+		mockExchangeRepository.EXPECT().UpdateExchangeRate("TRY", "USD", decimal.RequireFromString("0.053667")).Return(nil)
+		mockExchangeRepository.EXPECT().UpdateExchangeRate("USD", "TRY", decimal.RequireFromString("18.700000")).Return(nil)
+
+		assert.Nil(t, scheduler.Refresh())
+	})
+
+	t.Run("failing base does not stop the others", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ListExchanges().Return(exchanges, nil)
+		mockRateProvider.EXPECT().GetRates("TRY").Return(nil, errors.New("currency api responded 503 for TRY"))
+		mockRateProvider.EXPECT().GetRates("USD").Return(map[string]decimal.Decimal{
+			"TRY": decimal.RequireFromString("18.7"),
+		}, nil)
+		mockExchangeRepository.EXPECT().UpdateExchangeRate("USD", "TRY", decimal.RequireFromString("18.700000")).Return(nil)
+
+		err := scheduler.Refresh()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "TRY: currency api responded 503")
+	})
+
+	t.Run("missing and non positive rates keep the previous rate", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ListExchanges().Return(exchanges[1:], nil)
+		mockRateProvider.EXPECT().GetRates("TRY").Return(map[string]decimal.Decimal{"USD": decimal.Zero}, nil)
+		mockRateProvider.EXPECT().GetRates("USD").Return(map[string]decimal.Decimal{}, nil)
+
+		err := scheduler.Refresh()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "USD/TRY: "+ErrRateNotFound.Error())
+	})
+}
+
+func TestRateScheduler_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	scheduler := NewRateScheduler(NewMockIRateProvider(ctrl), mockExchangeRepository, time.Millisecond)
+
+	refreshed := make(chan struct{}, 2)
+	mockExchangeRepository.EXPECT().ListExchanges().DoAndReturn(func() ([]Exchange, error) {
+		select {
+		case refreshed <- struct{}{}:
+		default:
+		}
+		return nil, nil
+	}).MinTimes(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	<-refreshed
+	<-refreshed
+	cancel()
+	<-scheduler.Done()
+}
@@ -0,0 +1,188 @@
+package exchange
+
+import (
+	// Go imports
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+const (
+	// DefaultRateStreamInterval is used when no stream interval is configured
+	DefaultRateStreamInterval = 2 * time.Second
+	// rateSubscriptionBuffer is the number of updates a subscriber may fall behind before it is dropped
+	rateSubscriptionBuffer = 64
+)
+
+// IRateStream pushes the changes on the exchanges table to its subscribers
+type IRateStream interface {
+	Subscribe(pairs map[string]bool) *RateSubscription
+	Unsubscribe(subscription *RateSubscription)
+}
+
+// RateSubscription receives the updates of the subscribed pairs, or of every pair when no pair is subscribed.
+// Its channel is closed when the subscriber falls too far behind, the client is expected to connect again.
+type RateSubscription struct {
+	pairs    map[string]bool
+	snapshot []RateUpdate
+	updates  chan RateUpdate
+}
+
+// Snapshot returns the rates of the subscribed pairs at the time of subscription
+func (s *RateSubscription) Snapshot() []RateUpdate {
+	return s.snapshot
+}
+
+func (s *RateSubscription) Updates() <-chan RateUpdate {
+	return s.updates
+}
+
+func (s *RateSubscription) wants(pair string) bool {
+	return len(s.pairs) == 0 || s.pairs[pair]
+}
+
+// RateStream polls the exchanges table and broadcasts the pairs which changed since the last poll.
+// Polling the table catches the rates refreshed from the rate provider and the changes of admins,
+// also when they are made by another instance of the service.
+type RateStream struct {
+	exchangeRepo IExchangeRepository
+	interval     time.Duration
+
+	mu            sync.Mutex
+	rates         map[string]RateUpdate
+	subscriptions map[*RateSubscription]struct{}
+}
+
+func NewRateStream(exchangeRepository IExchangeRepository, interval time.Duration) *RateStream {
+	if interval <= 0 {
+		interval = DefaultRateStreamInterval
+	}
+	return &RateStream{
+		exchangeRepo:  exchangeRepository,
+		interval:      interval,
+		rates:         make(map[string]RateUpdate),
+		subscriptions: make(map[*RateSubscription]struct{}),
+	}
+}
+
+// Start polls the rates right away and then on every interval until the context is done
+func (s *RateStream) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Poll(); err != nil {
+				log.Println("exchange rates stream:", err.Error())
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Poll reads every pair and sends the changed ones to their subscribers, deleted pairs are forgotten silently
+func (s *RateStream) Poll() error {
+	exchanges, err := s.exchangeRepo.ListExchanges()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rates := make(map[string]RateUpdate, len(exchanges))
+	for _, exchange := range exchanges {
+		update := toRateUpdate(exchange)
+		pair := rateStreamPair(update.FromCurrencyCode, update.ToCurrencyCode)
+		rates[pair] = update
+
+		if previous, ok := s.rates[pair]; ok && previous.equal(update) {
+			continue
+		}
+
+		s.broadcast(pair, update)
+	}
+
+	s.rates = rates
+	return nil
+}
+
+func (s *RateStream) Subscribe(pairs map[string]bool) *RateSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription := &RateSubscription{pairs: pairs, updates: make(chan RateUpdate, rateSubscriptionBuffer)}
+	for pair, update := range s.rates {
+		if subscription.wants(pair) {
+			subscription.snapshot = append(subscription.snapshot, update)
+		}
+	}
+
+	s.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+func (s *RateStream) Unsubscribe(subscription *RateSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscriptions[subscription]; ok {
+		delete(s.subscriptions, subscription)
+		close(subscription.updates)
+	}
+}
+
+// Close ends every subscription so open streams return, e.g. while the server is shutting down
+func (s *RateStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscription := range s.subscriptions {
+		delete(s.subscriptions, subscription)
+		close(subscription.updates)
+	}
+}
+
+// broadcast has to be called with the lock held, a subscriber which can not keep up is dropped so the others are not blocked
+func (s *RateStream) broadcast(pair string, update RateUpdate) {
+	for subscription := range s.subscriptions {
+		if !subscription.wants(pair) {
+			continue
+		}
+
+		select {
+		case subscription.updates <- update:
+		default:
+			delete(s.subscriptions, subscription)
+			close(subscription.updates)
+		}
+	}
+}
+
+func (u RateUpdate) equal(other RateUpdate) bool {
+	return u.ExchangeRate.Equal(other.ExchangeRate) && u.Disabled == other.Disabled
+}
+
+func rateStreamPair(fromCurrencyCode, toCurrencyCode string) string {
+	return fromCurrencyCode + "/" + toCurrencyCode
+}
+
+// toRateUpdate sends the rate the pair is quoted at with its default markup, the mid rate and the markup are not shown
+func toRateUpdate(exchange Exchange) RateUpdate {
+	return RateUpdate{
+		FromCurrencyCode: exchange.FromCurrencyCode,
+		ToCurrencyCode:   exchange.ToCurrencyCode,
+		ExchangeRate:     currency.RoundRate(exchange.ExchangeRate.Sub(exchange.MarkupRate)),
+		Disabled:         exchange.Disabled,
+		UpdatedAt:        exchange.UpdatedAt,
+	}
+}
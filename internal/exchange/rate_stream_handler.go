@@ -0,0 +1,212 @@
+package exchange
+
+import (
+	// Go imports
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+// rateStreamHeartbeatInterval keeps idle streams from being closed by proxies
+const rateStreamHeartbeatInterval = 15 * time.Second
+
+type RateStreamHandler interface {
+	Stream(c *gin.Context)
+	StreamWebSocket(c *gin.Context)
+	StreamRoutes(router *gin.RouterGroup)
+}
+
+type rateStreamHandler struct {
+	currencyService   currency.Service
+	rateStream        IRateStream
+	allowedOrigins    map[string]bool
+	heartbeatInterval time.Duration
+}
+
+// NewRateStreamHandler takes the origins such as https://app.example.com WebSocket streams may be opened from besides
+// the host of the server itself
+func NewRateStreamHandler(currencyService currency.Service, rateStream IRateStream, allowedOrigins []string) RateStreamHandler {
+	origins := make(map[string]bool)
+	for _, origin := range allowedOrigins {
+		if origin = strings.ToLower(strings.TrimRight(strings.TrimSpace(origin), "/")); origin != "" {
+			origins[origin] = true
+		}
+	}
+
+	return &rateStreamHandler{currencyService: currencyService, rateStream: rateStream, allowedOrigins: origins, heartbeatInterval: rateStreamHeartbeatInterval}
+}
+
+func (h *rateStreamHandler) StreamRoutes(router *gin.RouterGroup) {
+	router.GET("/stream", h.Stream)
+	router.GET("/stream/ws", h.StreamWebSocket)
+}
+
+// Stream godoc
+// @Summary Stream Exchange Rates
+// @Description Push the rates of the watched pairs as server-sent events, every pair is sent on connect and then whenever it changes.
+// @Description Events are rate with a RateUpdate and heartbeat without data.
+// @Tags Exchange
+// @Produce  text/event-stream
+// @Param X-Auth-Token header string false "Auth token of logged-in user, may be given as token query param instead."
+// @Param request query RateStreamRequest false "query params"
+// @Success 200 {object} RateUpdate "Stream of rate events"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Router /exchange/stream [get]
+func (h *rateStreamHandler) Stream(c *gin.Context) {
+	pairs, err := h.parsePairs(c.Query("pairs"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	subscription := h.rateStream.Subscribe(pairs)
+	defer h.rateStream.Unsubscribe(subscription)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	h.stream(subscription, c.Request.Context().Done(), func(event string, update *RateUpdate) error {
+		if update != nil {
+			c.SSEvent(event, update)
+		} else {
+			c.SSEvent(event, "")
+		}
+		c.Writer.Flush()
+		return nil
+	})
+}
+
+// StreamWebSocket godoc
+// @Summary Stream Exchange Rates Over WebSocket
+// @Description Push the rates of the watched pairs as RateStreamMessage, every pair is sent on connect and then whenever it changes.
+// @Description Messages sent by the client are ignored.
+// @Tags Exchange
+// @Param X-Auth-Token header string false "Auth token of logged-in user, may be given as token query param instead."
+// @Param request query RateStreamRequest false "query params"
+// @Success 101 {object} RateStreamMessage "Switching Protocols"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Router /exchange/stream/ws [get]
+func (h *rateStreamHandler) StreamWebSocket(c *gin.Context) {
+	pairs, err := h.parsePairs(c.Query("pairs"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			subscription := h.rateStream.Subscribe(pairs)
+			defer h.rateStream.Unsubscribe(subscription)
+
+			// Reading only tells when the client is gone
+			done := make(chan struct{})
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				close(done)
+			}()
+
+			h.stream(subscription, done, func(event string, update *RateUpdate) error {
+				return websocket.JSON.Send(conn, RateStreamMessage{Event: event, Data: update})
+			})
+		},
+	}
+
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkOrigin keeps other sites from opening a stream with a token they got hold of, a page can not forge the origin
+// the browser sends. Clients other than browsers send no origin and are let through.
+func (h *rateStreamHandler) checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+
+	if origin == nil {
+		return nil
+	}
+
+	if strings.EqualFold(origin.Host, req.Host) || h.allowedOrigins[strings.ToLower(origin.Scheme+"://"+origin.Host)] {
+		config.Origin = origin
+		return nil
+	}
+
+	return fmt.Errorf("origin %s is not allowed", origin.String())
+}
+
+// stream sends the snapshot and then the updates of the subscription until the client is gone or falls behind
+func (h *rateStreamHandler) stream(subscription *RateSubscription, done <-chan struct{}, send func(event string, update *RateUpdate) error) {
+	snapshot := subscription.Snapshot()
+	for i := range snapshot {
+		if err := send("rate", &snapshot[i]); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case update, ok := <-subscription.Updates():
+			if !ok {
+				return
+			}
+			err = send("rate", &update)
+		case <-heartbeat.C:
+			err = send("heartbeat", nil)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// parsePairs reads comma separated pairs such as USD/TRY, no pairs means every pair
+func (h *rateStreamHandler) parsePairs(query string) (map[string]bool, error) {
+	pairs := make(map[string]bool)
+	for _, pair := range strings.Split(query, ",") {
+		pair = strings.ToUpper(strings.TrimSpace(pair))
+		if pair == "" {
+			continue
+		}
+
+		currencyCodes := strings.Split(pair, "/")
+		if len(currencyCodes) != 2 {
+			return nil, fmt.Errorf("pair %s must be in FROM/TO format", pair)
+		}
+
+		for _, currencyCode := range currencyCodes {
+			if ok := h.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+				return nil, fmt.Errorf("currency %s not found", currencyCode)
+			}
+		}
+
+		pairs[rateStreamPair(currencyCodes[0], currencyCodes[1])] = true
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,121 @@
+package exchange
+
+import (
+	// Go imports
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+func TestRateStreamHandler_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	rateStream := NewRateStream(mockExchangeRepository, time.Second)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "EUR", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	NewRateStreamHandler(currencyService, rateStream, []string{"https://app.example.com/"}).StreamRoutes(router.Group("/exchange"))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	usdTry := Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.RequireFromString("18.63"), MarkupRate: decimal.RequireFromString("0.11")}
+	eurTry := Exchange{FromCurrencyCode: "EUR", ToCurrencyCode: "TRY", ExchangeRate: decimal.RequireFromString("19.40"), MarkupRate: decimal.RequireFromString("0.12")}
+	mockExchangeRepository.EXPECT().ListExchanges().Return([]Exchange{usdTry, eurTry}, nil)
+	assert.Nil(t, rateStream.Poll())
+
+	waitForSubscriber := func() {
+		for i := 0; i < 100; i++ {
+			rateStream.mu.Lock()
+			subscribed := len(rateStream.subscriptions) > 0
+			rateStream.mu.Unlock()
+			if subscribed {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("stream did not subscribe")
+	}
+
+	t.Run("unknown currency in pairs", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/stream?pairs=USD/XXX", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("event stream sends snapshot and changes", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/exchange/stream?pairs=usd/try")
+		if err != nil {
+			t.Fatalf("Could not connect: %v\n", err.Error())
+		}
+		defer resp.Body.Close()
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(resp.Body)
+		readData := func() string {
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					t.Fatalf("Could not read event: %v\n", err.Error())
+				}
+				if strings.HasPrefix(line, "data:") {
+					return line
+				}
+			}
+		}
+
+		data := readData()
+		assert.Contains(t, data, `"exchange_rate":"18.52"`)
+		assert.NotContains(t, data, "markup")
+
+		waitForSubscriber()
+		changed := usdTry
+		changed.ExchangeRate = decimal.RequireFromString("18.70")
+		mockExchangeRepository.EXPECT().ListExchanges().Return([]Exchange{changed, eurTry}, nil)
+		assert.Nil(t, rateStream.Poll())
+		assert.Contains(t, readData(), `"exchange_rate":"18.59"`)
+	})
+
+	t.Run("websocket sends snapshot", func(t *testing.T) {
+		conn, err := websocket.Dial(strings.Replace(server.URL, "http", "ws", 1)+"/exchange/stream/ws?pairs=EUR/TRY", "", server.URL)
+		if err != nil {
+			t.Fatalf("Could not connect: %v\n", err.Error())
+		}
+		defer conn.Close()
+
+		var message RateStreamMessage
+		assert.Nil(t, websocket.JSON.Receive(conn, &message))
+		assert.Equal(t, "rate", message.Event)
+		assert.Equal(t, "EUR", message.Data.FromCurrencyCode)
+	})
+
+	t.Run("websocket from an allowed origin", func(t *testing.T) {
+		conn, err := websocket.Dial(strings.Replace(server.URL, "http", "ws", 1)+"/exchange/stream/ws", "", "https://APP.example.com")
+		if err != nil {
+			t.Fatalf("Could not connect: %v\n", err.Error())
+		}
+		conn.Close()
+	})
+
+	t.Run("websocket from another site", func(t *testing.T) {
+		_, err := websocket.Dial(strings.Replace(server.URL, "http", "ws", 1)+"/exchange/stream/ws", "", "https://evil.example.com")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package exchange
+
+import (
+	// Go imports
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateStream_Poll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	rateStream := NewRateStream(mockExchangeRepository, time.Second)
+	usdTry := Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.RequireFromString("18.63"), MarkupRate: decimal.RequireFromString("0.11")}
+	eurTry := Exchange{FromCurrencyCode: "EUR", ToCurrencyCode: "TRY", ExchangeRate: decimal.RequireFromString("19.40"), MarkupRate: decimal.RequireFromString("0.12")}
+
+	mockExchangeRepository.EXPECT().ListExchanges().Return([]Exchange{usdTry, eurTry}, nil)
+	assert.Nil(t, rateStream.Poll())
+
+	every := rateStream.Subscribe(nil)
+	usdTryOnly := rateStream.Subscribe(map[string]bool{"USD/TRY": true})
+	assert.Len(t, every.Snapshot(), 2)
+	assert.Len(t, usdTryOnly.Snapshot(), 1)
+
+	t.Run("only changed pairs are sent", func(t *testing.T) {
+		changed := eurTry
+		changed.ExchangeRate = decimal.RequireFromString("19.41")
+		mockExchangeRepository.EXPECT().ListExchanges().Return([]Exchange{usdTry, changed}, nil)
+		assert.Nil(t, rateStream.Poll())
+
+		update := <-every.Updates()
+		assert.Equal(t, "EUR", update.FromCurrencyCode)
+		assert.True(t, update.ExchangeRate.Equal(decimal.RequireFromString("19.29")))
+		assert.Len(t, every.Updates(), 0)
+		assert.Len(t, usdTryOnly.Updates(), 0)
+	})
+
+	t.Run("unsubscribed stream gets nothing", func(t *testing.T) {
+		rateStream.Unsubscribe(usdTryOnly)
+		_, ok := <-usdTryOnly.Updates()
+		assert.False(t, ok)
+	})
+
+	t.Run("subscriber falling behind is dropped", func(t *testing.T) {
+		for i := 0; i <= rateSubscriptionBuffer; i++ {
+			changed := usdTry
+			changed.ExchangeRate = usdTry.ExchangeRate.Add(decimal.NewFromInt(int64(i + 1)))
+			mockExchangeRepository.EXPECT().ListExchanges().Return([]Exchange{changed}, nil)
+			assert.Nil(t, rateStream.Poll())
+		}
+
+		received := 0
+		for range every.Updates() {
+			received++
+		}
+		assert.Equal(t, rateSubscriptionBuffer, received)
+
+		// Unsubscribing a dropped subscriber is safe
+		rateStream.Unsubscribe(every)
+	})
+}
+
+func TestRateStream_Close(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rateStream := NewRateStream(NewMockIExchangeRepository(ctrl), time.Second)
+	subscription := rateStream.Subscribe(nil)
+
+	rateStream.Close()
+	_, ok := <-subscription.Updates()
+	assert.False(t, ok)
+
+	// Handlers still unsubscribe when their stream returns
+	rateStream.Unsubscribe(subscription)
+}
@@ -6,13 +6,40 @@ import (
 
 	// External imports
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IExchangeRepository interface {
 	GetExchangeRate(fromCurrency, toCurrency string) (*Exchange, error)
+	ListExchanges() ([]Exchange, error)
+	UpdateExchangeRate(fromCurrency, toCurrency string, exchangeRate decimal.Decimal) error
+	ListRateHistory(fromCurrency, toCurrency string, start, end time.Time) ([]RateHistory, error)
+	CreateRateHistory(history RateHistory) error
+	GetExchangeForUpdate(fromCurrency, toCurrency string) (*Exchange, error)
+	CreateExchange(exchange Exchange) error
+	UpdateExchange(exchange Exchange) error
+	DeleteExchange(fromCurrency, toCurrency string) error
+	CreateExchangeAudit(audit ExchangeAudit) error
+	ListExchangeAudits(fromCurrency, toCurrency string) ([]ExchangeAudit, error)
+	ListMarkupRules(fromCurrency, toCurrency string) ([]MarkupRule, error)
+	ReplaceMarkupRules(fromCurrency, toCurrency string, rules []MarkupRule) error
 	CreateOffer(offer Offer) (*Offer, error)
 	GetOffer(id uint) (*Offer, error)
+	GetOfferForUpdate(id uint) (*Offer, error)
+	UpdateOffer(offer Offer) error
+	ListUserOffers(filter OfferFilter) ([]Offer, error)
+	ListUserOffersByIds(userId uint, ids []uint) ([]Offer, error)
+	ExpireOffers(now int64) (int64, error)
+	ArchiveOffers(updatedBefore, archivedAt time.Time, limit int) (int, error)
+	CreateLimitOrder(order LimitOrder) (*LimitOrder, error)
+	GetLimitOrderForUpdate(id uint) (*LimitOrder, error)
+	UpdateLimitOrder(order LimitOrder) error
+	ListUserLimitOrders(userId uint, status LimitOrderStatus) ([]LimitOrder, error)
+	ListOpenLimitOrders() ([]LimitOrder, error)
+	ListExpiredLimitOrders(now time.Time) ([]LimitOrder, error)
+	WithTx(tx *gorm.DB) IExchangeRepository
 	Migration() error
 }
 
@@ -28,12 +55,128 @@ func NewExchangeRepository(db *gorm.DB) IExchangeRepository {
 
 func (r *exchangeRepository) GetExchangeRate(fromCurrency, toCurrency string) (*Exchange, error) {
 	var exchange *Exchange
-	if err := r.db.Debug().Where("from_currency_code =?", fromCurrency).Where("to_currency_code =?", toCurrency).First(&exchange).Error; err != nil {
+	if err := r.db.Debug().Where("from_currency_code =?", fromCurrency).Where("to_currency_code =?", toCurrency).Where("disabled =?", false).First(&exchange).Error; err != nil {
 		return nil, err
 	}
 	return exchange, nil
 }
 
+func (r *exchangeRepository) ListExchanges() ([]Exchange, error) {
+	var exchanges []Exchange
+	if err := r.db.Debug().Order("from_currency_code, to_currency_code").Find(&exchanges).Error; err != nil {
+		return nil, err
+	}
+	return exchanges, nil
+}
+
+// UpdateExchangeRate changes the rate of the pair and records the new rate on the rate history
+func (r *exchangeRepository) UpdateExchangeRate(fromCurrency, toCurrency string, exchangeRate decimal.Decimal) error {
+	return r.db.Debug().Transaction(func(tx *gorm.DB) error {
+		var exchange Exchange
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("from_currency_code =?", fromCurrency).
+			Where("to_currency_code =?", toCurrency).
+			First(&exchange).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&exchange).Updates(map[string]interface{}{"exchange_rate": exchangeRate, "updated_at": now}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&RateHistory{
+			FromCurrencyCode: fromCurrency,
+			ToCurrencyCode:   toCurrency,
+			ExchangeRate:     exchangeRate,
+			MarkupRate:       exchange.MarkupRate,
+			RecordedAt:       now,
+		}).Error
+	})
+}
+
+func (r *exchangeRepository) ListRateHistory(fromCurrency, toCurrency string, start, end time.Time) ([]RateHistory, error) {
+	var histories []RateHistory
+	if err := r.db.Debug().
+		Where("from_currency_code =?", fromCurrency).
+		Where("to_currency_code =?", toCurrency).
+		Where("recorded_at >=?", start).
+		Where("recorded_at <?", end).
+		Order("recorded_at, id").
+		Find(&histories).Error; err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+func (r *exchangeRepository) CreateRateHistory(history RateHistory) error {
+	return r.db.Debug().Create(&history).Error
+}
+
+// GetExchangeForUpdate returns the pair even if it is disabled and locks it until the surrounding transaction ends
+func (r *exchangeRepository) GetExchangeForUpdate(fromCurrency, toCurrency string) (*Exchange, error) {
+	var exchange *Exchange
+	if err := r.db.Debug().Clauses(clause.Locking{Strength: "UPDATE"}).Where("from_currency_code =?", fromCurrency).Where("to_currency_code =?", toCurrency).First(&exchange).Error; err != nil {
+		return nil, err
+	}
+	return exchange, nil
+}
+
+func (r *exchangeRepository) CreateExchange(exchange Exchange) error {
+	return r.db.Debug().Create(&exchange).Error
+}
+
+func (r *exchangeRepository) UpdateExchange(exchange Exchange) error {
+	return r.db.Debug().Save(&exchange).Error
+}
+
+// DeleteExchange removes the pair for good so it can be created again, its audits and rate history are kept
+func (r *exchangeRepository) DeleteExchange(fromCurrency, toCurrency string) error {
+	return r.db.Debug().Unscoped().Where("from_currency_code =?", fromCurrency).Where("to_currency_code =?", toCurrency).Delete(&Exchange{}).Error
+}
+
+func (r *exchangeRepository) CreateExchangeAudit(audit ExchangeAudit) error {
+	return r.db.Debug().Create(&audit).Error
+}
+
+func (r *exchangeRepository) ListExchangeAudits(fromCurrency, toCurrency string) ([]ExchangeAudit, error) {
+	query := r.db.Debug().Model(&ExchangeAudit{})
+	if fromCurrency != "" {
+		query = query.Where("from_currency_code =?", fromCurrency)
+	}
+
+	if toCurrency != "" {
+		query = query.Where("to_currency_code =?", toCurrency)
+	}
+
+	var audits []ExchangeAudit
+	if err := query.Order("id DESC").Find(&audits).Error; err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+func (r *exchangeRepository) ListMarkupRules(fromCurrency, toCurrency string) ([]MarkupRule, error) {
+	var rules []MarkupRule
+	if err := r.db.Debug().Where("from_currency_code =?", fromCurrency).Where("to_currency_code =?", toCurrency).Order("segment, min_amount").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceMarkupRules has to run inside a transaction, the rules of the pair are deleted before the new ones are created
+func (r *exchangeRepository) ReplaceMarkupRules(fromCurrency, toCurrency string, rules []MarkupRule) error {
+	if err := r.db.Debug().Where("from_currency_code =?", fromCurrency).Where("to_currency_code =?", toCurrency).Delete(&MarkupRule{}).Error; err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return r.db.Debug().Create(&rules).Error
+}
+
 func (r *exchangeRepository) CreateOffer(offer Offer) (*Offer, error) {
 	if err := r.db.Debug().Create(&offer).Error; err != nil {
 		return nil, err
@@ -49,8 +192,177 @@ func (r *exchangeRepository) GetOffer(id uint) (*Offer, error) {
 	return offer, nil
 }
 
+func (r *exchangeRepository) GetOfferForUpdate(id uint) (*Offer, error) {
+	var offer *Offer
+	if err := r.db.Debug().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id =?", id).First(&offer).Error; err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
+
+func (r *exchangeRepository) UpdateOffer(offer Offer) error {
+	return r.db.Debug().Save(&offer).Error
+}
+
+func (r *exchangeRepository) ListUserOffers(filter OfferFilter) ([]Offer, error) {
+	query := r.db.Debug().Where("user_id =?", filter.UserId)
+
+	switch filter.Status {
+	case "":
+	case OfferStatusPending:
+		query = query.Where("status =? AND expires_at >=?", OfferStatusPending, filter.Now)
+	case OfferStatusExpired:
+		query = query.Where("status =? OR (status =? AND expires_at <?)", OfferStatusExpired, OfferStatusPending, filter.Now)
+	default:
+		query = query.Where("status =?", filter.Status)
+	}
+
+	if filter.FromCurrencyCode != "" {
+		query = query.Where("from_currency_code =?", filter.FromCurrencyCode)
+	}
+
+	if filter.ToCurrencyCode != "" {
+		query = query.Where("to_currency_code =?", filter.ToCurrencyCode)
+	}
+
+	if filter.Start != nil {
+		query = query.Where("created_at >=?", *filter.Start)
+	}
+
+	if filter.End != nil {
+		query = query.Where("created_at <?", *filter.End)
+	}
+
+	if filter.BeforeId > 0 {
+		query = query.Where("id <?", filter.BeforeId)
+	}
+
+	var offers []Offer
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&offers).Error; err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+// ListUserOffersByIds finds the offers of the user with the given ids, archived offers are read from the offer history
+func (r *exchangeRepository) ListUserOffersByIds(userId uint, ids []uint) ([]Offer, error) {
+	var offers []Offer
+	if err := r.db.Debug().Unscoped().Where("user_id =? AND id IN ?", userId, ids).Order("id").Find(&offers).Error; err != nil {
+		return nil, err
+	}
+
+	if len(offers) == len(ids) {
+		return offers, nil
+	}
+
+	var histories []OfferHistory
+	if err := r.db.Debug().Unscoped().Where("user_id =? AND id IN ?", userId, ids).Order("id").Find(&histories).Error; err != nil {
+		return nil, err
+	}
+
+	for _, history := range histories {
+		offers = append(offers, history.Offer)
+	}
+	return offers, nil
+}
+
+// ExpireOffers marks the pending offers past their expiry as expired and returns how many were marked
+func (r *exchangeRepository) ExpireOffers(now int64) (int64, error) {
+	result := r.db.Debug().Model(&Offer{}).
+		Where("status =? AND expires_at <?", OfferStatusPending, now).
+		Update("status", OfferStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+// ArchiveOffers moves up to limit closed offers last changed before the given time to the offer history as archived at archivedAt,
+// it has to run inside a transaction so an offer is never on both tables or on neither
+func (r *exchangeRepository) ArchiveOffers(updatedBefore, archivedAt time.Time, limit int) (int, error) {
+	var offers []Offer
+	if err := r.db.Debug().Unscoped().
+		Where("status IN ? AND updated_at <?", []OfferStatus{OfferStatusAccepted, OfferStatusExpired, OfferStatusCancelled}, updatedBefore).
+		Order("id").Limit(limit).Find(&offers).Error; err != nil {
+		return 0, err
+	}
+
+	if len(offers) == 0 {
+		return 0, nil
+	}
+
+	histories := make([]OfferHistory, 0, len(offers))
+	offerIds := make([]uint, 0, len(offers))
+	for _, offer := range offers {
+		histories = append(histories, OfferHistory{Offer: offer, ArchivedAt: archivedAt})
+		offerIds = append(offerIds, offer.Id)
+	}
+
+	if err := r.db.Debug().Create(&histories).Error; err != nil {
+		return 0, err
+	}
+
+	if err := r.db.Debug().Unscoped().Where("id IN ?", offerIds).Delete(&Offer{}).Error; err != nil {
+		return 0, err
+	}
+
+	return len(offers), nil
+}
+
+func (r *exchangeRepository) CreateLimitOrder(order LimitOrder) (*LimitOrder, error) {
+	if err := r.db.Debug().Create(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *exchangeRepository) GetLimitOrderForUpdate(id uint) (*LimitOrder, error) {
+	var order *LimitOrder
+	if err := r.db.Debug().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id =?", id).First(&order).Error; err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *exchangeRepository) UpdateLimitOrder(order LimitOrder) error {
+	return r.db.Debug().Save(&order).Error
+}
+
+// ListUserLimitOrders returns the user's orders newest first, every status when status is empty
+func (r *exchangeRepository) ListUserLimitOrders(userId uint, status LimitOrderStatus) ([]LimitOrder, error) {
+	query := r.db.Debug().Where("user_id =?", userId)
+	if status != "" {
+		query = query.Where("status =?", status)
+	}
+
+	var orders []LimitOrder
+	if err := query.Order("id DESC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// ListOpenLimitOrders returns the open orders oldest first, so older orders are filled first
+func (r *exchangeRepository) ListOpenLimitOrders() ([]LimitOrder, error) {
+	var orders []LimitOrder
+	if err := r.db.Debug().Where("status =?", LimitOrderStatusOpen).Order("id").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// ListExpiredLimitOrders returns the open orders which are past their expiry
+func (r *exchangeRepository) ListExpiredLimitOrders(now time.Time) ([]LimitOrder, error) {
+	var orders []LimitOrder
+	if err := r.db.Debug().Where("status =? AND expires_at <=?", LimitOrderStatusOpen, now).Order("id").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *exchangeRepository) WithTx(tx *gorm.DB) IExchangeRepository {
+	return NewExchangeRepository(tx)
+}
+
 func (r *exchangeRepository) Migration() error {
-	if err := r.db.AutoMigrate(Offer{}); err != nil {
+	if err := r.db.AutoMigrate(Offer{}, OfferHistory{}, RateHistory{}, ExchangeAudit{}, MarkupRule{}, LimitOrder{}); err != nil {
 		return err
 	}
 
@@ -61,48 +373,48 @@ func (r *exchangeRepository) Migration() error {
 				Exchange{
 					FromCurrencyCode: "TRY",
 					ToCurrencyCode:   "USD",
-					ExchangeRate:     0.054,
-					MarkupRate:       0.009,
+					ExchangeRate:     decimal.RequireFromString("0.054"),
+					MarkupRate:       decimal.RequireFromString("0.009"),
 					CreatedAt:        time.Now(),
 					UpdatedAt:        time.Now(),
 				},
 				Exchange{
 					FromCurrencyCode: "USD",
 					ToCurrencyCode:   "TRY",
-					ExchangeRate:     18.63,
-					MarkupRate:       0.3,
+					ExchangeRate:     decimal.RequireFromString("18.63"),
+					MarkupRate:       decimal.RequireFromString("0.3"),
 					CreatedAt:        time.Now(),
 					UpdatedAt:        time.Now(),
 				},
 				Exchange{
 					FromCurrencyCode: "USD",
 					ToCurrencyCode:   "EUR",
-					ExchangeRate:     0.96,
-					MarkupRate:       0.3,
+					ExchangeRate:     decimal.RequireFromString("0.96"),
+					MarkupRate:       decimal.RequireFromString("0.3"),
 					CreatedAt:        time.Now(),
 					UpdatedAt:        time.Now(),
 				},
 				Exchange{
 					FromCurrencyCode: "EUR",
 					ToCurrencyCode:   "USD",
-					ExchangeRate:     1.04,
-					MarkupRate:       0.2,
+					ExchangeRate:     decimal.RequireFromString("1.04"),
+					MarkupRate:       decimal.RequireFromString("0.2"),
 					CreatedAt:        time.Now(),
 					UpdatedAt:        time.Now(),
 				},
 				Exchange{
 					FromCurrencyCode: "TRY",
 					ToCurrencyCode:   "EUR",
-					ExchangeRate:     0.052,
-					MarkupRate:       0.007,
+					ExchangeRate:     decimal.RequireFromString("0.052"),
+					MarkupRate:       decimal.RequireFromString("0.007"),
 					CreatedAt:        time.Now(),
 					UpdatedAt:        time.Now(),
 				},
 				Exchange{
 					FromCurrencyCode: "EUR",
 					ToCurrencyCode:   "TRY",
-					ExchangeRate:     19.36,
-					MarkupRate:       0.2,
+					ExchangeRate:     decimal.RequireFromString("19.36"),
+					MarkupRate:       decimal.RequireFromString("0.2"),
 					CreatedAt:        time.Now(),
 					UpdatedAt:        time.Now(),
 				},
@@ -110,6 +422,21 @@ func (r *exchangeRepository) Migration() error {
 			if err = r.db.Create(exchanges).Error; err != nil {
 				return err
 			}
+
+			// Seeded rates are the first records of the rate history
+			var histories []RateHistory
+			for _, exchange := range exchanges {
+				histories = append(histories, RateHistory{
+					FromCurrencyCode: exchange.FromCurrencyCode,
+					ToCurrencyCode:   exchange.ToCurrencyCode,
+					ExchangeRate:     exchange.ExchangeRate,
+					MarkupRate:       exchange.MarkupRate,
+					RecordedAt:       exchange.CreatedAt,
+				})
+			}
+			if err = r.db.Create(histories).Error; err != nil {
+				return err
+			}
 		}
 
 	}
@@ -4,6 +4,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"regexp"
 	"testing"
@@ -18,18 +19,19 @@ func TestExchangeRepository_CreateOffer(t *testing.T) {
 		Id:               uint(uuid.New().ID()),
 		FromCurrencyCode: "USD",
 		ToCurrencyCode:   "TRY",
-		ExchangeRate:     18.63,
+		ExchangeRate:     decimal.RequireFromString("18.63"),
 		ExpiresAt:        1669602327,
 		UserId:           1,
+		Status:           OfferStatusPending,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(
-		regexp.QuoteMeta(` INSERT INTO "offers" ("from_currency_code","to_currency_code","exchange_rate","expires_at","user_id","created_at","updated_at","deleted_at","id") 
- 							VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
-		WithArgs(o.FromCurrencyCode, o.ToCurrencyCode, o.ExchangeRate, o.ExpiresAt, o.UserId, o.CreatedAt, o.UpdatedAt, nil, o.Id).
+		regexp.QuoteMeta(` INSERT INTO "offers" ("from_currency_code","to_currency_code","exchange_rate","expires_at","user_id","status","min_amount","sell_amount","buy_amount","fixed_fee","accepted_amount","accepted_at","created_at","updated_at","deleted_at","id") 
+ 							VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16) RETURNING "id"`)).
+		WithArgs(o.FromCurrencyCode, o.ToCurrencyCode, o.ExchangeRate, o.ExpiresAt, o.UserId, o.Status, nil, nil, nil, nil, nil, nil, o.CreatedAt, o.UpdatedAt, nil, o.Id).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "from_currency_code", "to_currency_code", "exchange_rate", "created_at", "updated_at"}).
 				AddRow(o.Id, o.FromCurrencyCode, o.ToCurrencyCode, o.ExchangeRate, o.CreatedAt, o.UpdatedAt))
@@ -53,8 +55,8 @@ func TestExchangeRepository_GetExchangeRate(t *testing.T) {
 	expectedExchange := &Exchange{
 		FromCurrencyCode: "TRY",
 		ToCurrencyCode:   "USD",
-		ExchangeRate:     18.63,
-		MarkupRate:       0.03,
+		ExchangeRate:     decimal.RequireFromString("18.63"),
+		MarkupRate:       decimal.RequireFromString("0.03"),
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
@@ -64,9 +66,9 @@ func TestExchangeRepository_GetExchangeRate(t *testing.T) {
 		AddRow(expectedExchange.FromCurrencyCode, expectedExchange.ToCurrencyCode,
 			expectedExchange.ExchangeRate, expectedExchange.MarkupRate, expectedExchange.CreatedAt, expectedExchange.UpdatedAt)
 
-	sqlSelectOne := `SELECT * FROM "exchanges" WHERE from_currency_code =$1 AND to_currency_code =$2 AND "exchanges"."deleted_at" IS NULL ORDER BY "exchanges"."from_currency_code" LIMIT 1`
+	sqlSelectOne := `SELECT * FROM "exchanges" WHERE from_currency_code =$1 AND to_currency_code =$2 AND disabled =$3 AND "exchanges"."deleted_at" IS NULL ORDER BY "exchanges"."from_currency_code" LIMIT 1`
 
-	mock.ExpectQuery(regexp.QuoteMeta(sqlSelectOne)).WithArgs(expectedExchange.FromCurrencyCode, expectedExchange.ToCurrencyCode).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(sqlSelectOne)).WithArgs(expectedExchange.FromCurrencyCode, expectedExchange.ToCurrencyCode, false).WillReturnRows(rows)
 
 	dbExchange, err := r.GetExchangeRate(expectedExchange.FromCurrencyCode, expectedExchange.ToCurrencyCode)
 	assert.Nil(t, err)
@@ -81,7 +83,7 @@ func TestExchangeRepository_GetOffer(t *testing.T) {
 		Id:               uint(uuid.New().ID()),
 		FromCurrencyCode: "USD",
 		ToCurrencyCode:   "TRY",
-		ExchangeRate:     18.63,
+		ExchangeRate:     decimal.RequireFromString("18.63"),
 		ExpiresAt:        1669602327,
 		UserId:           1,
 		CreatedAt:        time.Now(),
@@ -100,3 +102,293 @@ func TestExchangeRepository_GetOffer(t *testing.T) {
 	assert.Nil(t, mock.ExpectationsWereMet())
 	assert.Equal(t, expectedOffer, dbOffer)
 }
+
+func TestExchangeRepository_GetOfferForUpdate(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+	offerId := uint(7)
+
+	rows := sqlmock.
+		NewRows([]string{"id", "from_currency_code", "to_currency_code", "exchange_rate", "expires_at", "user_id"}).
+		AddRow(offerId, "USD", "TRY", "18.63", 1669602327, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "offers" WHERE id =$1 AND "offers"."deleted_at" IS NULL ORDER BY "offers"."id" LIMIT 1 FOR UPDATE`)).
+		WithArgs(offerId).WillReturnRows(rows)
+
+	dbOffer, err := r.GetOfferForUpdate(offerId)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, offerId, dbOffer.Id)
+}
+
+func TestExchangeRepository_UpdateOffer(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+	acceptedAt := time.Now()
+	o := Offer{
+		Id:               uint(3),
+		FromCurrencyCode: "USD",
+		ToCurrencyCode:   "TRY",
+		ExchangeRate:     decimal.RequireFromString("18.63"),
+		ExpiresAt:        1669602327,
+		UserId:           1,
+		Status:           OfferStatusAccepted,
+		AcceptedAmount:   decimal.NewNullDecimal(decimal.RequireFromString("100.00")),
+		AcceptedAt:       &acceptedAt,
+		CreatedAt:        acceptedAt,
+		UpdatedAt:        acceptedAt,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "offers" SET "from_currency_code"=$1,"to_currency_code"=$2,"exchange_rate"=$3,"expires_at"=$4,"user_id"=$5,"status"=$6,"min_amount"=$7,"sell_amount"=$8,"buy_amount"=$9,"fixed_fee"=$10,"accepted_amount"=$11,"accepted_at"=$12,"created_at"=$13,"updated_at"=$14,"deleted_at"=$15 WHERE "offers"."deleted_at" IS NULL AND "id" = $16`)).
+		WithArgs(o.FromCurrencyCode, o.ToCurrencyCode, o.ExchangeRate, o.ExpiresAt, o.UserId, o.Status, o.MinAmount, o.SellAmount, o.BuyAmount, o.FixedFee, o.AcceptedAmount, acceptedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, o.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := r.UpdateOffer(o)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRepository_ListExchanges(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	rows := sqlmock.
+		NewRows([]string{"from_currency_code", "to_currency_code", "exchange_rate", "markup_rate"}).
+		AddRow("TRY", "USD", "0.054", "0.009").
+		AddRow("USD", "TRY", "18.63", "0.3")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchanges" WHERE "exchanges"."deleted_at" IS NULL ORDER BY from_currency_code, to_currency_code`)).
+		WillReturnRows(rows)
+
+	exchanges, err := r.ListExchanges()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, exchanges, 2)
+	assert.True(t, exchanges[1].ExchangeRate.Equal(decimal.RequireFromString("18.63")))
+}
+
+func TestExchangeRepository_UpdateExchangeRate(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+	exchangeRate := decimal.RequireFromString("18.7")
+	markupRate := decimal.RequireFromString("0.3")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchanges" WHERE from_currency_code =$1 AND to_currency_code =$2 AND "exchanges"."deleted_at" IS NULL ORDER BY "exchanges"."from_currency_code" LIMIT 1 FOR UPDATE`)).
+		WithArgs("USD", "TRY").
+		WillReturnRows(sqlmock.NewRows([]string{"from_currency_code", "to_currency_code", "exchange_rate", "markup_rate"}).
+			AddRow("USD", "TRY", "18.63", markupRate))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "exchanges" SET "exchange_rate"=$1,"updated_at"=$2 WHERE "exchanges"."deleted_at" IS NULL AND "from_currency_code" = $3 AND "to_currency_code" = $4`)).
+		WithArgs(exchangeRate, sqlmock.AnyArg(), "USD", "TRY").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "rate_histories" ("from_currency_code","to_currency_code","exchange_rate","markup_rate","recorded_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
+		WithArgs("USD", "TRY", exchangeRate, markupRate, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := r.UpdateExchangeRate("USD", "TRY", exchangeRate)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRepository_ListRateHistory(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+	end := time.Date(2022, 12, 2, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-24 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "rate_histories" WHERE from_currency_code =$1 AND to_currency_code =$2 AND recorded_at >=$3 AND recorded_at <$4 ORDER BY recorded_at, id`)).
+		WithArgs("USD", "TRY", start, end).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "from_currency_code", "to_currency_code", "exchange_rate", "markup_rate", "recorded_at"}).
+			AddRow(1, "USD", "TRY", "18.63", "0.3", start.Add(time.Hour)).
+			AddRow(2, "USD", "TRY", "18.7", "0.3", start.Add(2*time.Hour)))
+
+	histories, err := r.ListRateHistory("USD", "TRY", start, end)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, histories, 2)
+	assert.True(t, histories[1].ExchangeRate.Equal(decimal.RequireFromString("18.7")))
+}
+
+func TestExchangeRepository_GetExchangeForUpdate(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchanges" WHERE from_currency_code =$1 AND to_currency_code =$2 AND "exchanges"."deleted_at" IS NULL ORDER BY "exchanges"."from_currency_code" LIMIT 1 FOR UPDATE`)).
+		WithArgs("USD", "TRY").
+		WillReturnRows(sqlmock.NewRows([]string{"from_currency_code", "to_currency_code", "exchange_rate", "markup_rate", "disabled"}).
+			AddRow("USD", "TRY", "18.63", "0.3", true))
+
+	exchange, err := r.GetExchangeForUpdate("USD", "TRY")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.True(t, exchange.Disabled)
+}
+
+func TestExchangeRepository_DeleteExchange(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "exchanges" WHERE from_currency_code =$1 AND to_currency_code =$2`)).
+		WithArgs("USD", "TRY").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := r.DeleteExchange("USD", "TRY")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRepository_ListExchangeAudits(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_audits" WHERE from_currency_code =$1 ORDER BY id DESC`)).
+		WithArgs("USD").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "admin_user_id", "action", "from_currency_code", "to_currency_code"}).
+			AddRow(2, 9, PairAuditActionDelete, "USD", "TRY"))
+
+	audits, err := r.ListExchangeAudits("USD", "")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, audits, 1)
+	assert.Equal(t, PairAuditActionDelete, audits[0].Action)
+}
+
+func TestExchangeRepository_ListMarkupRules(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "markup_rules" WHERE from_currency_code =$1 AND to_currency_code =$2 ORDER BY segment, min_amount`)).
+		WithArgs("USD", "TRY").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "from_currency_code", "to_currency_code", "segment", "type", "value", "min_amount"}).
+			AddRow(1, "USD", "TRY", "", MarkupTypeBasisPoints, "50", "0"))
+
+	rules, err := r.ListMarkupRules("USD", "TRY")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, rules, 1)
+	assert.Equal(t, MarkupTypeBasisPoints, rules[0].Type)
+}
+
+func TestExchangeRepository_ReplaceMarkupRules(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "markup_rules" WHERE from_currency_code =$1 AND to_currency_code =$2`)).
+		WithArgs("USD", "TRY").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := r.ReplaceMarkupRules("USD", "TRY", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRepository_ListUserLimitOrders(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "limit_orders" WHERE user_id =$1 AND status =$2 ORDER BY id DESC`)).
+		WithArgs(1, LimitOrderStatusOpen).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "from_currency_code", "to_currency_code", "amount", "limit_rate", "status"}).
+			AddRow(2, 1, "USD", "TRY", "100", "19.1", LimitOrderStatusOpen))
+
+	orders, err := r.ListUserLimitOrders(1, LimitOrderStatusOpen)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, orders, 1)
+	assert.Equal(t, uint(2), orders[0].Id)
+}
+
+func TestExchangeRepository_ListOpenLimitOrders(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "limit_orders" WHERE status =$1 ORDER BY id`)).
+		WithArgs(LimitOrderStatusOpen).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(2, LimitOrderStatusOpen).AddRow(3, LimitOrderStatusOpen))
+
+	orders, err := r.ListOpenLimitOrders()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, orders, 2)
+}
+
+func TestExchangeRepository_ListUserOffers(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+	now := time.Now().Unix()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "offers" WHERE user_id =$1 AND (status =$2 OR (status =$3 AND expires_at <$4)) AND from_currency_code =$5 AND id <$6 AND "offers"."deleted_at" IS NULL ORDER BY id DESC LIMIT 21`)).
+		WithArgs(1, OfferStatusExpired, OfferStatusPending, now, "USD", 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(8, OfferStatusPending))
+
+	offers, err := r.ListUserOffers(OfferFilter{UserId: 1, Status: OfferStatusExpired, FromCurrencyCode: "USD", Now: now, BeforeId: 9, Limit: 21})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, offers, 1)
+}
+
+func TestExchangeRepository_ListUserOffersByIds(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "offers" WHERE user_id =$1 AND id IN ($2,$3) ORDER BY id`)).
+		WithArgs(1, 4, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(7, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "offer_histories" WHERE user_id =$1 AND id IN ($2,$3) ORDER BY id`)).
+		WithArgs(1, 4, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(4, 1))
+
+	offers, err := r.ListUserOffersByIds(1, []uint{4, 7})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, offers, 2)
+	assert.Equal(t, uint(4), offers[1].Id)
+}
+
+func TestExchangeRepository_ExpireOffers(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+	now := time.Now().Unix()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "offers" SET "status"=$1,"updated_at"=$2 WHERE (status =$3 AND expires_at <$4) AND "offers"."deleted_at" IS NULL`)).
+		WithArgs(OfferStatusExpired, sqlmock.AnyArg(), OfferStatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	expired, err := r.ExpireOffers(now)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, int64(3), expired)
+}
+
+func TestExchangeRepository_ArchiveOffers(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewExchangeRepository(db)
+	updatedBefore := time.Now().Add(-DefaultOfferRetention)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "offers" WHERE status IN ($1,$2,$3) AND updated_at <$4 ORDER BY id LIMIT 2`)).
+		WithArgs(OfferStatusAccepted, OfferStatusExpired, OfferStatusCancelled, updatedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, OfferStatusAccepted).AddRow(7, OfferStatusExpired))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "offer_histories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(7))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "offers" WHERE id IN ($1,$2)`)).
+		WithArgs(4, 7).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	archived, err := r.ArchiveOffers(updatedBefore, time.Now(), 2)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, 2, archived)
+}
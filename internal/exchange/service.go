@@ -7,25 +7,81 @@ import (
 	"strings"
 	"time"
 
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
 	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+const (
+	defaultRateHistoryInterval = time.Hour
+	defaultRateHistoryRange    = 24 * time.Hour
+	minRateHistoryInterval     = time.Minute
+	maxRateHistoryBuckets      = 1000
+
+	// DefaultOfferTTL is used when neither the pair nor the config has an offer TTL
+	DefaultOfferTTL = 3 * time.Minute
+)
+
+var (
+	ErrOfferAlreadyUsed        = errors.New("offer has already been used")
+	ErrOfferExpired            = errors.New("offer has expired")
+	ErrOfferCancelled          = errors.New("offer has been cancelled")
+	ErrInvalidRateHistoryQuery = errors.New("invalid rate history query")
+	ErrAmountBelowMarkupTier   = errors.New("amount is below the markup tier of the offer")
+	ErrInvalidOfferAmount      = errors.New("invalid offer amount")
+	ErrOfferAmountMismatch     = errors.New("amount does not match the locked amount of the offer")
 )
 
 type IExchangeService interface {
 	GetExchangeRateOffer(userId uint, request OfferRequest) (*OfferResponse, error)
 	AcceptExchangeRateOffer(userId uint, request AcceptOfferRequest) ([]account.WalletAccount, error)
-	CreateExchangeRateOffer(userId uint, fromCurrencyCode, toCurrencyCode string, exchangeRate float64) (uint, error)
+	CreateExchangeRateOffer(userId uint, fromCurrencyCode, toCurrencyCode string, exchangeRate decimal.Decimal) (uint, error)
+	GetExchangeRateHistory(request RateHistoryRequest) (*RateHistoryResponse, error)
+	PlaceLimitOrder(userId uint, request LimitOrderRequest) (*LimitOrderResponse, error)
+	ListLimitOrders(userId uint, request LimitOrderListRequest) ([]LimitOrderResponse, error)
+	CancelLimitOrder(userId, limitOrderId uint) (*LimitOrderResponse, error)
+	MatchLimitOrders() error
+	ListOffers(userId uint, request OfferListRequest) (*OfferListResponse, error)
+	GetUserOffer(userId, offerId uint) (*OfferDetailResponse, error)
+	CancelOffer(userId, offerId uint) (*OfferDetailResponse, error)
+	GetUserConversionOffers(userId uint, offerIds []uint) ([]account.ConversionOffer, error)
+	SweepOffers(retention time.Duration) (*SweepStats, error)
+	WithTx(tx *gorm.DB) IExchangeService
 }
 
 type exchangeService struct {
 	exchangeRepo    IExchangeRepository
 	currencyService currency.Service
 	accountService  account.IAccountService
+	segmentResolver IUserSegmentResolver
+	unitOfWork      uow.IUnitOfWork
+	config          config.Config
+	clock           clock.IClock
+}
+
+func NewExchangeService(exchangeRepository IExchangeRepository, currencyService currency.Service, accountService account.IAccountService, segmentResolver IUserSegmentResolver, unitOfWork uow.IUnitOfWork, config config.Config, clock clock.IClock) IExchangeService {
+	return &exchangeService{exchangeRepo: exchangeRepository, currencyService: currencyService, accountService: accountService, segmentResolver: segmentResolver, unitOfWork: unitOfWork, config: config, clock: clock}
 }
 
-func NewExchangeService(exchangeRepository IExchangeRepository, currencyService currency.Service, accountService account.IAccountService) IExchangeService {
-	return &exchangeService{exchangeRepo: exchangeRepository, currencyService: currencyService, accountService: accountService}
+// WithTx quotes and accepts offers inside the given transaction, e.g. a conversion which is a part of a transfer
+func (s *exchangeService) WithTx(tx *gorm.DB) IExchangeService {
+	return &exchangeService{
+		exchangeRepo:    s.exchangeRepo.WithTx(tx),
+		currencyService: s.currencyService,
+		accountService:  s.accountService.WithTx(tx),
+		segmentResolver: s.segmentResolver,
+		unitOfWork:      uow.NewUnitOfWork(tx),
+		config:          s.config,
+		clock:           s.clock,
+	}
 }
 
 func (s *exchangeService) GetExchangeRateOffer(userId uint, request OfferRequest) (*OfferResponse, error) {
@@ -43,42 +99,162 @@ func (s *exchangeService) GetExchangeRateOffer(userId uint, request OfferRequest
 	}
 
 	if ok := s.accountService.IsUserHasAccountOnGivenCurrency(userId, toCurrencyCode); !ok {
-		if _, err := s.accountService.CreateUserAccount(userId, toCurrencyCode, false); err != nil {
+		if _, err := s.accountService.CreateUserAccount(userId, toCurrencyCode); err != nil {
 			return nil, err
 		}
 	}
 
-	exchange, err := s.exchangeRepo.GetExchangeRate(fromCurrencyCode, toCurrencyCode)
+	sellAmount := currency.Round(request.SellAmount, fromCurrencyCode)
+	buyAmount := currency.Round(request.BuyAmount, toCurrencyCode)
+	if sellAmount.IsNegative() || buyAmount.IsNegative() {
+		return nil, fmt.Errorf("%w: amounts can not be negative", ErrInvalidOfferAmount)
+	}
+
+	if sellAmount.IsPositive() && buyAmount.IsPositive() {
+		return nil, fmt.Errorf("%w: only one of sell and buy amounts can be given", ErrInvalidOfferAmount)
+	}
+
+	offer, response, err := s.quoteOffer(userId, fromCurrencyCode, toCurrencyCode, sellAmount, buyAmount)
 	if err != nil {
 		return nil, err
 	}
 
-	exchangeRateWithMarkupRate := exchange.ExchangeRate - exchange.MarkupRate
-	offerId, err := s.CreateExchangeRateOffer(userId, fromCurrencyCode, toCurrencyCode, exchangeRateWithMarkupRate)
+	createdOffer, err := s.exchangeRepo.CreateOffer(*offer)
 	if err != nil {
 		return nil, err
 	}
 
-	return &OfferResponse{
-		OfferId:          offerId,
-		FromCurrencyCode: exchange.FromCurrencyCode,
-		ToCurrencyCode:   exchange.ToCurrencyCode,
-		ExchangeRate:     exchangeRateWithMarkupRate,
-	}, nil
+	response.OfferId = createdOffer.Id
+	return response, nil
 }
 
-func (s *exchangeService) CreateExchangeRateOffer(userId uint, fromCurrencyCode, toCurrencyCode string, exchangeRate float64) (uint, error) {
-	offer := Offer{
+// quoteOffer prices a new offer on the current rates without storing it
+func (s *exchangeService) quoteOffer(userId uint, fromCurrencyCode, toCurrencyCode string, sellAmount, buyAmount decimal.Decimal) (*Offer, *OfferResponse, error) {
+	path, err := s.getRatePath(fromCurrencyCode, toCurrencyCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The markup tier of a buy side quote is selected by its amount at mid rate
+	tierAmount := sellAmount
+	if buyAmount.IsPositive() {
+		tierAmount = buyAmount.Div(path.MidRate())
+	}
+
+	markup, err := s.getMarkup(userId, fromCurrencyCode, toCurrencyCode, path, tierAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	midRate := currency.RoundRate(path.MidRate())
+	exchangeRateWithMarkupRate := currency.RoundRate(path.MidRate().Sub(markup.Rate))
+	if !exchangeRateWithMarkupRate.IsPositive() {
+		return nil, nil, fmt.Errorf("markup of %s/%s exceeds its exchange rate", fromCurrencyCode, toCurrencyCode)
+	}
+
+	offer := s.newOffer(userId, fromCurrencyCode, toCurrencyCode, exchangeRateWithMarkupRate, s.offerTTL(path))
+	if markup.MinAmount.IsPositive() {
+		offer.MinAmount = decimal.NewNullDecimal(markup.MinAmount)
+	}
+
+	fixedFee := currency.Round(path.FixedFee(), toCurrencyCode)
+	if fixedFee.IsPositive() {
+		offer.FixedFee = decimal.NewNullDecimal(fixedFee)
+	}
+
+	var quote *Quote
+	if sellAmount.IsPositive() || buyAmount.IsPositive() {
+		if sellAmount, buyAmount, err = lockOfferAmounts(fromCurrencyCode, toCurrencyCode, exchangeRateWithMarkupRate, fixedFee, sellAmount, buyAmount); err != nil {
+			return nil, nil, err
+		}
+		offer.SellAmount = decimal.NewNullDecimal(sellAmount)
+		offer.BuyAmount = decimal.NewNullDecimal(buyAmount)
+		quote = newQuote(toCurrencyCode, sellAmount, buyAmount, path.MidRate(), markup.Rate, fixedFee)
+	}
+
+	return &offer, &OfferResponse{
 		FromCurrencyCode: fromCurrencyCode,
 		ToCurrencyCode:   toCurrencyCode,
-		ExchangeRate:     exchangeRate,
-		ExpiresAt:        time.Now().Add(time.Minute * 3).Unix(),
-		UserId:           userId,
-		CreatedAt:        time.Now(),
-		UpdatedAt:        time.Now(),
+		ExchangeRate:     exchangeRateWithMarkupRate,
+		Path:             path.Currencies(),
+		MidRate:          midRate,
+		Markup:           markup,
+		SellAmount:       offer.SellAmount,
+		BuyAmount:        offer.BuyAmount,
+		FixedFee:         fixedFee,
+		Quote:            quote,
+		ExpiresAt:        time.Unix(offer.ExpiresAt, 0).UTC(),
+		ExpiresIn:        offer.ExpiresAt - offer.CreatedAt.Unix(),
+	}, nil
+}
+
+// offerTTL is the shortest offer TTL of the legs of the path, pairs without their own TTL use the configured one
+func (s *exchangeService) offerTTL(path ratePath) time.Duration {
+	if ttl := path.OfferTTL(); ttl > 0 {
+		return ttl
+	}
+
+	if s.config.OfferTTL > 0 {
+		return s.config.OfferTTL
+	}
+
+	return DefaultOfferTTL
+}
+
+// lockOfferAmounts computes the other leg of the given amount, the buy amount is net of the fixed fee.
+// A buy side amount is sold for the smallest amount in from currency which covers it and the fee,
+// so the user never receives less than asked for.
+func lockOfferAmounts(fromCurrencyCode, toCurrencyCode string, exchangeRate, fixedFee, sellAmount, buyAmount decimal.Decimal) (decimal.Decimal, decimal.Decimal, error) {
+	if buyAmount.IsPositive() {
+		sellAmount = currency.RoundUp(buyAmount.Add(fixedFee).Div(exchangeRate), fromCurrencyCode)
+	} else {
+		buyAmount = currency.Round(sellAmount.Mul(exchangeRate), toCurrencyCode).Sub(fixedFee)
+	}
+
+	if !sellAmount.IsPositive() || !buyAmount.IsPositive() {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("%w: amount is too small to convert", ErrInvalidOfferAmount)
+	}
+
+	return sellAmount, buyAmount, nil
+}
+
+// newQuote breaks the net amount down, rounding is whatever is left after the rounded gross amount,
+// markup cost and fee, so the figures always add up to the credited amount
+func newQuote(toCurrencyCode string, sellAmount, netAmount, midRate, markupRate, fixedFee decimal.Decimal) *Quote {
+	grossAmount := currency.Round(sellAmount.Mul(midRate), toCurrencyCode)
+	markupCost := currency.Round(sellAmount.Mul(markupRate), toCurrencyCode)
+	return &Quote{
+		GrossAmount: grossAmount,
+		MarkupCost:  markupCost,
+		FixedFee:    fixedFee,
+		Rounding:    grossAmount.Sub(markupCost).Sub(fixedFee).Sub(netAmount),
+		NetAmount:   netAmount,
+	}
+}
+
+// getMarkup applies the markup rules of the pair for the user's segment, pairs without rules use their markup rate
+func (s *exchangeService) getMarkup(userId uint, fromCurrencyCode, toCurrencyCode string, path ratePath, amount decimal.Decimal) (AppliedMarkup, error) {
+	rules, err := s.exchangeRepo.ListMarkupRules(fromCurrencyCode, toCurrencyCode)
+	if err != nil {
+		return AppliedMarkup{}, err
+	}
+
+	var segment string
+	if len(rules) > 0 {
+		if segment, err = s.segmentResolver.GetUserSegment(userId); err != nil {
+			return AppliedMarkup{}, err
+		}
 	}
 
-	createdOffer, err := s.exchangeRepo.CreateOffer(offer)
+	return newMarkupStrategy(rules, segment, path).Markup(path.MidRate(), amount), nil
+}
+
+func (s *exchangeService) getRatePath(fromCurrencyCode, toCurrencyCode string) (ratePath, error) {
+	return resolveRatePath(s.exchangeRepo, fromCurrencyCode, toCurrencyCode, strings.ToUpper(s.config.RatePivotCurrency))
+}
+
+func (s *exchangeService) CreateExchangeRateOffer(userId uint, fromCurrencyCode, toCurrencyCode string, exchangeRate decimal.Decimal) (uint, error) {
+	createdOffer, err := s.exchangeRepo.CreateOffer(s.newOffer(userId, fromCurrencyCode, toCurrencyCode, exchangeRate, s.offerTTL(ratePath{})))
 	if err != nil {
 		return 0, err
 	}
@@ -86,32 +262,57 @@ func (s *exchangeService) CreateExchangeRateOffer(userId uint, fromCurrencyCode,
 	return createdOffer.Id, nil
 }
 
+func (s *exchangeService) newOffer(userId uint, fromCurrencyCode, toCurrencyCode string, exchangeRate decimal.Decimal, ttl time.Duration) Offer {
+	now := s.clock.Now()
+	return Offer{
+		FromCurrencyCode: fromCurrencyCode,
+		ToCurrencyCode:   toCurrencyCode,
+		ExchangeRate:     exchangeRate,
+		ExpiresAt:        now.Add(ttl).Unix(),
+		UserId:           userId,
+		Status:           OfferStatusPending,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
 func (s *exchangeService) AcceptExchangeRateOffer(userId uint, request AcceptOfferRequest) ([]account.WalletAccount, error) {
+	// Offers of other users are not found either, so their ids are not revealed
 	offer, err := s.exchangeRepo.GetOffer(request.OfferId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && offer.UserId != userId) {
+		return nil, fmt.Errorf("%w: %d", ErrOfferNotFound, request.OfferId)
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	// Check offer for user
-	if offer.UserId != userId {
-		return nil, errors.New("exchange rate is not valid for this user")
+	// Check offer is valid
+	if err = checkOfferIsAcceptable(*offer, s.clock.Now()); err != nil {
+		return nil, err
 	}
 
-	// Check offer is valid
-	if offer.ExpiresAt < time.Now().Unix() {
-		return nil, errors.New("offer has expired")
+	amount := currency.Round(request.Amount, offer.FromCurrencyCode)
+	if offer.SellAmount.Valid {
+		if !amount.IsZero() && !amount.Equal(offer.SellAmount.Decimal) {
+			return nil, fmt.Errorf("%w: offer is quoted for %s %s", ErrOfferAmountMismatch, offer.SellAmount.Decimal.String(), offer.FromCurrencyCode)
+		}
+		amount = offer.SellAmount.Decimal
 	}
 
-	balance, err := s.accountService.GetUserBalanceOnGivenCurrencyAccount(userId, offer.FromCurrencyCode)
-	if err != nil {
-		return nil, err
+	if !amount.IsPositive() {
+		return nil, errors.New("amount must be positive")
 	}
 
-	if request.Amount > balance {
-		return nil, errors.New("not enough balance")
+	// A lower markup tier can not be used for an amount smaller than the tier it was quoted for
+	if offer.MinAmount.Valid && amount.LessThan(offer.MinAmount.Decimal) {
+		return nil, fmt.Errorf("%w: at least %s %s", ErrAmountBelowMarkupTier, offer.MinAmount.Decimal.String(), offer.FromCurrencyCode)
 	}
 
-	if err = s.updateUserBalances(userId, *offer, request.Amount); err != nil {
+	// Debit and credit are applied together or not at all
+	if err = s.unitOfWork.Do(func(tx *gorm.DB) error {
+		return s.acceptOffer(s.exchangeRepo.WithTx(tx), s.accountService.WithTx(tx), userId, offer.Id, amount, nil)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -123,26 +324,151 @@ func (s *exchangeService) AcceptExchangeRateOffer(userId uint, request AcceptOff
 	return accountsWithBalances, nil
 }
 
-func (s *exchangeService) updateUserBalances(userId uint, offer Offer, amount float64) error {
-	// Calculate from currency balance
-	fromCurrencyCode, fromBalance := s.calculateFromBalanceAfterAcceptedCurrencyConversion(offer, amount)
-	if err := s.accountService.UpdateUserBalanceOnGivenCurrencyAccount(userId, fromCurrencyCode, fromBalance); err != nil {
+// acceptOffer converts the amount on the offer, it has to run inside a transaction. The amount is spent from the
+// available balance, or captured from the given hold which already set it aside.
+func (s *exchangeService) acceptOffer(txExchangeRepo IExchangeRepository, txAccountService account.IAccountService, userId, offerId uint, amount decimal.Decimal, holdId *uint) error {
+	// Lock the offer so concurrent accepts of the same offer are serialized
+	lockedOffer, err := txExchangeRepo.GetOfferForUpdate(offerId)
+	if err != nil {
+		return err
+	}
+
+	// Another request may have consumed the offer while we were waiting for the lock
+	if err = checkOfferIsAcceptable(*lockedOffer, s.clock.Now()); err != nil {
 		return err
 	}
 
-	// Calculate to currency balance
-	toCurrencyCode, toBalance := s.calculateToBalanceAfterAcceptedCurrencyConversion(offer, amount)
-	if err := s.accountService.UpdateUserBalanceOnGivenCurrencyAccount(userId, toCurrencyCode, toBalance); err != nil {
+	balances, err := txAccountService.LockUserAccounts(userId, lockedOffer.FromCurrencyCode, lockedOffer.ToCurrencyCode)
+	if err != nil {
 		return err
 	}
 
+	if holdId == nil && amount.GreaterThan(balances[lockedOffer.FromCurrencyCode]) {
+		return account.ErrNotEnoughBalance
+	}
+
+	if err = s.updateUserBalances(txAccountService, userId, *lockedOffer, amount, holdId); err != nil {
+		return err
+	}
+
+	acceptedAt := s.clock.Now()
+	lockedOffer.Status = OfferStatusAccepted
+	lockedOffer.AcceptedAmount = decimal.NewNullDecimal(amount)
+	lockedOffer.AcceptedAt = &acceptedAt
+	lockedOffer.UpdatedAt = acceptedAt
+	return txExchangeRepo.UpdateOffer(*lockedOffer)
+}
+
+// GetExchangeRateHistory groups the recorded rates of the pair into OHLC buckets aligned to the interval
+func (s *exchangeService) GetExchangeRateHistory(request RateHistoryRequest) (*RateHistoryResponse, error) {
+	fromCurrencyCode := strings.ToUpper(request.FromCurrencyCode)
+	toCurrencyCode := strings.ToUpper(request.ToCurrencyCode)
+	if fromCurrencyCode == "" || toCurrencyCode == "" {
+		return nil, fmt.Errorf("%w: from and to are required", ErrInvalidRateHistoryQuery)
+	}
+
+	interval := defaultRateHistoryInterval
+	if request.Interval != "" {
+		var err error
+		if interval, err = time.ParseDuration(request.Interval); err != nil {
+			return nil, fmt.Errorf("%w: invalid interval %s", ErrInvalidRateHistoryQuery, request.Interval)
+		}
+	}
+
+	if interval < minRateHistoryInterval {
+		return nil, fmt.Errorf("%w: interval must be at least %s", ErrInvalidRateHistoryQuery, minRateHistoryInterval)
+	}
+
+	end := s.clock.Now()
+	if request.End != nil {
+		end = *request.End
+	}
+
+	start := end.Add(-defaultRateHistoryRange)
+	if request.Start != nil {
+		start = *request.Start
+	}
+
+	if !start.Before(end) {
+		return nil, fmt.Errorf("%w: start must be before end", ErrInvalidRateHistoryQuery)
+	}
+
+	if end.Sub(start)/interval > maxRateHistoryBuckets {
+		return nil, fmt.Errorf("%w: more than %d buckets requested", ErrInvalidRateHistoryQuery, maxRateHistoryBuckets)
+	}
+
+	histories, err := s.exchangeRepo.ListRateHistory(fromCurrencyCode, toCurrencyCode, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := []RateBucket{}
+	for _, history := range histories {
+		bucketStart := history.RecordedAt.UTC().Truncate(interval)
+		rate := history.ExchangeRate
+		if len(buckets) == 0 || !buckets[len(buckets)-1].Start.Equal(bucketStart) {
+			buckets = append(buckets, RateBucket{Start: bucketStart, Open: rate, High: rate, Low: rate})
+		}
+
+		bucket := &buckets[len(buckets)-1]
+		bucket.High = decimal.Max(bucket.High, rate)
+		bucket.Low = decimal.Min(bucket.Low, rate)
+		bucket.Close = rate
+		bucket.Count++
+	}
+
+	return &RateHistoryResponse{
+		FromCurrencyCode: fromCurrencyCode,
+		ToCurrencyCode:   toCurrencyCode,
+		Interval:         interval.String(),
+		Buckets:          buckets,
+	}, nil
+}
+
+// checkOfferIsAcceptable makes sure the offer is still pending at the given time, an offer can be consumed exactly once
+func checkOfferIsAcceptable(offer Offer, now time.Time) error {
+	switch offer.Status {
+	case OfferStatusAccepted:
+		return ErrOfferAlreadyUsed
+	case OfferStatusCancelled:
+		return ErrOfferCancelled
+	case OfferStatusExpired:
+		return ErrOfferExpired
+	}
+
+	if offer.ExpiresAt < now.Unix() {
+		return ErrOfferExpired
+	}
+
 	return nil
 }
 
-func (s *exchangeService) calculateToBalanceAfterAcceptedCurrencyConversion(offer Offer, amount float64) (string, float64) {
-	return offer.ToCurrencyCode, amount * offer.ExchangeRate
+// updateUserBalances posts the conversion to the ledger, the user pays into and gets paid from the house FX accounts
+func (s *exchangeService) updateUserBalances(accountService account.IAccountService, userId uint, offer Offer, amount decimal.Decimal, holdId *uint) error {
+	fromCurrencyCode, fromAmount := s.calculateFromAmountOfAcceptedCurrencyConversion(offer, amount)
+	toCurrencyCode, toAmount := s.calculateToAmountOfAcceptedCurrencyConversion(offer, amount)
+	if !toAmount.IsPositive() {
+		return errors.New("amount is too small to convert")
+	}
+
+	entry := ledger.NewConversionEntry(userId, offer.Id, fromCurrencyCode, toCurrencyCode, fromAmount, toAmount)
+	if holdId != nil {
+		_, err := accountService.CaptureHold(*holdId, entry)
+		return err
+	}
+
+	_, err := accountService.ApplyJournalEntry(entry)
+	return err
+}
+
+func (s *exchangeService) calculateToAmountOfAcceptedCurrencyConversion(offer Offer, amount decimal.Decimal) (string, decimal.Decimal) {
+	// The locked buy amount is credited as quoted, recomputing it could round a buy side quote below the asked amount
+	if offer.BuyAmount.Valid {
+		return offer.ToCurrencyCode, offer.BuyAmount.Decimal
+	}
+	return offer.ToCurrencyCode, currency.Round(amount.Mul(offer.ExchangeRate), offer.ToCurrencyCode).Sub(offer.FixedFee.Decimal)
 }
 
-func (s *exchangeService) calculateFromBalanceAfterAcceptedCurrencyConversion(offer Offer, amount float64) (string, float64) {
-	return offer.FromCurrencyCode, -1 * amount
+func (s *exchangeService) calculateFromAmountOfAcceptedCurrencyConversion(offer Offer, amount decimal.Decimal) (string, decimal.Decimal) {
+	return offer.FromCurrencyCode, currency.Round(amount, offer.FromCurrencyCode)
 }
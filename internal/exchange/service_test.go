@@ -10,45 +10,65 @@ import (
 	// External imports
 	"github.com/golang/mock/gomock"
 	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 
 	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
 )
 
 func TestExchangeService_AcceptExchangeRateOffer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
 	accService := account.NewMockIAccountService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
 	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
 	currencyService.SetLocalCacheToCurrencies()
-	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService)
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), mockUnitOfWork, config.Config{}, clock.NewClock())
 
 	t.Run("offer not found", func(t *testing.T) {
 		userId := uint(1)
 		acceptOfferRequest := AcceptOfferRequest{
 			OfferId: uint(1),
-			Amount:  100,
+			Amount:  decimal.NewFromInt(100),
 		}
 
-		mockExchangeRepository.EXPECT().GetOffer(acceptOfferRequest.OfferId).Return(&Offer{}, errors.New("offer not found"))
+		mockExchangeRepository.EXPECT().GetOffer(acceptOfferRequest.OfferId).Return(nil, gorm.ErrRecordNotFound)
 		_, err := exchService.AcceptExchangeRateOffer(userId, acceptOfferRequest)
+		assert.ErrorIs(t, err, ErrOfferNotFound)
+	})
+
+	t.Run("offer of another user", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().GetOffer(uint(1)).Return(&Offer{Id: 1, UserId: 7}, nil)
+		_, err := exchService.AcceptExchangeRateOffer(uint(1), AcceptOfferRequest{OfferId: 1, Amount: decimal.NewFromInt(100)})
+		assert.ErrorIs(t, err, ErrOfferNotFound)
+	})
+
+	t.Run("offer can not be read", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().GetOffer(uint(1)).Return(nil, errors.New("connection refused"))
+		_, err := exchService.AcceptExchangeRateOffer(uint(1), AcceptOfferRequest{OfferId: 1, Amount: decimal.NewFromInt(100)})
 		assert.NotNil(t, err)
+		assert.NotErrorIs(t, err, ErrOfferNotFound)
 	})
 
 	t.Run("offer expired", func(t *testing.T) {
 		userId := uint(1)
 		acceptOfferRequest := AcceptOfferRequest{
 			OfferId: uint(1),
-			Amount:  100,
+			Amount:  decimal.NewFromInt(100),
 		}
 
 		expectedOffer := Offer{
 			Id:               acceptOfferRequest.OfferId,
 			FromCurrencyCode: "TRY",
 			ToCurrencyCode:   "USD",
-			ExchangeRate:     18.50,
+			ExchangeRate:     decimal.RequireFromString("18.50"),
 			ExpiresAt:        time.Now().Add(time.Minute * -3).Unix(),
 			UserId:           userId,
 		}
@@ -63,20 +83,23 @@ func TestExchangeService_AcceptExchangeRateOffer(t *testing.T) {
 		userId := uint(1)
 		acceptOfferRequest := AcceptOfferRequest{
 			OfferId: uint(1),
-			Amount:  100,
+			Amount:  decimal.NewFromInt(100),
 		}
 
 		expectedOffer := Offer{
 			Id:               acceptOfferRequest.OfferId,
 			FromCurrencyCode: "TRY",
 			ToCurrencyCode:   "USD",
-			ExchangeRate:     18.50,
+			ExchangeRate:     decimal.RequireFromString("18.50"),
 			ExpiresAt:        time.Now().Add(time.Minute * 3).Unix(),
 			UserId:           userId,
 		}
 
 		mockExchangeRepository.EXPECT().GetOffer(acceptOfferRequest.OfferId).Return(&expectedOffer, nil)
-		accService.EXPECT().GetUserBalanceOnGivenCurrencyAccount(userId, expectedOffer.FromCurrencyCode).Return(float64(50), nil)
+		expectTransaction(mockUnitOfWork, mockExchangeRepository, accService)
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(expectedOffer.Id).Return(&expectedOffer, nil)
+		accService.EXPECT().LockUserAccounts(userId, expectedOffer.FromCurrencyCode, expectedOffer.ToCurrencyCode).
+			Return(map[string]decimal.Decimal{"TRY": decimal.NewFromInt(50), "USD": decimal.Zero}, nil)
 		_, err := exchService.AcceptExchangeRateOffer(userId, acceptOfferRequest)
 		assert.NotNil(t, err)
 		assert.Equal(t, err.Error(), "not enough balance")
@@ -86,39 +109,132 @@ func TestExchangeService_AcceptExchangeRateOffer(t *testing.T) {
 		userId := uint(1)
 		acceptOfferRequest := AcceptOfferRequest{
 			OfferId: uint(1),
-			Amount:  100,
+			Amount:  decimal.NewFromInt(100),
 		}
 
 		expectedOffer := Offer{
 			Id:               acceptOfferRequest.OfferId,
 			FromCurrencyCode: "TRY",
 			ToCurrencyCode:   "USD",
-			ExchangeRate:     18.50,
+			ExchangeRate:     decimal.RequireFromString("18.50"),
 			ExpiresAt:        time.Now().Add(time.Minute * 3).Unix(),
 			UserId:           userId,
 		}
 
 		mockExchangeRepository.EXPECT().GetOffer(acceptOfferRequest.OfferId).Return(&expectedOffer, nil)
-		accService.EXPECT().GetUserBalanceOnGivenCurrencyAccount(userId, expectedOffer.FromCurrencyCode).Return(float64(150), nil)
-
-		amount := acceptOfferRequest.Amount * expectedOffer.ExchangeRate
-		accService.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, expectedOffer.FromCurrencyCode, -1*acceptOfferRequest.Amount).Return(nil)
-		accService.EXPECT().UpdateUserBalanceOnGivenCurrencyAccount(userId, expectedOffer.ToCurrencyCode, amount).Return(nil)
+		expectTransaction(mockUnitOfWork, mockExchangeRepository, accService)
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(expectedOffer.Id).Return(&expectedOffer, nil)
+		accService.EXPECT().LockUserAccounts(userId, expectedOffer.FromCurrencyCode, expectedOffer.ToCurrencyCode).
+			Return(map[string]decimal.Decimal{"TRY": decimal.NewFromInt(150), "USD": decimal.Zero}, nil)
+
+		conversionEntry := ledger.NewConversionEntry(userId, expectedOffer.Id, expectedOffer.FromCurrencyCode, expectedOffer.ToCurrencyCode, decimal.RequireFromString("100.00"), decimal.RequireFromString("1850.00"))
+		accService.EXPECT().ApplyJournalEntry(conversionEntry).Return(&conversionEntry, nil)
+		mockExchangeRepository.EXPECT().UpdateOffer(gomock.Any()).DoAndReturn(func(offer Offer) error {
+			assert.Equal(t, OfferStatusAccepted, offer.Status)
+			assert.True(t, offer.AcceptedAmount.Decimal.Equal(decimal.NewFromInt(100)))
+			assert.NotNil(t, offer.AcceptedAt)
+			return nil
+		})
 
 		accService.EXPECT().ListUserAccounts(userId).Return([]account.WalletAccount{}, nil)
 		_, err := exchService.AcceptExchangeRateOffer(userId, acceptOfferRequest)
 		assert.Nil(t, err)
 	})
 
+	t.Run("offer already used", func(t *testing.T) {
+		userId := uint(1)
+		acceptOfferRequest := AcceptOfferRequest{
+			OfferId: uint(1),
+			Amount:  decimal.NewFromInt(100),
+		}
+
+		expectedOffer := Offer{
+			Id:               acceptOfferRequest.OfferId,
+			FromCurrencyCode: "TRY",
+			ToCurrencyCode:   "USD",
+			ExchangeRate:     decimal.RequireFromString("18.50"),
+			ExpiresAt:        time.Now().Add(time.Minute * 3).Unix(),
+			UserId:           userId,
+			Status:           OfferStatusAccepted,
+		}
+
+		mockExchangeRepository.EXPECT().GetOffer(acceptOfferRequest.OfferId).Return(&expectedOffer, nil)
+		_, err := exchService.AcceptExchangeRateOffer(userId, acceptOfferRequest)
+		assert.Equal(t, ErrOfferAlreadyUsed, err)
+	})
+
+	t.Run("offer consumed while waiting for lock", func(t *testing.T) {
+		userId := uint(1)
+		acceptOfferRequest := AcceptOfferRequest{
+			OfferId: uint(1),
+			Amount:  decimal.NewFromInt(100),
+		}
+
+		pendingOffer := Offer{
+			Id:               acceptOfferRequest.OfferId,
+			FromCurrencyCode: "TRY",
+			ToCurrencyCode:   "USD",
+			ExchangeRate:     decimal.RequireFromString("18.50"),
+			ExpiresAt:        time.Now().Add(time.Minute * 3).Unix(),
+			UserId:           userId,
+			Status:           OfferStatusPending,
+		}
+		acceptedOffer := pendingOffer
+		acceptedOffer.Status = OfferStatusAccepted
+
+		mockExchangeRepository.EXPECT().GetOffer(acceptOfferRequest.OfferId).Return(&pendingOffer, nil)
+		expectTransaction(mockUnitOfWork, mockExchangeRepository, accService)
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(pendingOffer.Id).Return(&acceptedOffer, nil)
+		_, err := exchService.AcceptExchangeRateOffer(userId, acceptOfferRequest)
+		assert.Equal(t, ErrOfferAlreadyUsed, err)
+	})
+
+	t.Run("ledger failure rolls back conversion", func(t *testing.T) {
+		userId := uint(1)
+		acceptOfferRequest := AcceptOfferRequest{
+			OfferId: uint(1),
+			Amount:  decimal.NewFromInt(100),
+		}
+
+		expectedOffer := Offer{
+			Id:               acceptOfferRequest.OfferId,
+			FromCurrencyCode: "TRY",
+			ToCurrencyCode:   "USD",
+			ExchangeRate:     decimal.RequireFromString("18.50"),
+			ExpiresAt:        time.Now().Add(time.Minute * 3).Unix(),
+			UserId:           userId,
+		}
+
+		mockExchangeRepository.EXPECT().GetOffer(acceptOfferRequest.OfferId).Return(&expectedOffer, nil)
+		expectTransaction(mockUnitOfWork, mockExchangeRepository, accService)
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(expectedOffer.Id).Return(&expectedOffer, nil)
+		accService.EXPECT().LockUserAccounts(userId, expectedOffer.FromCurrencyCode, expectedOffer.ToCurrencyCode).
+			Return(map[string]decimal.Decimal{"TRY": decimal.NewFromInt(150), "USD": decimal.Zero}, nil)
+		accService.EXPECT().ApplyJournalEntry(gomock.Any()).Return(nil, errors.New("connection lost"))
+
+		_, err := exchService.AcceptExchangeRateOffer(userId, acceptOfferRequest)
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "connection lost")
+	})
+}
+
+// expectTransaction makes the mocked unit of work run its function and the repositories join it
+func expectTransaction(mockUnitOfWork *uow.MockIUnitOfWork, mockExchangeRepository *MockIExchangeRepository, accService *account.MockIAccountService) {
+	mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+		return fn(nil)
+	})
+	mockExchangeRepository.EXPECT().WithTx(gomock.Any()).Return(mockExchangeRepository)
+	accService.EXPECT().WithTx(gomock.Any()).Return(accService)
 }
 
 func TestExchangeService_GetExchangeRateOffer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
 	accService := account.NewMockIAccountService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
 	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
 	currencyService.SetLocalCacheToCurrencies()
-	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService)
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), mockUnitOfWork, config.Config{}, clock.NewClock())
 
 	t.Run("currency is not exist", func(t *testing.T) {
 		fromCurrencyCode := "AAA"
@@ -150,8 +266,8 @@ func TestExchangeService_GetExchangeRateOffer(t *testing.T) {
 		expectedExchangeResponse := &Exchange{
 			FromCurrencyCode: offerRequest.FromCurrencyCode,
 			ToCurrencyCode:   offerRequest.ToCurrencyCode,
-			ExchangeRate:     18,
-			MarkupRate:       2,
+			ExchangeRate:     decimal.NewFromInt(18),
+			MarkupRate:       decimal.NewFromInt(2),
 			CreatedAt:        now,
 			UpdatedAt:        now,
 		}
@@ -163,7 +279,7 @@ func TestExchangeService_GetExchangeRateOffer(t *testing.T) {
 			Id:               uint(2),
 			FromCurrencyCode: expectedExchangeResponse.FromCurrencyCode,
 			ToCurrencyCode:   expectedExchangeResponse.ToCurrencyCode,
-			ExchangeRate:     expectedExchangeResponse.ExchangeRate - expectedExchangeResponse.MarkupRate,
+			ExchangeRate:     expectedExchangeResponse.ExchangeRate.Sub(expectedExchangeResponse.MarkupRate),
 			ExpiresAt:        time.Now().Add(time.Minute * 3).Unix(),
 			UserId:           userId,
 			CreatedAt:        now,
@@ -184,9 +300,10 @@ func TestExchangeService_CreateExchangeRateOffer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
 	accService := account.NewMockIAccountService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
 	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
 	currencyService.SetLocalCacheToCurrencies()
-	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService)
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), mockUnitOfWork, config.Config{}, clock.NewClock())
 
 	offerId := uint(1)
 	expiresAt := time.Now().Add(time.Minute * 3).Unix()
@@ -194,7 +311,7 @@ func TestExchangeService_CreateExchangeRateOffer(t *testing.T) {
 		Id:               offerId,
 		FromCurrencyCode: "TRY",
 		ToCurrencyCode:   "USD",
-		ExchangeRate:     18,
+		ExchangeRate:     decimal.NewFromInt(18),
 		ExpiresAt:        expiresAt,
 		UserId:           uint(1),
 	}
@@ -203,7 +320,7 @@ func TestExchangeService_CreateExchangeRateOffer(t *testing.T) {
 		Id:               offerId,
 		FromCurrencyCode: "TRY",
 		ToCurrencyCode:   "USD",
-		ExchangeRate:     18,
+		ExchangeRate:     decimal.NewFromInt(18),
 		ExpiresAt:        expiresAt,
 		UserId:           uint(1),
 	}
@@ -212,3 +329,347 @@ func TestExchangeService_CreateExchangeRateOffer(t *testing.T) {
 	_, err := exchService.CreateExchangeRateOffer(offer.UserId, offer.FromCurrencyCode, offer.ToCurrencyCode, offer.ExchangeRate)
 	assert.NotNil(t, err)
 }
+
+func TestExchangeService_GetExchangeRateOffer_CrossRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"TRY", "USD", "EUR", "JPY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{RatePivotCurrency: "usd"}, clock.NewClock())
+	userId := uint(1)
+
+	t.Run("rate computed through pivot currency", func(t *testing.T) {
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "EUR").Return(true)
+		mockExchangeRepository.EXPECT().GetExchangeRate("TRY", "EUR").Return(nil, gorm.ErrRecordNotFound)
+		mockExchangeRepository.EXPECT().ListExchanges().Return(testExchanges(), nil)
+		mockExchangeRepository.EXPECT().ListMarkupRules("TRY", "EUR").Return(nil, nil)
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			assert.True(t, offer.ExchangeRate.Equal(decimal.RequireFromString("0.049248")))
+			offer.Id = 7
+			return &offer, nil
+		})
+
+		response, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "try", ToCurrencyCode: "eur"})
+		assert.Nil(t, err)
+		assert.Equal(t, uint(7), response.OfferId)
+		assert.Equal(t, []string{"TRY", "USD", "EUR"}, response.Path)
+		assert.True(t, response.ExchangeRate.Equal(decimal.RequireFromString("0.049248")))
+	})
+
+	t.Run("no path between currencies", func(t *testing.T) {
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "JPY").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		mockExchangeRepository.EXPECT().GetExchangeRate("JPY", "TRY").Return(nil, gorm.ErrRecordNotFound)
+		mockExchangeRepository.EXPECT().ListExchanges().Return(testExchanges(), nil)
+
+		_, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "JPY", ToCurrencyCode: "TRY"})
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+}
+
+func TestExchangeService_GetExchangeRateOffer_Markup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockSegmentResolver := NewMockIUserSegmentResolver(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, mockSegmentResolver, uow.NewMockIUnitOfWork(ctrl), config.Config{}, clock.NewClock())
+	userId := uint(1)
+	rules := []MarkupRule{
+		{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(100), MinAmount: decimal.Zero},
+		{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Type: MarkupTypeBasisPoints, Value: decimal.NewFromInt(50), MinAmount: decimal.NewFromInt(1000)},
+		{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Segment: "VIP", Type: MarkupTypeAbsolute, Value: decimal.RequireFromString("0.05"), MinAmount: decimal.Zero},
+	}
+	exchange := &Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(20), MarkupRate: decimal.NewFromInt(1)}
+
+	expectQuote := func() {
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		mockExchangeRepository.EXPECT().GetExchangeRate("USD", "TRY").Return(exchange, nil)
+		mockExchangeRepository.EXPECT().ListMarkupRules("USD", "TRY").Return(rules, nil)
+	}
+
+	t.Run("tier of the amount is applied", func(t *testing.T) {
+		expectQuote()
+		mockSegmentResolver.EXPECT().GetUserSegment(userId).Return("", nil)
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			assert.True(t, offer.MinAmount.Valid)
+			assert.True(t, offer.MinAmount.Decimal.Equal(decimal.NewFromInt(1000)))
+			offer.Id = 3
+			return &offer, nil
+		})
+
+		response, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", SellAmount: decimal.NewFromInt(5000)})
+		assert.Nil(t, err)
+		assert.True(t, response.MidRate.Equal(decimal.NewFromInt(20)))
+		assert.True(t, response.ExchangeRate.Equal(decimal.RequireFromString("19.9")))
+		assert.Equal(t, MarkupTypeBasisPoints, response.Markup.Type)
+		assert.True(t, response.Markup.Rate.Equal(decimal.RequireFromString("0.1")))
+	})
+
+	t.Run("segment rules are applied", func(t *testing.T) {
+		expectQuote()
+		mockSegmentResolver.EXPECT().GetUserSegment(userId).Return("VIP", nil)
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			assert.False(t, offer.MinAmount.Valid)
+			offer.Id = 4
+			return &offer, nil
+		})
+
+		response, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", SellAmount: decimal.NewFromInt(5000)})
+		assert.Nil(t, err)
+		assert.True(t, response.ExchangeRate.Equal(decimal.RequireFromString("19.95")))
+		assert.Equal(t, "VIP", response.Markup.Segment)
+	})
+}
+
+func TestExchangeService_AcceptExchangeRateOffer_BelowMarkupTier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	exchService := NewExchangeService(mockExchangeRepository, currency.Service{}, account.NewMockIAccountService(ctrl), NewMockIUserSegmentResolver(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{}, clock.NewClock())
+	offer := &Offer{
+		Id:               5,
+		FromCurrencyCode: "USD",
+		ToCurrencyCode:   "TRY",
+		ExchangeRate:     decimal.RequireFromString("19.9"),
+		ExpiresAt:        time.Now().Add(time.Minute).Unix(),
+		UserId:           1,
+		Status:           OfferStatusPending,
+		MinAmount:        decimal.NewNullDecimal(decimal.NewFromInt(1000)),
+	}
+
+	mockExchangeRepository.EXPECT().GetOffer(offer.Id).Return(offer, nil)
+
+	_, err := exchService.AcceptExchangeRateOffer(1, AcceptOfferRequest{OfferId: offer.Id, Amount: decimal.NewFromInt(999)})
+	assert.ErrorIs(t, err, ErrAmountBelowMarkupTier)
+}
+
+func TestExchangeService_GetExchangeRateOffer_LockedAmounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{}, clock.NewClock())
+	userId := uint(1)
+	exchange := &Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(20), MarkupRate: decimal.NewFromInt(1)}
+
+	expectQuote := func() {
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		mockExchangeRepository.EXPECT().GetExchangeRate("USD", "TRY").Return(exchange, nil)
+		mockExchangeRepository.EXPECT().ListMarkupRules("USD", "TRY").Return(nil, nil)
+	}
+
+	t.Run("both amounts given", func(t *testing.T) {
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		_, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", SellAmount: decimal.NewFromInt(10), BuyAmount: decimal.NewFromInt(190)})
+		assert.ErrorIs(t, err, ErrInvalidOfferAmount)
+	})
+
+	t.Run("sell amount locked", func(t *testing.T) {
+		expectQuote()
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			assert.True(t, offer.SellAmount.Decimal.Equal(decimal.NewFromInt(10)))
+			assert.True(t, offer.BuyAmount.Decimal.Equal(decimal.NewFromInt(190)))
+			return &offer, nil
+		})
+
+		response, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", SellAmount: decimal.NewFromInt(10)})
+		assert.Nil(t, err)
+		assert.True(t, response.BuyAmount.Decimal.Equal(decimal.NewFromInt(190)))
+	})
+
+	t.Run("sell amount covering the buy amount", func(t *testing.T) {
+		expectQuote()
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			return &offer, nil
+		})
+
+		response, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", BuyAmount: decimal.NewFromInt(500)})
+		assert.Nil(t, err)
+		assert.True(t, response.SellAmount.Decimal.Equal(decimal.RequireFromString("26.32")))
+		assert.True(t, response.BuyAmount.Decimal.Equal(decimal.NewFromInt(500)))
+	})
+
+	t.Run("quote with fixed fee", func(t *testing.T) {
+		exchange.FixedFee = decimal.RequireFromString("1.50")
+		defer func() { exchange.FixedFee = decimal.Zero }()
+
+		expectQuote()
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			assert.True(t, offer.FixedFee.Decimal.Equal(decimal.RequireFromString("1.50")))
+			return &offer, nil
+		})
+
+		response, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", BuyAmount: decimal.NewFromInt(500)})
+		assert.Nil(t, err)
+		assert.True(t, response.SellAmount.Decimal.Equal(decimal.RequireFromString("26.40")))
+		assert.True(t, response.Quote.GrossAmount.Equal(decimal.NewFromInt(528)))
+		assert.True(t, response.Quote.MarkupCost.Equal(decimal.RequireFromString("26.40")))
+		assert.True(t, response.Quote.FixedFee.Equal(decimal.RequireFromString("1.50")))
+		assert.True(t, response.Quote.Rounding.Equal(decimal.RequireFromString("0.10")))
+		assert.True(t, response.Quote.NetAmount.Equal(decimal.NewFromInt(500)))
+		assert.False(t, response.ExpiresAt.IsZero())
+	})
+
+	t.Run("rate only offer has no quote", func(t *testing.T) {
+		expectQuote()
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			return &offer, nil
+		})
+
+		response, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY"})
+		assert.Nil(t, err)
+		assert.Nil(t, response.Quote)
+		assert.False(t, response.SellAmount.Valid)
+	})
+}
+
+func TestExchangeService_AcceptExchangeRateOffer_LockedAmounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	exchService := NewExchangeService(mockExchangeRepository, currency.Service{}, accService, NewMockIUserSegmentResolver(ctrl), mockUnitOfWork, config.Config{}, clock.NewClock())
+	userId := uint(1)
+	offer := Offer{
+		Id:               6,
+		FromCurrencyCode: "USD",
+		ToCurrencyCode:   "TRY",
+		ExchangeRate:     decimal.NewFromInt(19),
+		ExpiresAt:        time.Now().Add(time.Minute).Unix(),
+		UserId:           userId,
+		Status:           OfferStatusPending,
+		SellAmount:       decimal.NewNullDecimal(decimal.RequireFromString("26.32")),
+		BuyAmount:        decimal.NewNullDecimal(decimal.NewFromInt(500)),
+	}
+
+	t.Run("amount differs from the locked amount", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().GetOffer(offer.Id).Return(&offer, nil)
+		_, err := exchService.AcceptExchangeRateOffer(userId, AcceptOfferRequest{OfferId: offer.Id, Amount: decimal.NewFromInt(30)})
+		assert.ErrorIs(t, err, ErrOfferAmountMismatch)
+	})
+
+	t.Run("locked amounts are converted", func(t *testing.T) {
+		lockedOffer := offer
+		mockExchangeRepository.EXPECT().GetOffer(offer.Id).Return(&offer, nil)
+		expectTransaction(mockUnitOfWork, mockExchangeRepository, accService)
+		mockExchangeRepository.EXPECT().GetOfferForUpdate(offer.Id).Return(&lockedOffer, nil)
+		accService.EXPECT().LockUserAccounts(userId, "USD", "TRY").Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(100), "TRY": decimal.Zero}, nil)
+
+		conversionEntry := ledger.NewConversionEntry(userId, offer.Id, "USD", "TRY", offer.SellAmount.Decimal, offer.BuyAmount.Decimal)
+		accService.EXPECT().ApplyJournalEntry(conversionEntry).Return(&conversionEntry, nil)
+		mockExchangeRepository.EXPECT().UpdateOffer(gomock.Any()).Return(nil)
+		accService.EXPECT().ListUserAccounts(userId).Return([]account.WalletAccount{}, nil)
+
+		_, err := exchService.AcceptExchangeRateOffer(userId, AcceptOfferRequest{OfferId: offer.Id})
+		assert.Nil(t, err)
+	})
+}
+
+func TestExchangeService_GetExchangeRateHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	exchService := NewExchangeService(mockExchangeRepository, currency.Service{}, account.NewMockIAccountService(ctrl), NewMockIUserSegmentResolver(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{}, clock.NewClock())
+	end := time.Date(2022, 12, 2, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-24 * time.Hour)
+
+	t.Run("invalid interval", func(t *testing.T) {
+		_, err := exchService.GetExchangeRateHistory(RateHistoryRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Interval: "10s"})
+		assert.ErrorIs(t, err, ErrInvalidRateHistoryQuery)
+	})
+
+	t.Run("too many buckets", func(t *testing.T) {
+		_, err := exchService.GetExchangeRateHistory(RateHistoryRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Start: &start, End: &end, Interval: "1m"})
+		assert.ErrorIs(t, err, ErrInvalidRateHistoryQuery)
+	})
+
+	t.Run("rates grouped into hourly buckets", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ListRateHistory("USD", "TRY", start, end).Return([]RateHistory{
+			{ExchangeRate: decimal.RequireFromString("18.63"), RecordedAt: start.Add(10 * time.Minute)},
+			{ExchangeRate: decimal.RequireFromString("18.71"), RecordedAt: start.Add(20 * time.Minute)},
+			{ExchangeRate: decimal.RequireFromString("18.60"), RecordedAt: start.Add(30 * time.Minute)},
+			{ExchangeRate: decimal.RequireFromString("18.65"), RecordedAt: start.Add(40 * time.Minute)},
+			{ExchangeRate: decimal.RequireFromString("18.70"), RecordedAt: start.Add(3*time.Hour + time.Minute)},
+		}, nil)
+
+		history, err := exchService.GetExchangeRateHistory(RateHistoryRequest{FromCurrencyCode: "usd", ToCurrencyCode: "try", Start: &start, End: &end})
+		assert.Nil(t, err)
+		assert.Equal(t, "1h0m0s", history.Interval)
+		assert.Len(t, history.Buckets, 2)
+
+		first := history.Buckets[0]
+		assert.Equal(t, start, first.Start)
+		assert.Equal(t, 4, first.Count)
+		assert.True(t, first.Open.Equal(decimal.RequireFromString("18.63")))
+		assert.True(t, first.High.Equal(decimal.RequireFromString("18.71")))
+		assert.True(t, first.Low.Equal(decimal.RequireFromString("18.60")))
+		assert.True(t, first.Close.Equal(decimal.RequireFromString("18.65")))
+		assert.Equal(t, start.Add(3*time.Hour), history.Buckets[1].Start)
+	})
+}
+
+func TestExchangeService_OfferTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	mockClock := clock.NewMockIClock(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{OfferTTL: time.Minute}, mockClock)
+	userId := uint(1)
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	quote := func(exchange Exchange) *OfferResponse {
+		mockClock.EXPECT().Now().Return(now).AnyTimes()
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "TRY").Return(true)
+		mockExchangeRepository.EXPECT().GetExchangeRate("USD", "TRY").Return(&exchange, nil)
+		mockExchangeRepository.EXPECT().ListMarkupRules("USD", "TRY").Return(nil, nil)
+		mockExchangeRepository.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer Offer) (*Offer, error) {
+			offer.Id = 4
+			return &offer, nil
+		})
+
+		offer, err := exchService.GetExchangeRateOffer(userId, OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY"})
+		assert.Nil(t, err)
+		return offer
+	}
+
+	t.Run("configured ttl", func(t *testing.T) {
+		offer := quote(Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(19), MarkupRate: decimal.RequireFromString("0.3")})
+		assert.Equal(t, now.Add(time.Minute), offer.ExpiresAt)
+		assert.Equal(t, int64(60), offer.ExpiresIn)
+	})
+
+	t.Run("ttl of the pair", func(t *testing.T) {
+		offer := quote(Exchange{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", ExchangeRate: decimal.NewFromInt(19), MarkupRate: decimal.RequireFromString("0.3"), OfferTTLSeconds: 15})
+		assert.Equal(t, now.Add(15*time.Second), offer.ExpiresAt)
+		assert.Equal(t, int64(15), offer.ExpiresIn)
+	})
+
+	t.Run("accept after the server clock passed the expiry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClock := clock.NewMockIClock(ctrl)
+		exchService := NewExchangeService(mockExchangeRepository, currencyService, accService, NewMockIUserSegmentResolver(ctrl), uow.NewMockIUnitOfWork(ctrl), config.Config{}, mockClock)
+		offer := Offer{Id: 4, UserId: userId, FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Status: OfferStatusPending, ExpiresAt: now.Add(15 * time.Second).Unix()}
+
+		mockClock.EXPECT().Now().Return(now.Add(16 * time.Second))
+		mockExchangeRepository.EXPECT().GetOffer(offer.Id).Return(&offer, nil)
+		_, err := exchService.AcceptExchangeRateOffer(userId, AcceptOfferRequest{OfferId: offer.Id, Amount: decimal.NewFromInt(100)})
+		assert.ErrorIs(t, err, ErrOfferExpired)
+	})
+}
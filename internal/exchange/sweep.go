@@ -0,0 +1,80 @@
+package exchange
+
+import (
+	// Go imports
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"gorm.io/gorm"
+)
+
+// offerArchiveBatchSize bounds the rows moved in one transaction so archiving does not hold long locks
+const offerArchiveBatchSize = 500
+
+// SweepOffers marks the pending offers past their expiry as expired, expires the open limit orders past their
+// expiry giving their reserved amounts back, and moves the closed offers unchanged for the retention to the offer history.
+// Offers are archived even when some limit orders can not be expired. The stats of what was done so far are returned
+// together with an error.
+func (s *exchangeService) SweepOffers(retention time.Duration) (*SweepStats, error) {
+	now := s.clock.Now()
+	stats := &SweepStats{StartedAt: now}
+	defer func() {
+		stats.FinishedAt = s.clock.Now()
+	}()
+
+	expiredOffers, err := s.exchangeRepo.ExpireOffers(now.Unix())
+	if err != nil {
+		return stats, err
+	}
+	stats.ExpiredOffers = expiredOffers
+
+	orders, err := s.exchangeRepo.ListExpiredLimitOrders(now)
+	if err != nil {
+		return stats, err
+	}
+
+	// A failing order does not stop the others
+	var failures []string
+	for _, order := range orders {
+		expired, err := s.expireLimitOrder(order.Id)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("can not expire limit order %d: %s", order.Id, err.Error()))
+			continue
+		}
+
+		if expired {
+			stats.ExpiredLimitOrders++
+		}
+	}
+
+	if err = s.archiveOffers(now.Add(-retention), now, stats); err != nil {
+		failures = append(failures, fmt.Sprintf("can not archive offers: %s", err.Error()))
+	}
+
+	if len(failures) > 0 {
+		return stats, fmt.Errorf("can not sweep offers: %s", strings.Join(failures, ", "))
+	}
+
+	return stats, nil
+}
+
+// archiveOffers moves the closed offers in batches until a batch is not full
+func (s *exchangeService) archiveOffers(updatedBefore, archivedAt time.Time, stats *SweepStats) error {
+	for {
+		var archived int
+		if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+			var err error
+			archived, err = s.exchangeRepo.WithTx(tx).ArchiveOffers(updatedBefore, archivedAt, offerArchiveBatchSize)
+			return err
+		}); err != nil {
+			return err
+		}
+
+		stats.ArchivedOffers += archived
+		if archived < offerArchiveBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package exchange
+
+import (
+	// Go imports
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+func TestExchangeService_SweepOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeRepository := NewMockIExchangeRepository(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	mockClock := clock.NewMockIClock(ctrl)
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now).AnyTimes()
+	exchService := NewExchangeService(mockExchangeRepository, currency.Service{}, accService, NewMockIUserSegmentResolver(ctrl), mockUnitOfWork, config.Config{}, mockClock)
+
+	mockExchangeRepository.EXPECT().WithTx(gomock.Any()).Return(mockExchangeRepository).AnyTimes()
+	accService.EXPECT().WithTx(gomock.Any()).Return(accService).AnyTimes()
+	mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+		return fn(nil)
+	}).AnyTimes()
+
+	holdId := uint(5)
+	expiredOrder := LimitOrder{Id: 2, UserId: 1, FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Amount: decimal.NewFromInt(100), Status: LimitOrderStatusOpen, HoldId: holdId}
+	matchedOrder := LimitOrder{Id: 3, UserId: 1, FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Amount: decimal.NewFromInt(50), Status: LimitOrderStatusFilled}
+
+	t.Run("expires and archives", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ExpireOffers(gomock.Any()).Return(int64(4), nil)
+		mockExchangeRepository.EXPECT().ListExpiredLimitOrders(gomock.Any()).Return([]LimitOrder{expiredOrder, matchedOrder}, nil)
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(expiredOrder.Id).Return(&expiredOrder, nil)
+		accService.EXPECT().ReleaseHold(holdId).Return(&account.Hold{Id: holdId, Status: account.HoldStatusReleased}, nil)
+		mockExchangeRepository.EXPECT().UpdateLimitOrder(gomock.Any()).DoAndReturn(func(order LimitOrder) error {
+			assert.Equal(t, LimitOrderStatusExpired, order.Status)
+			return nil
+		})
+
+		// Filled by the matcher after it was listed
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(matchedOrder.Id).Return(&matchedOrder, nil)
+
+		gomock.InOrder(
+			mockExchangeRepository.EXPECT().ArchiveOffers(now.Add(-time.Hour), now, offerArchiveBatchSize).Return(offerArchiveBatchSize, nil),
+			mockExchangeRepository.EXPECT().ArchiveOffers(now.Add(-time.Hour), now, offerArchiveBatchSize).Return(12, nil),
+		)
+
+		stats, err := exchService.SweepOffers(time.Hour)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), stats.ExpiredOffers)
+		assert.Equal(t, 1, stats.ExpiredLimitOrders)
+		assert.Equal(t, offerArchiveBatchSize+12, stats.ArchivedOffers)
+		assert.False(t, stats.FinishedAt.Before(stats.StartedAt))
+	})
+
+	t.Run("failing limit order does not stop archiving", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ExpireOffers(gomock.Any()).Return(int64(0), nil)
+		mockExchangeRepository.EXPECT().ListExpiredLimitOrders(gomock.Any()).Return([]LimitOrder{expiredOrder}, nil)
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(expiredOrder.Id).Return(nil, errors.New("connection reset"))
+		mockExchangeRepository.EXPECT().ArchiveOffers(now.Add(-time.Hour), now, offerArchiveBatchSize).Return(3, nil)
+
+		stats, err := exchService.SweepOffers(time.Hour)
+		assert.ErrorContains(t, err, "can not expire limit order 2: connection reset")
+		assert.Equal(t, 0, stats.ExpiredLimitOrders)
+		assert.Equal(t, 3, stats.ArchivedOffers)
+	})
+
+	t.Run("failures of both steps are reported", func(t *testing.T) {
+		mockExchangeRepository.EXPECT().ExpireOffers(gomock.Any()).Return(int64(0), nil)
+		mockExchangeRepository.EXPECT().ListExpiredLimitOrders(gomock.Any()).Return([]LimitOrder{expiredOrder}, nil)
+		mockExchangeRepository.EXPECT().GetLimitOrderForUpdate(expiredOrder.Id).Return(nil, errors.New("connection reset"))
+		mockExchangeRepository.EXPECT().ArchiveOffers(now.Add(-time.Hour), now, offerArchiveBatchSize).Return(0, errors.New("lock timeout"))
+
+		_, err := exchService.SweepOffers(time.Hour)
+		assert.ErrorContains(t, err, "connection reset")
+		assert.ErrorContains(t, err, "can not archive offers: lock timeout")
+	})
+}
+
+func TestSweeper(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	mockClock := clock.NewMockIClock(ctrl)
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now).AnyTimes()
+	sweeper := NewSweeper(mockExchangeService, time.Millisecond, time.Hour, mockClock)
+
+	t.Run("keeps the error of the last run", func(t *testing.T) {
+		mockExchangeService.EXPECT().SweepOffers(time.Hour).Return(&SweepStats{ExpiredOffers: 2}, errors.New("connection reset"))
+		assert.Nil(t, sweeper.LastRun())
+
+		sweeper.Sweep()
+		assert.Equal(t, int64(2), sweeper.LastRun().ExpiredOffers)
+		assert.Equal(t, "connection reset", sweeper.LastRun().Error)
+	})
+
+	t.Run("failed run without stats", func(t *testing.T) {
+		mockExchangeService.EXPECT().SweepOffers(time.Hour).Return(nil, errors.New("connection refused"))
+
+		sweeper.Sweep()
+		assert.Equal(t, now, sweeper.LastRun().StartedAt)
+		assert.Equal(t, now, sweeper.LastRun().FinishedAt)
+		assert.Equal(t, "connection refused", sweeper.LastRun().Error)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		swept := make(chan struct{}, 2)
+		mockExchangeService.EXPECT().SweepOffers(time.Hour).DoAndReturn(func(time.Duration) (*SweepStats, error) {
+			select {
+			case swept <- struct{}{}:
+			default:
+			}
+			return &SweepStats{}, nil
+		}).MinTimes(2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sweeper.Start(ctx)
+		<-swept
+		<-swept
+		cancel()
+		<-sweeper.Done()
+		assert.Empty(t, sweeper.LastRun().Error)
+	})
+}
@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	// Go imports
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+)
+
+const (
+	// DefaultSweepInterval is used when no sweep interval is configured
+	DefaultSweepInterval = time.Minute
+	// DefaultOfferRetention is used when no offer retention is configured
+	DefaultOfferRetention = 30 * 24 * time.Hour
+)
+
+// Sweeper expires offers and limit orders and archives old offers on every interval, keeping the stats of its last run
+type Sweeper struct {
+	exchangeService IExchangeService
+	interval        time.Duration
+	retention       time.Duration
+	clock           clock.IClock
+	done            chan struct{}
+
+	mu      sync.RWMutex
+	lastRun *SweepStats
+}
+
+func NewSweeper(exchangeService IExchangeService, interval, retention time.Duration, clock clock.IClock) *Sweeper {
+	if interval <= 0 {
+		interval = DefaultSweepInterval
+	}
+	if retention <= 0 {
+		retention = DefaultOfferRetention
+	}
+	return &Sweeper{exchangeService: exchangeService, interval: interval, retention: retention, clock: clock, done: make(chan struct{})}
+}
+
+// Start sweeps right away and then on every interval until the context is done, a sweep in progress is finished first
+func (s *Sweeper) Start(ctx context.Context) {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.Sweep()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Done is closed once the sweeper stopped after its context is done
+func (s *Sweeper) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Sweeper) Sweep() {
+	stats, err := s.exchangeService.SweepOffers(s.retention)
+	if stats == nil {
+		now := s.clock.Now()
+		stats = &SweepStats{StartedAt: now, FinishedAt: now}
+	}
+
+	if err != nil {
+		stats.Error = err.Error()
+		log.Println("offers sweep:", err.Error())
+	}
+
+	s.mu.Lock()
+	s.lastRun = stats
+	s.mu.Unlock()
+}
+
+// LastRun returns the stats of the last sweep, nil before the first sweep finished
+func (s *Sweeper) LastRun() *SweepStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastRun
+}
@@ -0,0 +1,51 @@
+package exchange
+
+import (
+	// Go imports
+	"net/http"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+)
+
+type SweeperHandler interface {
+	LastRun(c *gin.Context)
+	SweeperRoutes(router *gin.RouterGroup)
+}
+
+type sweeperHandler struct {
+	sweeper *Sweeper
+}
+
+func NewSweeperHandler(sweeper *Sweeper) SweeperHandler {
+	return &sweeperHandler{sweeper: sweeper}
+}
+
+func (h *sweeperHandler) SweeperRoutes(router *gin.RouterGroup) {
+	router.GET("/sweeper", h.LastRun)
+}
+
+// LastRun godoc
+// @Summary Get Last Sweep
+// @Description Get the stats of the last run of the sweeper which expires offers and limit orders and archives old offers
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of an admin user."
+// @Success 200 {object} helper.Response{data=SweepStats} "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Router /admin/exchange/sweeper [get]
+func (h *sweeperHandler) LastRun(c *gin.Context) {
+	lastRun := h.sweeper.LastRun()
+	if lastRun == nil {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "sweeper has not run yet")
+		return
+	}
+
+	helper.Success(c, lastRun)
+}
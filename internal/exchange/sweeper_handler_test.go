@@ -0,0 +1,45 @@
+package exchange
+
+import (
+	// Go imports
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+)
+
+func TestSweeperHandler_LastRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExchangeService := NewMockIExchangeService(ctrl)
+	sweeper := NewSweeper(mockExchangeService, time.Minute, time.Hour, clock.NewClock())
+	httpHandler := NewSweeperHandler(sweeper)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	httpHandler.SweeperRoutes(router.Group("/admin/exchange"))
+
+	t.Run("not run yet", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/admin/exchange/sweeper", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("stats of the last run", func(t *testing.T) {
+		mockExchangeService.EXPECT().SweepOffers(time.Hour).Return(&SweepStats{ArchivedOffers: 7}, nil)
+		sweeper.Sweep()
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin/exchange/sweeper", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"archived_offers":7`)
+	})
+}
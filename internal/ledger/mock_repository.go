@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/ledger (interfaces: ILedgerRepository)
+
+// Package ledger is a generated GoMock package.
+package ledger
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	gorm "gorm.io/gorm"
+)
+
+// MockILedgerRepository is a mock of ILedgerRepository interface.
+type MockILedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILedgerRepositoryMockRecorder
+}
+
+// MockILedgerRepositoryMockRecorder is the mock recorder for MockILedgerRepository.
+type MockILedgerRepositoryMockRecorder struct {
+	mock *MockILedgerRepository
+}
+
+// NewMockILedgerRepository creates a new mock instance.
+func NewMockILedgerRepository(ctrl *gomock.Controller) *MockILedgerRepository {
+	mock := &MockILedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockILedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILedgerRepository) EXPECT() *MockILedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateJournalEntry mocks base method.
+func (m *MockILedgerRepository) CreateJournalEntry(arg0 JournalEntry) (*JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJournalEntry", arg0)
+	ret0, _ := ret[0].(*JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJournalEntry indicates an expected call of CreateJournalEntry.
+func (mr *MockILedgerRepositoryMockRecorder) CreateJournalEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournalEntry", reflect.TypeOf((*MockILedgerRepository)(nil).CreateJournalEntry), arg0)
+}
+
+// GetUserBalance mocks base method.
+func (m *MockILedgerRepository) GetUserBalance(arg0 uint, arg1 string) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalance", arg0, arg1)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalance indicates an expected call of GetUserBalance.
+func (mr *MockILedgerRepositoryMockRecorder) GetUserBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockILedgerRepository)(nil).GetUserBalance), arg0, arg1)
+}
+
+// GetUserBalanceBefore mocks base method.
+func (m *MockILedgerRepository) GetUserBalanceBefore(arg0 uint, arg1 string, arg2 time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalanceBefore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalanceBefore indicates an expected call of GetUserBalanceBefore.
+func (mr *MockILedgerRepositoryMockRecorder) GetUserBalanceBefore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalanceBefore", reflect.TypeOf((*MockILedgerRepository)(nil).GetUserBalanceBefore), arg0, arg1, arg2)
+}
+
+// ListUserBalances mocks base method.
+func (m *MockILedgerRepository) ListUserBalances() ([]UserBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserBalances")
+	ret0, _ := ret[0].([]UserBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserBalances indicates an expected call of ListUserBalances.
+func (mr *MockILedgerRepositoryMockRecorder) ListUserBalances() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserBalances", reflect.TypeOf((*MockILedgerRepository)(nil).ListUserBalances))
+}
+
+// ListUserPostings mocks base method.
+func (m *MockILedgerRepository) ListUserPostings(arg0 UserPostingFilter) ([]UserPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPostings", arg0)
+	ret0, _ := ret[0].([]UserPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPostings indicates an expected call of ListUserPostings.
+func (mr *MockILedgerRepositoryMockRecorder) ListUserPostings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPostings", reflect.TypeOf((*MockILedgerRepository)(nil).ListUserPostings), arg0)
+}
+
+// Migration mocks base method.
+func (m *MockILedgerRepository) Migration() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migration")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migration indicates an expected call of Migration.
+func (mr *MockILedgerRepositoryMockRecorder) Migration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockILedgerRepository)(nil).Migration))
+}
+
+// WithTx mocks base method.
+func (m *MockILedgerRepository) WithTx(arg0 *gorm.DB) ILedgerRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(ILedgerRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockILedgerRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockILedgerRepository)(nil).WithTx), arg0)
+}
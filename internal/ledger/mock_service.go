@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/ledger (interfaces: ILedgerService)
+
+// Package ledger is a generated GoMock package.
+package ledger
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	gorm "gorm.io/gorm"
+)
+
+// MockILedgerService is a mock of ILedgerService interface.
+type MockILedgerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILedgerServiceMockRecorder
+}
+
+// MockILedgerServiceMockRecorder is the mock recorder for MockILedgerService.
+type MockILedgerServiceMockRecorder struct {
+	mock *MockILedgerService
+}
+
+// NewMockILedgerService creates a new mock instance.
+func NewMockILedgerService(ctrl *gomock.Controller) *MockILedgerService {
+	mock := &MockILedgerService{ctrl: ctrl}
+	mock.recorder = &MockILedgerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILedgerService) EXPECT() *MockILedgerServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserBalance mocks base method.
+func (m *MockILedgerService) GetUserBalance(arg0 uint, arg1 string) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalance", arg0, arg1)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalance indicates an expected call of GetUserBalance.
+func (mr *MockILedgerServiceMockRecorder) GetUserBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockILedgerService)(nil).GetUserBalance), arg0, arg1)
+}
+
+// GetUserBalanceBefore mocks base method.
+func (m *MockILedgerService) GetUserBalanceBefore(arg0 uint, arg1 string, arg2 time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalanceBefore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalanceBefore indicates an expected call of GetUserBalanceBefore.
+func (mr *MockILedgerServiceMockRecorder) GetUserBalanceBefore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalanceBefore", reflect.TypeOf((*MockILedgerService)(nil).GetUserBalanceBefore), arg0, arg1, arg2)
+}
+
+// ListUserBalances mocks base method.
+func (m *MockILedgerService) ListUserBalances() ([]UserBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserBalances")
+	ret0, _ := ret[0].([]UserBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserBalances indicates an expected call of ListUserBalances.
+func (mr *MockILedgerServiceMockRecorder) ListUserBalances() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserBalances", reflect.TypeOf((*MockILedgerService)(nil).ListUserBalances))
+}
+
+// ListUserPostings mocks base method.
+func (m *MockILedgerService) ListUserPostings(arg0 UserPostingFilter) ([]UserPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPostings", arg0)
+	ret0, _ := ret[0].([]UserPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPostings indicates an expected call of ListUserPostings.
+func (mr *MockILedgerServiceMockRecorder) ListUserPostings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPostings", reflect.TypeOf((*MockILedgerService)(nil).ListUserPostings), arg0)
+}
+
+// Record mocks base method.
+func (m *MockILedgerService) Record(arg0 JournalEntry) (*JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0)
+	ret0, _ := ret[0].(*JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockILedgerServiceMockRecorder) Record(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockILedgerService)(nil).Record), arg0)
+}
+
+// WithTx mocks base method.
+func (m *MockILedgerService) WithTx(arg0 *gorm.DB) ILedgerService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(ILedgerService)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockILedgerServiceMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockILedgerService)(nil).WithTx), arg0)
+}
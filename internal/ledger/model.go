@@ -0,0 +1,273 @@
+package ledger
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+)
+
+// EntryType tells why money moved
+type EntryType string
+
+const (
+	EntryTypeOpeningBalance    EntryType = "OPENING_BALANCE"
+	EntryTypeRegistrationBonus EntryType = "REGISTRATION_BONUS"
+	EntryTypeBonusClawback     EntryType = "BONUS_CLAWBACK"
+	EntryTypeConversion        EntryType = "CONVERSION"
+	EntryTypeTransfer          EntryType = "TRANSFER"
+	EntryTypeDeposit           EntryType = "DEPOSIT"
+	EntryTypeWithdrawal        EntryType = "WITHDRAWAL"
+)
+
+// AccountKind is the owner of a ledger account, house accounts are kept per currency with user id 0
+type AccountKind string
+
+const (
+	AccountKindUser           AccountKind = "USER"
+	AccountKindHouseFx        AccountKind = "HOUSE_FX"
+	AccountKindHousePromotion AccountKind = "HOUSE_PROMOTION"
+	AccountKindHouseEquity    AccountKind = "HOUSE_EQUITY"
+	AccountKindHouseGateway   AccountKind = "HOUSE_GATEWAY" // Funds moving in and out through the payment gateway
+)
+
+// Direction of a posting, a credit increases and a debit decreases the account balance
+type Direction string
+
+const (
+	DirectionDebit  Direction = "DEBIT"
+	DirectionCredit Direction = "CREDIT"
+)
+
+const (
+	ReferenceTypeOffer      = "OFFER"
+	ReferenceTypeLimitOrder = "LIMIT_ORDER"
+	ReferenceTypeTransfer   = "TRANSFER"
+	ReferenceTypePayment    = "PAYMENT"
+	ReferenceTypeBonusGrant = "BONUS_GRANT"
+)
+
+// JournalEntry Gorm model, entries are immutable once recorded
+type JournalEntry struct {
+	Id            uint      `gorm:"primaryKey;autoIncrement"`
+	Type          EntryType `gorm:"type:varchar(32);not null;index"`
+	UserId        uint      `gorm:"not null;index"`
+	ReferenceType string    `gorm:"type:varchar(32)"`
+	ReferenceId   uint
+	Description   string
+	Postings      []Posting `gorm:"foreignKey:JournalEntryId"`
+	CreatedAt     time.Time
+}
+
+// Posting Gorm model, one leg of a journal entry
+type Posting struct {
+	Id             uint            `gorm:"primaryKey;autoIncrement"`
+	JournalEntryId uint            `gorm:"not null;index"`
+	AccountKind    AccountKind     `gorm:"type:varchar(32);not null;index:idx_posting_account"`
+	UserId         uint            `gorm:"not null;index:idx_posting_account"`
+	CurrencyCode   string          `gorm:"not null;index:idx_posting_account"`
+	Direction      Direction       `gorm:"type:varchar(8);not null"`
+	Amount         decimal.Decimal `gorm:"type:numeric;not null"`
+	CreatedAt      time.Time
+}
+
+// SignedAmount returns the amount as the change on the account balance
+func (p Posting) SignedAmount() decimal.Decimal {
+	if p.Direction == DirectionDebit {
+		return p.Amount.Neg()
+	}
+	return p.Amount
+}
+
+// TransactionType is how a user posting is shown to the account owner
+type TransactionType string
+
+const (
+	TransactionTypeOpeningBalance    TransactionType = "OPENING_BALANCE"
+	TransactionTypeRegistrationBonus TransactionType = "REGISTRATION_BONUS"
+	TransactionTypeBonusClawback     TransactionType = "BONUS_CLAWBACK"
+	TransactionTypeConversionDebit   TransactionType = "CONVERSION_DEBIT"
+	TransactionTypeConversionCredit  TransactionType = "CONVERSION_CREDIT"
+	TransactionTypeTransferOut       TransactionType = "TRANSFER_OUT"
+	TransactionTypeTransferIn        TransactionType = "TRANSFER_IN"
+	TransactionTypeDeposit           TransactionType = "DEPOSIT"
+	TransactionTypeWithdrawal        TransactionType = "WITHDRAWAL"
+)
+
+type transactionTypeRule struct {
+	entryType EntryType
+	direction Direction // empty matches both directions
+}
+
+var transactionTypeRules = map[TransactionType]transactionTypeRule{
+	TransactionTypeOpeningBalance:    {entryType: EntryTypeOpeningBalance},
+	TransactionTypeRegistrationBonus: {entryType: EntryTypeRegistrationBonus},
+	TransactionTypeBonusClawback:     {entryType: EntryTypeBonusClawback},
+	TransactionTypeConversionDebit:   {entryType: EntryTypeConversion, direction: DirectionDebit},
+	TransactionTypeConversionCredit:  {entryType: EntryTypeConversion, direction: DirectionCredit},
+	TransactionTypeTransferOut:       {entryType: EntryTypeTransfer, direction: DirectionDebit},
+	TransactionTypeTransferIn:        {entryType: EntryTypeTransfer, direction: DirectionCredit},
+	TransactionTypeDeposit:           {entryType: EntryTypeDeposit},
+	TransactionTypeWithdrawal:        {entryType: EntryTypeWithdrawal},
+}
+
+// IsValid reports whether the transaction type is known
+func (t TransactionType) IsValid() bool {
+	_, ok := transactionTypeRules[t]
+	return ok
+}
+
+// UserPosting is a posting on a user account joined with its journal entry
+type UserPosting struct {
+	Id            uint
+	EntryId       uint
+	EntryType     EntryType
+	ReferenceType string
+	ReferenceId   uint
+	CurrencyCode  string
+	Direction     Direction
+	Amount        decimal.Decimal
+	CreatedAt     time.Time
+}
+
+// SignedAmount returns the amount as the change on the user's balance
+func (p UserPosting) SignedAmount() decimal.Decimal {
+	return Posting{Direction: p.Direction, Amount: p.Amount}.SignedAmount()
+}
+
+// TransactionType resolves the type shown to the user, entries moving money both ways are split by direction
+func (p UserPosting) TransactionType() TransactionType {
+	for transactionType, rule := range transactionTypeRules {
+		if rule.entryType == p.EntryType && (rule.direction == "" || rule.direction == p.Direction) {
+			return transactionType
+		}
+	}
+	return TransactionType(p.EntryType)
+}
+
+// UserPostingFilter narrows the user's postings, postings are returned newest first
+type UserPostingFilter struct {
+	UserId          uint
+	CurrencyCode    string
+	TransactionType TransactionType
+	Start           *time.Time
+	End             *time.Time
+	BeforeId        uint // cursor, only postings with a smaller id are returned
+	Limit           int  // every posting is returned when it is not positive
+}
+
+// UserBalance is the ledger balance of one user account
+type UserBalance struct {
+	UserId       uint
+	CurrencyCode string
+	Balance      decimal.Decimal
+}
+
+// NewConversionEntry moves amount out of the user's from account into the house FX account of that currency,
+// and the converted amount out of the house FX account of the target currency into the user's to account
+func NewConversionEntry(userId, offerId uint, fromCurrencyCode, toCurrencyCode string, fromAmount, toAmount decimal.Decimal) JournalEntry {
+	return JournalEntry{
+		Type:          EntryTypeConversion,
+		UserId:        userId,
+		ReferenceType: ReferenceTypeOffer,
+		ReferenceId:   offerId,
+		Postings: []Posting{
+			{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: fromCurrencyCode, Direction: DirectionDebit, Amount: fromAmount},
+			{AccountKind: AccountKindHouseFx, CurrencyCode: fromCurrencyCode, Direction: DirectionCredit, Amount: fromAmount},
+			{AccountKind: AccountKindHouseFx, CurrencyCode: toCurrencyCode, Direction: DirectionDebit, Amount: toAmount},
+			{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: toCurrencyCode, Direction: DirectionCredit, Amount: toAmount},
+		},
+	}
+}
+
+// NewTransferEntry moves the amount from the sender's account straight into the recipient's account of the same currency
+func NewTransferEntry(senderUserId, recipientUserId, transferId uint, currencyCode string, amount decimal.Decimal) JournalEntry {
+	return JournalEntry{
+		Type:          EntryTypeTransfer,
+		UserId:        senderUserId,
+		ReferenceType: ReferenceTypeTransfer,
+		ReferenceId:   transferId,
+		Postings: []Posting{
+			{AccountKind: AccountKindUser, UserId: senderUserId, CurrencyCode: currencyCode, Direction: DirectionDebit, Amount: amount},
+			{AccountKind: AccountKindUser, UserId: recipientUserId, CurrencyCode: currencyCode, Direction: DirectionCredit, Amount: amount},
+		},
+	}
+}
+
+// NewDepositEntry credits a settled deposit to the user out of the house gateway account
+func NewDepositEntry(userId, paymentId uint, currencyCode string, amount decimal.Decimal) JournalEntry {
+	return JournalEntry{
+		Type:          EntryTypeDeposit,
+		UserId:        userId,
+		ReferenceType: ReferenceTypePayment,
+		ReferenceId:   paymentId,
+		Postings: []Posting{
+			{AccountKind: AccountKindHouseGateway, CurrencyCode: currencyCode, Direction: DirectionDebit, Amount: amount},
+			{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: currencyCode, Direction: DirectionCredit, Amount: amount},
+		},
+	}
+}
+
+// NewWithdrawalEntry moves the amount of a settled withdrawal out of the user's account into the house gateway account,
+// it captures the hold the amount was kept under while the gateway paid it out
+func NewWithdrawalEntry(userId, paymentId uint, currencyCode string, amount decimal.Decimal) JournalEntry {
+	return JournalEntry{
+		Type:          EntryTypeWithdrawal,
+		UserId:        userId,
+		ReferenceType: ReferenceTypePayment,
+		ReferenceId:   paymentId,
+		Postings: []Posting{
+			{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: currencyCode, Direction: DirectionDebit, Amount: amount},
+			{AccountKind: AccountKindHouseGateway, CurrencyCode: currencyCode, Direction: DirectionCredit, Amount: amount},
+		},
+	}
+}
+
+// NewRegistrationBonusEntry credits the onboarding credit of a bonus grant to the user out of the house promotion account
+func NewRegistrationBonusEntry(userId, grantId uint, currencyCode string, amount decimal.Decimal, description string) JournalEntry {
+	return JournalEntry{
+		Type:          EntryTypeRegistrationBonus,
+		UserId:        userId,
+		ReferenceType: ReferenceTypeBonusGrant,
+		ReferenceId:   grantId,
+		Description:   description,
+		Postings: []Posting{
+			{AccountKind: AccountKindHousePromotion, CurrencyCode: currencyCode, Direction: DirectionDebit, Amount: amount},
+			{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: currencyCode, Direction: DirectionCredit, Amount: amount},
+		},
+	}
+}
+
+// NewBonusClawbackEntry takes the unspent part of an onboarding credit back into the house promotion account
+func NewBonusClawbackEntry(userId, grantId uint, currencyCode string, amount decimal.Decimal, description string) JournalEntry {
+	return JournalEntry{
+		Type:          EntryTypeBonusClawback,
+		UserId:        userId,
+		ReferenceType: ReferenceTypeBonusGrant,
+		ReferenceId:   grantId,
+		Description:   description,
+		Postings: []Posting{
+			{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: currencyCode, Direction: DirectionDebit, Amount: amount},
+			{AccountKind: AccountKindHousePromotion, CurrencyCode: currencyCode, Direction: DirectionCredit, Amount: amount},
+		},
+	}
+}
+
+// NewOpeningBalanceEntry brings a balance which existed before the ledger into it against house equity
+func NewOpeningBalanceEntry(userId uint, currencyCode string, amount decimal.Decimal) JournalEntry {
+	userDirection, houseDirection := DirectionCredit, DirectionDebit
+	if amount.IsNegative() {
+		userDirection, houseDirection = DirectionDebit, DirectionCredit
+	}
+
+	return JournalEntry{
+		Type:        EntryTypeOpeningBalance,
+		UserId:      userId,
+		Description: "balance carried over from before the ledger",
+		Postings: []Posting{
+			{AccountKind: AccountKindHouseEquity, CurrencyCode: currencyCode, Direction: houseDirection, Amount: amount.Abs()},
+			{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: currencyCode, Direction: userDirection, Amount: amount.Abs()},
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package ledger
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const signedAmountSql = "CASE WHEN direction = 'CREDIT' THEN amount ELSE -amount END"
+
+type ILedgerRepository interface {
+	CreateJournalEntry(entry JournalEntry) (*JournalEntry, error)
+	GetUserBalance(userId uint, currencyCode string) (decimal.Decimal, error)
+	GetUserBalanceBefore(userId uint, currencyCode string, before time.Time) (decimal.Decimal, error)
+	ListUserBalances() ([]UserBalance, error)
+	ListUserPostings(filter UserPostingFilter) ([]UserPosting, error)
+	WithTx(tx *gorm.DB) ILedgerRepository
+	Migration() error
+}
+
+type ledgerRepository struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) ILedgerRepository {
+	return &ledgerRepository{
+		db: db,
+	}
+}
+
+func (r *ledgerRepository) Migration() error {
+	return r.db.AutoMigrate(JournalEntry{}, Posting{})
+}
+
+func (r *ledgerRepository) WithTx(tx *gorm.DB) ILedgerRepository {
+	return NewLedgerRepository(tx)
+}
+
+// CreateJournalEntry inserts the entry together with its postings
+func (r *ledgerRepository) CreateJournalEntry(entry JournalEntry) (*JournalEntry, error) {
+	if err := r.db.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *ledgerRepository) GetUserBalance(userId uint, currencyCode string) (decimal.Decimal, error) {
+	var balance decimal.Decimal
+	if err := r.db.Model(&Posting{}).
+		Select("COALESCE(SUM("+signedAmountSql+"), 0)").
+		Where("account_kind =?", AccountKindUser).
+		Where("user_id =?", userId).
+		Where("currency_code =?", currencyCode).
+		Row().Scan(&balance); err != nil {
+		return decimal.Zero, err
+	}
+	return balance, nil
+}
+
+// GetUserBalanceBefore sums the postings of the user account made before the given time
+func (r *ledgerRepository) GetUserBalanceBefore(userId uint, currencyCode string, before time.Time) (decimal.Decimal, error) {
+	var balance decimal.Decimal
+	if err := r.db.Model(&Posting{}).
+		Select("COALESCE(SUM("+signedAmountSql+"), 0)").
+		Where("account_kind =?", AccountKindUser).
+		Where("user_id =?", userId).
+		Where("currency_code =?", currencyCode).
+		Where("created_at <?", before).
+		Row().Scan(&balance); err != nil {
+		return decimal.Zero, err
+	}
+	return balance, nil
+}
+
+func (r *ledgerRepository) ListUserBalances() ([]UserBalance, error) {
+	var balances []UserBalance
+	if err := r.db.Model(&Posting{}).
+		Select("user_id, currency_code, SUM("+signedAmountSql+") AS balance").
+		Where("account_kind =?", AccountKindUser).
+		Group("user_id, currency_code").
+		Scan(&balances).Error; err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *ledgerRepository) ListUserPostings(filter UserPostingFilter) ([]UserPosting, error) {
+	query := r.db.Model(&Posting{}).
+		Select("postings.id, postings.journal_entry_id AS entry_id, journal_entries.type AS entry_type, journal_entries.reference_type, journal_entries.reference_id, postings.currency_code, postings.direction, postings.amount, postings.created_at").
+		Joins("JOIN journal_entries ON journal_entries.id = postings.journal_entry_id").
+		Where("postings.account_kind =?", AccountKindUser).
+		Where("postings.user_id =?", filter.UserId)
+
+	if filter.CurrencyCode != "" {
+		query = query.Where("postings.currency_code =?", filter.CurrencyCode)
+	}
+
+	if rule, ok := transactionTypeRules[filter.TransactionType]; ok {
+		query = query.Where("journal_entries.type =?", rule.entryType)
+		if rule.direction != "" {
+			query = query.Where("postings.direction =?", rule.direction)
+		}
+	}
+
+	if filter.Start != nil {
+		query = query.Where("postings.created_at >=?", *filter.Start)
+	}
+
+	if filter.End != nil {
+		query = query.Where("postings.created_at <?", *filter.End)
+	}
+
+	if filter.BeforeId > 0 {
+		query = query.Where("postings.id <?", filter.BeforeId)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var postings []UserPosting
+	if err := query.Order("postings.id DESC").Scan(&postings).Error; err != nil {
+		return nil, err
+	}
+	return postings, nil
+}
@@ -0,0 +1,106 @@
+package ledger
+
+import (
+	// Go imports
+	"regexp"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+)
+
+func TestLedgerRepository_GetUserBalance(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewLedgerRepository(db)
+	userId := uint(1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(CASE WHEN direction = 'CREDIT' THEN amount ELSE -amount END), 0) FROM "postings" WHERE account_kind =$1 AND user_id =$2 AND currency_code =$3`)).
+		WithArgs(AccountKindUser, userId, "TRY").
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("9900.00"))
+
+	balance, err := r.GetUserBalance(userId, "TRY")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.True(t, balance.Equal(decimal.NewFromInt(9900)))
+}
+
+func TestLedgerRepository_GetUserBalanceBefore(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewLedgerRepository(db)
+	userId := uint(1)
+	before := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(CASE WHEN direction = 'CREDIT' THEN amount ELSE -amount END), 0) FROM "postings" WHERE account_kind =$1 AND user_id =$2 AND currency_code =$3 AND created_at <$4`)).
+		WithArgs(AccountKindUser, userId, "TRY", before).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("400.00"))
+
+	balance, err := r.GetUserBalanceBefore(userId, "TRY", before)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.True(t, balance.Equal(decimal.NewFromInt(400)))
+}
+
+func TestLedgerRepository_ListUserBalances(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewLedgerRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, currency_code, SUM(CASE WHEN direction = 'CREDIT' THEN amount ELSE -amount END) AS balance FROM "postings" WHERE account_kind =$1 GROUP BY user_id, currency_code`)).
+		WithArgs(AccountKindUser).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "currency_code", "balance"}).
+			AddRow(1, "TRY", "9900.00").
+			AddRow(1, "USD", "5.40"))
+
+	balances, err := r.ListUserBalances()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, balances, 2)
+	assert.True(t, balances[1].Balance.Equal(decimal.RequireFromString("5.4")))
+}
+
+func TestLedgerRepository_ListUserPostings(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewLedgerRepository(db)
+	userId := uint(1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT postings.id, postings.journal_entry_id AS entry_id, journal_entries.type AS entry_type, journal_entries.reference_type, journal_entries.reference_id, postings.currency_code, postings.direction, postings.amount, postings.created_at FROM "postings" JOIN journal_entries ON journal_entries.id = postings.journal_entry_id WHERE postings.account_kind =$1 AND postings.user_id =$2 AND postings.currency_code =$3 AND journal_entries.type =$4 AND postings.direction =$5 AND postings.id <$6 ORDER BY postings.id DESC LIMIT 3`)).
+		WithArgs(AccountKindUser, userId, "TRY", EntryTypeConversion, DirectionDebit, uint(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entry_id", "entry_type", "reference_type", "reference_id", "currency_code", "direction", "amount"}).
+			AddRow(8, 4, EntryTypeConversion, ReferenceTypeOffer, 2, "TRY", DirectionDebit, "100.00"))
+
+	postings, err := r.ListUserPostings(UserPostingFilter{
+		UserId:          userId,
+		CurrencyCode:    "TRY",
+		TransactionType: TransactionTypeConversionDebit,
+		BeforeId:        10,
+		Limit:           3,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, postings, 1)
+	assert.Equal(t, TransactionTypeConversionDebit, postings[0].TransactionType())
+	assert.True(t, postings[0].SignedAmount().Equal(decimal.NewFromInt(-100)))
+}
+
+func TestLedgerRepository_ListUserPostings_WithoutLimit(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewLedgerRepository(db)
+	userId := uint(1)
+	start := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT postings.id, postings.journal_entry_id AS entry_id, journal_entries.type AS entry_type, journal_entries.reference_type, journal_entries.reference_id, postings.currency_code, postings.direction, postings.amount, postings.created_at FROM "postings" JOIN journal_entries ON journal_entries.id = postings.journal_entry_id WHERE postings.account_kind =$1 AND postings.user_id =$2 AND postings.currency_code =$3 AND postings.created_at >=$4 AND postings.created_at <$5 ORDER BY postings.id DESC`)).
+		WithArgs(AccountKindUser, userId, "TRY", start, end).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entry_id", "entry_type", "currency_code", "direction", "amount"}).
+			AddRow(8, 4, EntryTypeDeposit, "TRY", DirectionCredit, "100.00"))
+
+	postings, err := r.ListUserPostings(UserPostingFilter{UserId: userId, CurrencyCode: "TRY", Start: &start, End: &end})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, postings, 1)
+}
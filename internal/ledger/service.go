@@ -0,0 +1,93 @@
+package ledger
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+)
+
+var ErrUnbalancedEntry = errors.New("journal entry is not balanced")
+
+type ILedgerService interface {
+	Record(entry JournalEntry) (*JournalEntry, error)
+	GetUserBalance(userId uint, currencyCode string) (decimal.Decimal, error)
+	GetUserBalanceBefore(userId uint, currencyCode string, before time.Time) (decimal.Decimal, error)
+	ListUserBalances() ([]UserBalance, error)
+	ListUserPostings(filter UserPostingFilter) ([]UserPosting, error)
+	WithTx(tx *gorm.DB) ILedgerService
+}
+
+type ledgerService struct {
+	ledgerRepo ILedgerRepository
+	clock      clock.IClock
+}
+
+func NewLedgerService(ledgerRepository ILedgerRepository, clock clock.IClock) ILedgerService {
+	return &ledgerService{ledgerRepo: ledgerRepository, clock: clock}
+}
+
+func (s *ledgerService) WithTx(tx *gorm.DB) ILedgerService {
+	return &ledgerService{ledgerRepo: s.ledgerRepo.WithTx(tx), clock: s.clock}
+}
+
+// Record validates and stores the entry, debits and credits have to be equal on every currency
+func (s *ledgerService) Record(entry JournalEntry) (*JournalEntry, error) {
+	if len(entry.Postings) < 2 {
+		return nil, fmt.Errorf("%w: at least two postings are required", ErrUnbalancedEntry)
+	}
+
+	now := s.clock.Now()
+	totals := make(map[string]decimal.Decimal)
+	for i := range entry.Postings {
+		posting := &entry.Postings[i]
+		posting.CurrencyCode = strings.ToUpper(posting.CurrencyCode)
+		posting.Amount = currency.Round(posting.Amount, posting.CurrencyCode)
+		posting.CreatedAt = now
+
+		if !posting.Amount.IsPositive() {
+			return nil, fmt.Errorf("%w: posting amount must be positive", ErrUnbalancedEntry)
+		}
+
+		if posting.AccountKind != AccountKindUser && posting.UserId != 0 {
+			return nil, fmt.Errorf("%w: house accounts can not belong to a user", ErrUnbalancedEntry)
+		}
+
+		totals[posting.CurrencyCode] = totals[posting.CurrencyCode].Add(posting.SignedAmount())
+	}
+
+	for currencyCode, total := range totals {
+		if !total.IsZero() {
+			return nil, fmt.Errorf("%w: %s is off by %s", ErrUnbalancedEntry, currencyCode, total.String())
+		}
+	}
+
+	entry.CreatedAt = now
+	return s.ledgerRepo.CreateJournalEntry(entry)
+}
+
+func (s *ledgerService) GetUserBalance(userId uint, currencyCode string) (decimal.Decimal, error) {
+	return s.ledgerRepo.GetUserBalance(userId, strings.ToUpper(currencyCode))
+}
+
+func (s *ledgerService) GetUserBalanceBefore(userId uint, currencyCode string, before time.Time) (decimal.Decimal, error) {
+	return s.ledgerRepo.GetUserBalanceBefore(userId, strings.ToUpper(currencyCode), before)
+}
+
+func (s *ledgerService) ListUserBalances() ([]UserBalance, error) {
+	return s.ledgerRepo.ListUserBalances()
+}
+
+func (s *ledgerService) ListUserPostings(filter UserPostingFilter) ([]UserPosting, error) {
+	filter.CurrencyCode = strings.ToUpper(filter.CurrencyCode)
+	return s.ledgerRepo.ListUserPostings(filter)
+}
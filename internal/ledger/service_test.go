@@ -0,0 +1,78 @@
+package ledger
+
+import (
+	// Go imports
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+)
+
+func TestLedgerService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLedgerRepository := NewMockILedgerRepository(ctrl)
+	mockClock := clock.NewMockIClock(ctrl)
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now).AnyTimes()
+	ledgerService := NewLedgerService(mockLedgerRepository, mockClock)
+	userId := uint(1)
+
+	t.Run("single posting", func(t *testing.T) {
+		entry := JournalEntry{
+			Type:     EntryTypeConversion,
+			UserId:   userId,
+			Postings: []Posting{{AccountKind: AccountKindUser, UserId: userId, CurrencyCode: "TRY", Direction: DirectionCredit, Amount: decimal.NewFromInt(10)}},
+		}
+		_, err := ledgerService.Record(entry)
+		assert.ErrorIs(t, err, ErrUnbalancedEntry)
+	})
+
+	t.Run("debits and credits differ on one currency", func(t *testing.T) {
+		entry := NewConversionEntry(userId, 1, "TRY", "USD", decimal.NewFromInt(100), decimal.RequireFromString("5.40"))
+		entry.Postings[1].Amount = decimal.NewFromInt(99)
+		_, err := ledgerService.Record(entry)
+		assert.ErrorIs(t, err, ErrUnbalancedEntry)
+	})
+
+	t.Run("house account with user", func(t *testing.T) {
+		entry := NewRegistrationBonusEntry(userId, 1, "TRY", decimal.NewFromInt(10000), "")
+		entry.Postings[0].UserId = userId
+		_, err := ledgerService.Record(entry)
+		assert.ErrorIs(t, err, ErrUnbalancedEntry)
+	})
+
+	t.Run("amount rounds to zero", func(t *testing.T) {
+		entry := NewConversionEntry(userId, 1, "TRY", "USD", decimal.NewFromInt(1), decimal.RequireFromString("0.001"))
+		_, err := ledgerService.Record(entry)
+		assert.ErrorIs(t, err, ErrUnbalancedEntry)
+	})
+
+	t.Run("balanced conversion is recorded", func(t *testing.T) {
+		entry := NewConversionEntry(userId, 1, "try", "usd", decimal.NewFromInt(100), decimal.RequireFromString("5.404"))
+		mockLedgerRepository.EXPECT().CreateJournalEntry(gomock.Any()).DoAndReturn(func(recorded JournalEntry) (*JournalEntry, error) {
+			assert.Len(t, recorded.Postings, 4)
+			for _, posting := range recorded.Postings {
+				assert.Contains(t, []string{"TRY", "USD"}, posting.CurrencyCode)
+			}
+			assert.True(t, recorded.Postings[3].Amount.Equal(decimal.RequireFromString("5.40")))
+			assert.Equal(t, now, recorded.CreatedAt)
+			assert.Equal(t, now, recorded.Postings[0].CreatedAt)
+			return &recorded, nil
+		})
+		_, err := ledgerService.Record(entry)
+		assert.Nil(t, err)
+	})
+}
+
+func TestPosting_SignedAmount(t *testing.T) {
+	debit := Posting{Direction: DirectionDebit, Amount: decimal.NewFromInt(5)}
+	credit := Posting{Direction: DirectionCredit, Amount: decimal.NewFromInt(5)}
+	assert.True(t, debit.SignedAmount().Equal(decimal.NewFromInt(-5)))
+	assert.True(t, credit.SignedAmount().Equal(decimal.NewFromInt(5)))
+}
@@ -0,0 +1,52 @@
+package onboarding
+
+import (
+	// Go imports
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultExpiryInterval is used when no expiry interval is configured
+const DefaultExpiryInterval = time.Hour
+
+// Expirer claws back the unspent credit of the expired grants on every interval
+type Expirer struct {
+	onboardingService IOnboardingService
+	interval          time.Duration
+	done              chan struct{}
+}
+
+func NewExpirer(onboardingService IOnboardingService, interval time.Duration) *Expirer {
+	if interval <= 0 {
+		interval = DefaultExpiryInterval
+	}
+	return &Expirer{onboardingService: onboardingService, interval: interval, done: make(chan struct{})}
+}
+
+// Start expires the grants on every interval until the context is done, an expiry in progress is finished first
+func (e *Expirer) Start(ctx context.Context) {
+	go func() {
+		defer close(e.done)
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if _, err := e.onboardingService.ExpireGrants(); err != nil {
+				log.Println("onboarding credit expiry:", err.Error())
+			}
+		}
+	}()
+}
+
+// Done is closed once the expirer stopped after its context is done
+func (e *Expirer) Done() <-chan struct{} {
+	return e.done
+}
@@ -0,0 +1,73 @@
+package onboarding
+
+import (
+	// Go imports
+	"net/http"
+	"strconv"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+)
+
+type Handler interface {
+	ClawBack(c *gin.Context)
+	OnboardingRoutes(router *gin.RouterGroup)
+}
+
+type onboardingHandler struct {
+	onboardingService IOnboardingService
+}
+
+func NewOnboardingHandler(onboardingService IOnboardingService) Handler {
+	return &onboardingHandler{onboardingService: onboardingService}
+}
+
+func (h *onboardingHandler) OnboardingRoutes(router *gin.RouterGroup) {
+	router.POST("/grants/:id/clawback", h.ClawBack)
+}
+
+// ClawBack godoc
+// @Summary Claw Back Onboarding Credit
+// @Description Take the unspent onboarding credit of a user back right away, the credit the user already spent is not taken back
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of an admin user."
+// @Param id path int true "ID of the grant"
+// @Success 200 {object} helper.Response{data=GrantResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Conflict"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /admin/onboarding/grants/{id}/clawback [post]
+func (h *onboardingHandler) ClawBack(c *gin.Context) {
+	grantId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrOnboardingError.Error(), "invalid grant id")
+		return
+	}
+
+	grant, err := h.onboardingService.ClawBack(uint(grantId))
+	if err != nil {
+		onboardingError(c, err)
+		return
+	}
+
+	helper.Success(c, grant)
+}
+
+func onboardingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrGrantNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrGrantClosed):
+		helper.Error(c, http.StatusConflict, errors.ErrOnboardingClosedError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrOnboardingError.Error(), err.Error())
+	}
+}
@@ -0,0 +1,52 @@
+package onboarding
+
+import (
+	// Go imports
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnboardingHandler_ClawBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOnboardingService := NewMockIOnboardingService(ctrl)
+	httpHandler := NewOnboardingHandler(mockOnboardingService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	httpHandler.OnboardingRoutes(router.Group("/admin/onboarding"))
+
+	clawBack := func(id string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/admin/onboarding/grants/"+id+"/clawback", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("invalid grant id", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, clawBack("abc").Code)
+	})
+
+	t.Run("clawed back", func(t *testing.T) {
+		mockOnboardingService.EXPECT().ClawBack(uint(3)).Return(&GrantResponse{Id: 3, Status: GrantStatusClawedBack, ClawedBackAmount: decimal.NewFromInt(1200)}, nil)
+		w := clawBack("3")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"CLAWED_BACK"`)
+	})
+
+	t.Run("clawed back already", func(t *testing.T) {
+		mockOnboardingService.EXPECT().ClawBack(uint(3)).Return(nil, fmt.Errorf("%w: grant 3 is EXPIRED", ErrGrantClosed))
+		assert.Equal(t, http.StatusConflict, clawBack("3").Code)
+	})
+
+	t.Run("grant not found", func(t *testing.T) {
+		mockOnboardingService.EXPECT().ClawBack(uint(4)).Return(nil, fmt.Errorf("%w: 4", ErrGrantNotFound))
+		assert.Equal(t, http.StatusNotFound, clawBack("4").Code)
+	})
+}
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/onboarding (interfaces: IOnboardingRepository)
+
+// Package onboarding is a generated GoMock package.
+package onboarding
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockIOnboardingRepository is a mock of IOnboardingRepository interface.
+type MockIOnboardingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOnboardingRepositoryMockRecorder
+}
+
+// MockIOnboardingRepositoryMockRecorder is the mock recorder for MockIOnboardingRepository.
+type MockIOnboardingRepositoryMockRecorder struct {
+	mock *MockIOnboardingRepository
+}
+
+// NewMockIOnboardingRepository creates a new mock instance.
+func NewMockIOnboardingRepository(ctrl *gomock.Controller) *MockIOnboardingRepository {
+	mock := &MockIOnboardingRepository{ctrl: ctrl}
+	mock.recorder = &MockIOnboardingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOnboardingRepository) EXPECT() *MockIOnboardingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateGrant mocks base method.
+func (m *MockIOnboardingRepository) CreateGrant(arg0 Grant) (*Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGrant", arg0)
+	ret0, _ := ret[0].(*Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGrant indicates an expected call of CreateGrant.
+func (mr *MockIOnboardingRepositoryMockRecorder) CreateGrant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGrant", reflect.TypeOf((*MockIOnboardingRepository)(nil).CreateGrant), arg0)
+}
+
+// GetCampaignUsage mocks base method.
+func (m *MockIOnboardingRepository) GetCampaignUsage(arg0 string) (*CampaignUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignUsage", arg0)
+	ret0, _ := ret[0].(*CampaignUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignUsage indicates an expected call of GetCampaignUsage.
+func (mr *MockIOnboardingRepositoryMockRecorder) GetCampaignUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignUsage", reflect.TypeOf((*MockIOnboardingRepository)(nil).GetCampaignUsage), arg0)
+}
+
+// GetGrantForUpdate mocks base method.
+func (m *MockIOnboardingRepository) GetGrantForUpdate(arg0 uint) (*Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrantForUpdate", arg0)
+	ret0, _ := ret[0].(*Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrantForUpdate indicates an expected call of GetGrantForUpdate.
+func (mr *MockIOnboardingRepositoryMockRecorder) GetGrantForUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrantForUpdate", reflect.TypeOf((*MockIOnboardingRepository)(nil).GetGrantForUpdate), arg0)
+}
+
+// ListExpiredGrants mocks base method.
+func (m *MockIOnboardingRepository) ListExpiredGrants(arg0 time.Time, arg1 int) ([]Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredGrants", arg0, arg1)
+	ret0, _ := ret[0].([]Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredGrants indicates an expected call of ListExpiredGrants.
+func (mr *MockIOnboardingRepositoryMockRecorder) ListExpiredGrants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredGrants", reflect.TypeOf((*MockIOnboardingRepository)(nil).ListExpiredGrants), arg0, arg1)
+}
+
+// LockCampaignUsage mocks base method.
+func (m *MockIOnboardingRepository) LockCampaignUsage(arg0 string) (*CampaignUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCampaignUsage", arg0)
+	ret0, _ := ret[0].(*CampaignUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCampaignUsage indicates an expected call of LockCampaignUsage.
+func (mr *MockIOnboardingRepositoryMockRecorder) LockCampaignUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCampaignUsage", reflect.TypeOf((*MockIOnboardingRepository)(nil).LockCampaignUsage), arg0)
+}
+
+// Migration mocks base method.
+func (m *MockIOnboardingRepository) Migration() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migration")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migration indicates an expected call of Migration.
+func (mr *MockIOnboardingRepositoryMockRecorder) Migration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockIOnboardingRepository)(nil).Migration))
+}
+
+// UpdateCampaignUsage mocks base method.
+func (m *MockIOnboardingRepository) UpdateCampaignUsage(arg0 CampaignUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaignUsage", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCampaignUsage indicates an expected call of UpdateCampaignUsage.
+func (mr *MockIOnboardingRepositoryMockRecorder) UpdateCampaignUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaignUsage", reflect.TypeOf((*MockIOnboardingRepository)(nil).UpdateCampaignUsage), arg0)
+}
+
+// UpdateGrant mocks base method.
+func (m *MockIOnboardingRepository) UpdateGrant(arg0 Grant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGrant", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGrant indicates an expected call of UpdateGrant.
+func (mr *MockIOnboardingRepositoryMockRecorder) UpdateGrant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGrant", reflect.TypeOf((*MockIOnboardingRepository)(nil).UpdateGrant), arg0)
+}
+
+// WithTx mocks base method.
+func (m *MockIOnboardingRepository) WithTx(arg0 *gorm.DB) IOnboardingRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IOnboardingRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIOnboardingRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIOnboardingRepository)(nil).WithTx), arg0)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/onboarding (interfaces: IOnboardingService)
+
+// Package onboarding is a generated GoMock package.
+package onboarding
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockIOnboardingService is a mock of IOnboardingService interface.
+type MockIOnboardingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOnboardingServiceMockRecorder
+}
+
+// MockIOnboardingServiceMockRecorder is the mock recorder for MockIOnboardingService.
+type MockIOnboardingServiceMockRecorder struct {
+	mock *MockIOnboardingService
+}
+
+// NewMockIOnboardingService creates a new mock instance.
+func NewMockIOnboardingService(ctrl *gomock.Controller) *MockIOnboardingService {
+	mock := &MockIOnboardingService{ctrl: ctrl}
+	mock.recorder = &MockIOnboardingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOnboardingService) EXPECT() *MockIOnboardingServiceMockRecorder {
+	return m.recorder
+}
+
+// ClawBack mocks base method.
+func (m *MockIOnboardingService) ClawBack(arg0 uint) (*GrantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClawBack", arg0)
+	ret0, _ := ret[0].(*GrantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClawBack indicates an expected call of ClawBack.
+func (mr *MockIOnboardingServiceMockRecorder) ClawBack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClawBack", reflect.TypeOf((*MockIOnboardingService)(nil).ClawBack), arg0)
+}
+
+// ExpireGrants mocks base method.
+func (m *MockIOnboardingService) ExpireGrants() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireGrants")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireGrants indicates an expected call of ExpireGrants.
+func (mr *MockIOnboardingServiceMockRecorder) ExpireGrants() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireGrants", reflect.TypeOf((*MockIOnboardingService)(nil).ExpireGrants))
+}
+
+// GrantOnboardingCredit mocks base method.
+func (m *MockIOnboardingService) GrantOnboardingCredit(arg0 uint, arg1, arg2 string) (*GrantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantOnboardingCredit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*GrantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantOnboardingCredit indicates an expected call of GrantOnboardingCredit.
+func (mr *MockIOnboardingServiceMockRecorder) GrantOnboardingCredit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantOnboardingCredit", reflect.TypeOf((*MockIOnboardingService)(nil).GrantOnboardingCredit), arg0, arg1, arg2)
+}
+
+// ValidateCampaignCode mocks base method.
+func (m *MockIOnboardingService) ValidateCampaignCode(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCampaignCode", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCampaignCode indicates an expected call of ValidateCampaignCode.
+func (mr *MockIOnboardingServiceMockRecorder) ValidateCampaignCode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCampaignCode", reflect.TypeOf((*MockIOnboardingService)(nil).ValidateCampaignCode), arg0)
+}
+
+// WithTx mocks base method.
+func (m *MockIOnboardingService) WithTx(arg0 *gorm.DB) IOnboardingService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IOnboardingService)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIOnboardingServiceMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIOnboardingService)(nil).WithTx), arg0)
+}
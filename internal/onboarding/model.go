@@ -0,0 +1,57 @@
+package onboarding
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+)
+
+// GrantStatus is the lifecycle state of an onboarding credit
+type GrantStatus string
+
+const (
+	GrantStatusActive     GrantStatus = "ACTIVE"      // Credited, may still be clawed back
+	GrantStatusExpired    GrantStatus = "EXPIRED"     // Unspent part was clawed back at expiry
+	GrantStatusClawedBack GrantStatus = "CLAWED_BACK" // Unspent part was clawed back by an admin
+)
+
+// Grant is the onboarding credit of a user, every user is credited once
+type Grant struct {
+	Id                    uint            `gorm:"primaryKey;autoIncrement"`
+	UserId                uint            `gorm:"not null;uniqueIndex"`
+	CampaignCode          string          `gorm:"index"` // Empty for the credit of users registering without a code
+	CurrencyCode          string          `gorm:"not null"`
+	Amount                decimal.Decimal `gorm:"type:numeric;not null"` // Credited amount in currency
+	ReferenceCurrencyCode string          `gorm:"not null"`
+	ReferenceAmount       decimal.Decimal `gorm:"type:numeric;not null"` // Credit of the policy in reference currency
+	ExchangeRate          decimal.Decimal `gorm:"type:numeric;not null"` // Mid rate from reference currency to currency
+	Status                GrantStatus     `gorm:"type:varchar(16);not null;index"`
+	ExpiresAt             *time.Time      `gorm:"index"` // Unspent credit is clawed back after this, never when empty
+	ClawedBackAmount      decimal.Decimal `gorm:"type:numeric;not null;default:0"`
+	ClawedBackAt          *time.Time
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// CampaignUsage counts the credit a campaign granted so its caps hold across instances of the service
+type CampaignUsage struct {
+	Code            string          `gorm:"primaryKey"`
+	Grants          int             `gorm:"not null;default:0"`
+	ReferenceAmount decimal.Decimal `gorm:"type:numeric;not null;default:0"`
+	UpdatedAt       time.Time
+}
+
+type GrantResponse struct {
+	Id               uint            `json:"id" extensions:"x-order=1" example:"3"`                                            // ID of the grant
+	UserId           uint            `json:"user_id" extensions:"x-order=2" example:"7"`                                       // ID of the credited user
+	CampaignCode     string          `json:"campaign_code,omitempty" extensions:"x-order=3" example:"WELCOME"`                 // Campaign the credit was granted by
+	CurrencyCode     string          `json:"currency_code" extensions:"x-order=4" example:"TRY"`                               // Currency of the credit
+	Amount           decimal.Decimal `json:"amount" extensions:"x-order=5" swaggertype:"string" example:"1863.00"`             // Credited amount
+	Status           GrantStatus     `json:"status" extensions:"x-order=6" swaggertype:"string" example:"ACTIVE"`              // ACTIVE, EXPIRED or CLAWED_BACK
+	ExpiresAt        *time.Time      `json:"expires_at,omitempty" extensions:"x-order=7" example:"2022-12-31T10:00:00Z"`       // Unspent credit is clawed back after this
+	ClawedBackAmount decimal.Decimal `json:"clawed_back_amount" extensions:"x-order=8" swaggertype:"string" example:"1200.00"` // Amount taken back from the user
+	ClawedBackAt     *time.Time      `json:"clawed_back_at,omitempty" extensions:"x-order=9" example:"2022-12-31T10:00:00Z"`   // Time the credit was clawed back
+	CreatedAt        time.Time       `json:"created_at" extensions:"x-order=10" example:"2022-12-01T10:00:00Z"`                // Time the credit was granted
+}
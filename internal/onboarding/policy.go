@@ -0,0 +1,152 @@
+package onboarding
+
+import (
+	// Go imports
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+)
+
+// Policy decides the onboarding credit of new users. Amounts are in the reference currency and converted
+// into the currency the user registers with at the mid rate, so every user gets the same value.
+//
+// The policy file looks like
+//
+//	{
+//	  "reference_currency_code": "USD",
+//	  "amount": "100.00",
+//	  "expires_after": "720h",
+//	  "campaigns": [
+//	    {"code": "WELCOME", "amount": "250.00", "ends_at": "2023-12-31T23:59:59Z", "max_grants": 1000, "budget": "200000"}
+//	  ]
+//	}
+type Policy struct {
+	ReferenceCurrencyCode string          `json:"reference_currency_code"`
+	Amount                decimal.Decimal `json:"amount"`        // Credit of users registering without a campaign code, zero for none
+	ExpiresAfter          Duration        `json:"expires_after"` // Unspent credit is clawed back after this, never when empty
+	Campaigns             []Campaign      `json:"campaigns"`
+}
+
+// Campaign grants its own credit to the users registering with its code
+type Campaign struct {
+	Code         string          `json:"code"`
+	Amount       decimal.Decimal `json:"amount"`
+	StartsAt     *time.Time      `json:"starts_at"`     // Code can not be used before this, from the start when empty
+	EndsAt       *time.Time      `json:"ends_at"`       // Code can not be used after this, forever when empty
+	MaxGrants    int             `json:"max_grants"`    // Number of users the campaign credits at most, unlimited when zero
+	Budget       decimal.Decimal `json:"budget"`        // Total credit in reference currency, unlimited when zero
+	ExpiresAfter *Duration       `json:"expires_after"` // Overrides the expiry of the policy
+}
+
+// Duration reads durations such as 720h from JSON
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == "" {
+		d.Duration = 0
+		return nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	d.Duration = duration
+	return nil
+}
+
+// LoadPolicy reads the policy file, no onboarding credit is granted when there is no file configured
+func LoadPolicy(path string) (*Policy, error) {
+	if path == "" {
+		return &Policy{}, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Policy
+	if err = json.Unmarshal(content, &policy); err != nil {
+		return nil, fmt.Errorf("can not parse onboarding policy file %s: %w", path, err)
+	}
+
+	if err = policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid onboarding policy file %s: %w", path, err)
+	}
+
+	return &policy, nil
+}
+
+func (p *Policy) validate() error {
+	p.ReferenceCurrencyCode = strings.ToUpper(p.ReferenceCurrencyCode)
+	if p.ReferenceCurrencyCode == "" && (p.Amount.IsPositive() || len(p.Campaigns) > 0) {
+		return errors.New("reference currency code is required")
+	}
+
+	if p.Amount.IsNegative() || p.ExpiresAfter.Duration < 0 {
+		return errors.New("amount and expiry can not be negative")
+	}
+
+	codes := make(map[string]bool, len(p.Campaigns))
+	for i := range p.Campaigns {
+		campaign := &p.Campaigns[i]
+		campaign.Code = strings.ToUpper(strings.TrimSpace(campaign.Code))
+		if campaign.Code == "" {
+			return errors.New("campaign code is required")
+		}
+
+		if codes[campaign.Code] {
+			return fmt.Errorf("campaign %s is defined more than once", campaign.Code)
+		}
+		codes[campaign.Code] = true
+
+		if !campaign.Amount.IsPositive() {
+			return fmt.Errorf("amount of campaign %s must be positive", campaign.Code)
+		}
+
+		if campaign.MaxGrants < 0 || campaign.Budget.IsNegative() || (campaign.ExpiresAfter != nil && campaign.ExpiresAfter.Duration < 0) {
+			return fmt.Errorf("caps and expiry of campaign %s can not be negative", campaign.Code)
+		}
+	}
+
+	return nil
+}
+
+// campaign finds the campaign of a code, codes are not case sensitive
+func (p *Policy) campaign(code string) (*Campaign, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for i := range p.Campaigns {
+		if p.Campaigns[i].Code == code {
+			return &p.Campaigns[i], true
+		}
+	}
+	return nil, false
+}
+
+// isActive reports whether the code of the campaign can be used at given time
+func (c Campaign) isActive(now time.Time) bool {
+	return (c.StartsAt == nil || !now.Before(*c.StartsAt)) && (c.EndsAt == nil || now.Before(*c.EndsAt))
+}
+
+// expiresAfter is the time the credit of the campaign is kept, zero when it never expires
+func (c Campaign) expiresAfter(policy Policy) time.Duration {
+	if c.ExpiresAfter != nil {
+		return c.ExpiresAfter.Duration
+	}
+	return policy.ExpiresAfter.Duration
+}
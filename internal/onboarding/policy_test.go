@@ -0,0 +1,77 @@
+package onboarding
+
+import (
+	// Go imports
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePolicyFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "onboarding.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Run("no policy file", func(t *testing.T) {
+		policy, err := LoadPolicy("")
+		assert.Nil(t, err)
+		assert.True(t, policy.Amount.IsZero())
+	})
+
+	t.Run("policy with campaigns", func(t *testing.T) {
+		policy, err := LoadPolicy(writePolicyFile(t, `{
+			"reference_currency_code": "usd",
+			"amount": "100.00",
+			"expires_after": "720h",
+			"campaigns": [{"code": " welcome ", "amount": "250", "max_grants": 10, "expires_after": "48h"}]
+		}`))
+		assert.Nil(t, err)
+		assert.Equal(t, "USD", policy.ReferenceCurrencyCode)
+		assert.True(t, policy.Amount.Equal(decimal.NewFromInt(100)))
+		assert.Equal(t, 720*time.Hour, policy.ExpiresAfter.Duration)
+
+		campaign, ok := policy.campaign("Welcome")
+		assert.True(t, ok)
+		assert.Equal(t, 10, campaign.MaxGrants)
+		assert.Equal(t, 48*time.Hour, campaign.expiresAfter(*policy))
+	})
+
+	t.Run("campaign defined twice", func(t *testing.T) {
+		_, err := LoadPolicy(writePolicyFile(t, `{"reference_currency_code": "USD", "campaigns": [{"code": "A", "amount": "1"}, {"code": "a", "amount": "2"}]}`))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("negative budget", func(t *testing.T) {
+		_, err := LoadPolicy(writePolicyFile(t, `{"reference_currency_code": "USD", "campaigns": [{"code": "A", "amount": "1", "budget": "-5"}]}`))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("credit without reference currency", func(t *testing.T) {
+		_, err := LoadPolicy(writePolicyFile(t, `{"amount": "100"}`))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		_, err := LoadPolicy(writePolicyFile(t, `{"reference_currency_code": "USD", "expires_after": "a month"}`))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCampaign_isActive(t *testing.T) {
+	startsAt := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	campaign := Campaign{Code: "A", StartsAt: &startsAt, EndsAt: &endsAt}
+
+	assert.False(t, campaign.isActive(startsAt.Add(-time.Second)))
+	assert.True(t, campaign.isActive(startsAt))
+	assert.False(t, campaign.isActive(endsAt))
+}
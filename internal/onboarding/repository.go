@@ -0,0 +1,96 @@
+package onboarding
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IOnboardingRepository interface {
+	CreateGrant(grant Grant) (*Grant, error)
+	GetGrantForUpdate(id uint) (*Grant, error)
+	UpdateGrant(grant Grant) error
+	ListExpiredGrants(now time.Time, limit int) ([]Grant, error)
+	GetCampaignUsage(code string) (*CampaignUsage, error)
+	LockCampaignUsage(code string) (*CampaignUsage, error)
+	UpdateCampaignUsage(usage CampaignUsage) error
+	WithTx(tx *gorm.DB) IOnboardingRepository
+	Migration() error
+}
+
+type onboardingRepository struct {
+	db *gorm.DB
+}
+
+func NewOnboardingRepository(db *gorm.DB) IOnboardingRepository {
+	return &onboardingRepository{db: db}
+}
+
+func (r *onboardingRepository) WithTx(tx *gorm.DB) IOnboardingRepository {
+	return NewOnboardingRepository(tx)
+}
+
+func (r *onboardingRepository) Migration() error {
+	return r.db.AutoMigrate(Grant{}, CampaignUsage{})
+}
+
+func (r *onboardingRepository) CreateGrant(grant Grant) (*Grant, error) {
+	if err := r.db.Create(&grant).Error; err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+func (r *onboardingRepository) GetGrantForUpdate(id uint) (*Grant, error) {
+	var grant *Grant
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id =?", id).First(&grant).Error; err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (r *onboardingRepository) UpdateGrant(grant Grant) error {
+	return r.db.Save(&grant).Error
+}
+
+// ListExpiredGrants returns the active grants whose credit expired, oldest expiry first
+func (r *onboardingRepository) ListExpiredGrants(now time.Time, limit int) ([]Grant, error) {
+	var grants []Grant
+	if err := r.db.Where("status =? AND expires_at <= ?", GrantStatusActive, now).Order("expires_at").Limit(limit).Find(&grants).Error; err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// GetCampaignUsage returns the usage of the campaign, a campaign nobody used yet has an empty usage
+func (r *onboardingRepository) GetCampaignUsage(code string) (*CampaignUsage, error) {
+	var usages []CampaignUsage
+	if err := r.db.Where("code =?", code).Limit(1).Find(&usages).Error; err != nil {
+		return nil, err
+	}
+
+	if len(usages) == 0 {
+		return &CampaignUsage{Code: code}, nil
+	}
+	return &usages[0], nil
+}
+
+// LockCampaignUsage locks the usage of the campaign until the surrounding transaction ends, it is created on first use
+func (r *onboardingRepository) LockCampaignUsage(code string) (*CampaignUsage, error) {
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&CampaignUsage{Code: code}).Error; err != nil {
+		return nil, err
+	}
+
+	var usage *CampaignUsage
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code =?", code).First(&usage).Error; err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (r *onboardingRepository) UpdateCampaignUsage(usage CampaignUsage) error {
+	return r.db.Save(&usage).Error
+}
@@ -0,0 +1,41 @@
+package onboarding
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnboardingRepository_ListExpiredGrants(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewOnboardingRepository(db)
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "grants" WHERE status =$1 AND expires_at <= $2 ORDER BY expires_at LIMIT 100`)).
+		WithArgs(GrantStatusActive, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(3, 7, GrantStatusActive))
+
+	grants, err := r.ListExpiredGrants(now, 100)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, grants, 1)
+}
+
+func TestOnboardingRepository_GetCampaignUsage(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewOnboardingRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "campaign_usages" WHERE code =$1 LIMIT 1`)).
+		WithArgs("WELCOME").
+		WillReturnRows(sqlmock.NewRows([]string{"code", "grants"}))
+
+	usage, err := r.GetCampaignUsage("WELCOME")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "WELCOME", usage.Code)
+	assert.Equal(t, 0, usage.Grants)
+}
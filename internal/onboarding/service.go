@@ -0,0 +1,343 @@
+package onboarding
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+// expiredGrantBatchSize bounds the grants clawed back on one run, the rest are left to the next run
+const expiredGrantBatchSize = 100
+
+var (
+	ErrInvalidCampaign   = errors.New("invalid campaign code")
+	ErrCampaignExhausted = errors.New("campaign has no credit left")
+	ErrGrantNotFound     = errors.New("onboarding credit not found")
+	ErrGrantClosed       = errors.New("onboarding credit is already clawed back")
+)
+
+type IOnboardingService interface {
+	ValidateCampaignCode(campaignCode string) error
+	GrantOnboardingCredit(userId uint, currencyCode, campaignCode string) (*GrantResponse, error)
+	ExpireGrants() (int, error)
+	ClawBack(grantId uint) (*GrantResponse, error)
+	WithTx(tx *gorm.DB) IOnboardingService
+}
+
+type onboardingService struct {
+	onboardingRepo IOnboardingRepository
+	policy         Policy
+	rateConverter  exchange.IRateConverter
+	accountService account.IAccountService
+	ledgerService  ledger.ILedgerService
+	unitOfWork     uow.IUnitOfWork
+	clock          clock.IClock
+}
+
+func NewOnboardingService(onboardingRepository IOnboardingRepository, policy Policy, rateConverter exchange.IRateConverter, accountService account.IAccountService, ledgerService ledger.ILedgerService, unitOfWork uow.IUnitOfWork, clock clock.IClock) IOnboardingService {
+	return &onboardingService{onboardingRepo: onboardingRepository, policy: policy, rateConverter: rateConverter, accountService: accountService, ledgerService: ledgerService, unitOfWork: unitOfWork, clock: clock}
+}
+
+func (s *onboardingService) WithTx(tx *gorm.DB) IOnboardingService {
+	return &onboardingService{
+		onboardingRepo: s.onboardingRepo.WithTx(tx),
+		policy:         s.policy,
+		rateConverter:  s.rateConverter,
+		accountService: s.accountService.WithTx(tx),
+		ledgerService:  s.ledgerService.WithTx(tx),
+		unitOfWork:     uow.NewUnitOfWork(tx),
+		clock:          s.clock,
+	}
+}
+
+// ValidateCampaignCode checks a code before the user is registered. The caps are checked again when the credit is granted
+// since the campaign may be used up by other users in between.
+func (s *onboardingService) ValidateCampaignCode(campaignCode string) error {
+	if strings.TrimSpace(campaignCode) == "" {
+		return nil
+	}
+
+	campaign, err := s.activeCampaign(campaignCode)
+	if err != nil {
+		return err
+	}
+
+	usage, err := s.onboardingRepo.GetCampaignUsage(campaign.Code)
+	if err != nil {
+		return err
+	}
+
+	return campaign.checkCaps(*usage, campaign.Amount)
+}
+
+func (s *onboardingService) activeCampaign(campaignCode string) (*Campaign, error) {
+	campaign, ok := s.policy.campaign(campaignCode)
+	if !ok || !campaign.isActive(s.clock.Now()) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCampaign, campaignCode)
+	}
+	return campaign, nil
+}
+
+// GrantOnboardingCredit credits the user's account in the currency through the ledger. The credit of the campaign,
+// or of the policy when there is no code, is converted from the reference currency at the mid rate.
+// Nothing is granted when the policy has no credit.
+func (s *onboardingService) GrantOnboardingCredit(userId uint, currencyCode, campaignCode string) (*GrantResponse, error) {
+	currencyCode = strings.ToUpper(currencyCode)
+	referenceAmount := s.policy.Amount
+	expiresAfter := s.policy.ExpiresAfter.Duration
+
+	var campaign *Campaign
+	if strings.TrimSpace(campaignCode) != "" {
+		var err error
+		if campaign, err = s.activeCampaign(campaignCode); err != nil {
+			return nil, err
+		}
+		referenceAmount = campaign.Amount
+		expiresAfter = campaign.expiresAfter(s.policy)
+	}
+
+	if !referenceAmount.IsPositive() {
+		return nil, nil
+	}
+
+	rate, err := s.rateConverter.GetMidRate(s.policy.ReferenceCurrencyCode, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := currency.Round(referenceAmount.Mul(rate), currencyCode)
+	if !amount.IsPositive() {
+		return nil, nil
+	}
+
+	now := s.clock.Now()
+	grant := Grant{
+		UserId:                userId,
+		CurrencyCode:          currencyCode,
+		Amount:                amount,
+		ReferenceCurrencyCode: s.policy.ReferenceCurrencyCode,
+		ReferenceAmount:       referenceAmount,
+		ExchangeRate:          rate,
+		Status:                GrantStatusActive,
+		ClawedBackAmount:      decimal.Zero,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+
+	if expiresAfter > 0 {
+		expiresAt := now.Add(expiresAfter)
+		grant.ExpiresAt = &expiresAt
+	}
+
+	if campaign != nil {
+		grant.CampaignCode = campaign.Code
+	}
+
+	var created *Grant
+	if err = s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txOnboardingRepo := s.onboardingRepo.WithTx(tx)
+		if campaign != nil {
+			if err := s.useCampaign(txOnboardingRepo, *campaign, referenceAmount, now); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if created, err = txOnboardingRepo.CreateGrant(grant); err != nil {
+			return err
+		}
+
+		_, err = s.accountService.WithTx(tx).ApplyJournalEntry(ledger.NewRegistrationBonusEntry(userId, created.Id, created.CurrencyCode, created.Amount, grantDescription(*created)))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toGrantResponse(*created)
+	return &response, nil
+}
+
+// useCampaign counts the credit against the caps of the campaign, it has to run inside a transaction
+func (s *onboardingService) useCampaign(txOnboardingRepo IOnboardingRepository, campaign Campaign, referenceAmount decimal.Decimal, now time.Time) error {
+	usage, err := txOnboardingRepo.LockCampaignUsage(campaign.Code)
+	if err != nil {
+		return err
+	}
+
+	if err = campaign.checkCaps(*usage, referenceAmount); err != nil {
+		return err
+	}
+
+	usage.Grants++
+	usage.ReferenceAmount = usage.ReferenceAmount.Add(referenceAmount)
+	usage.UpdatedAt = now
+	return txOnboardingRepo.UpdateCampaignUsage(*usage)
+}
+
+// ExpireGrants claws back the unspent credit of the expired grants and returns how many were expired.
+// A grant which can not be clawed back is skipped so it does not hold the others back.
+func (s *onboardingService) ExpireGrants() (int, error) {
+	grants, err := s.onboardingRepo.ListExpiredGrants(s.clock.Now(), expiredGrantBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	var errs []string
+	for _, grant := range grants {
+		if _, err = s.clawBack(grant.Id, GrantStatusExpired); err != nil {
+			// Clawed back by an admin since it was listed
+			if errors.Is(err, ErrGrantClosed) {
+				continue
+			}
+			errs = append(errs, fmt.Sprintf("grant %d: %s", grant.Id, err.Error()))
+			continue
+		}
+		expired++
+	}
+
+	if len(errs) > 0 {
+		return expired, errors.New(strings.Join(errs, "; "))
+	}
+
+	return expired, nil
+}
+
+// ClawBack takes the unspent credit of a grant back right away, e.g. when the credit was abused
+func (s *onboardingService) ClawBack(grantId uint) (*GrantResponse, error) {
+	return s.clawBack(grantId, GrantStatusClawedBack)
+}
+
+// clawBack takes back the part of the credit the user has not spent, spent credit is not taken back
+func (s *onboardingService) clawBack(grantId uint, status GrantStatus) (*GrantResponse, error) {
+	var grant *Grant
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txOnboardingRepo := s.onboardingRepo.WithTx(tx)
+		var err error
+		grant, err = txOnboardingRepo.GetGrantForUpdate(grantId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: %d", ErrGrantNotFound, grantId)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if grant.Status != GrantStatusActive {
+			return fmt.Errorf("%w: grant %d is %s", ErrGrantClosed, grant.Id, grant.Status)
+		}
+
+		txAccountService := s.accountService.WithTx(tx)
+		balances, err := txAccountService.LockUserAccounts(grant.UserId, grant.CurrencyCode)
+		if err != nil {
+			return err
+		}
+
+		unspent, err := unspentCredit(s.ledgerService.WithTx(tx), *grant)
+		if err != nil {
+			return err
+		}
+
+		amount := decimal.Min(unspent, balances[grant.CurrencyCode])
+		if amount.IsPositive() {
+			if _, err = txAccountService.ApplyJournalEntry(ledger.NewBonusClawbackEntry(grant.UserId, grant.Id, grant.CurrencyCode, amount, grantDescription(*grant))); err != nil {
+				return err
+			}
+		} else {
+			amount = decimal.Zero
+		}
+
+		now := s.clock.Now()
+		grant.Status = status
+		grant.ClawedBackAmount = amount
+		grant.ClawedBackAt = &now
+		grant.UpdatedAt = now
+		return txOnboardingRepo.UpdateGrant(*grant)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toGrantResponse(*grant)
+	return &response, nil
+}
+
+// unspentCredit is the lowest balance the account had since the credit was posted, capped by the credit. Money added
+// after the credit was spent is the user's own, so spending the credit and depositing again leaves nothing to take back.
+func unspentCredit(txLedgerService ledger.ILedgerService, grant Grant) (decimal.Decimal, error) {
+	balance, err := txLedgerService.GetUserBalance(grant.UserId, grant.CurrencyCode)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	postings, err := txLedgerService.ListUserPostings(ledger.UserPostingFilter{
+		UserId:       grant.UserId,
+		CurrencyCode: grant.CurrencyCode,
+		Start:        &grant.CreatedAt,
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	// Postings are listed newest first, the balance right after a posting is the current balance less the postings after it
+	lowest := balance
+	postedAfter := decimal.Zero
+	for _, posting := range postings {
+		lowest = decimal.Min(lowest, balance.Sub(postedAfter))
+		if posting.EntryType == ledger.EntryTypeRegistrationBonus && posting.ReferenceType == ledger.ReferenceTypeBonusGrant && posting.ReferenceId == grant.Id {
+			return decimal.Max(decimal.Min(grant.Amount, lowest), decimal.Zero), nil
+		}
+		postedAfter = postedAfter.Add(posting.SignedAmount())
+	}
+
+	// The credit was never posted
+	return decimal.Zero, nil
+}
+
+// checkCaps reports whether the campaign can grant one more credit of the amount
+func (c Campaign) checkCaps(usage CampaignUsage, referenceAmount decimal.Decimal) error {
+	if c.MaxGrants > 0 && usage.Grants >= c.MaxGrants {
+		return fmt.Errorf("%w: %s reached %d users", ErrCampaignExhausted, c.Code, c.MaxGrants)
+	}
+
+	if c.Budget.IsPositive() && usage.ReferenceAmount.Add(referenceAmount).GreaterThan(c.Budget) {
+		return fmt.Errorf("%w: %s budget is used up", ErrCampaignExhausted, c.Code)
+	}
+
+	return nil
+}
+
+func grantDescription(grant Grant) string {
+	if grant.CampaignCode == "" {
+		return "onboarding credit"
+	}
+	return "onboarding credit of campaign " + grant.CampaignCode
+}
+
+func toGrantResponse(grant Grant) GrantResponse {
+	return GrantResponse{
+		Id:               grant.Id,
+		UserId:           grant.UserId,
+		CampaignCode:     grant.CampaignCode,
+		CurrencyCode:     grant.CurrencyCode,
+		Amount:           grant.Amount,
+		Status:           grant.Status,
+		ExpiresAt:        grant.ExpiresAt,
+		ClawedBackAmount: grant.ClawedBackAmount,
+		ClawedBackAt:     grant.ClawedBackAt,
+		CreatedAt:        grant.CreatedAt,
+	}
+}
@@ -0,0 +1,313 @@
+package onboarding
+
+import (
+	// Go imports
+	"errors"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+type onboardingServiceMocks struct {
+	onboardingRepository *MockIOnboardingRepository
+	rateConverter        *exchange.MockIRateConverter
+	accountService       *account.MockIAccountService
+	ledgerService        *ledger.MockILedgerService
+	now                  time.Time
+}
+
+func newTestOnboardingService(t *testing.T, policy Policy) (IOnboardingService, onboardingServiceMocks) {
+	ctrl := gomock.NewController(t)
+	mocks := onboardingServiceMocks{
+		onboardingRepository: NewMockIOnboardingRepository(ctrl),
+		rateConverter:        exchange.NewMockIRateConverter(ctrl),
+		accountService:       account.NewMockIAccountService(ctrl),
+		ledgerService:        ledger.NewMockILedgerService(ctrl),
+		now:                  time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC),
+	}
+	mockClock := clock.NewMockIClock(ctrl)
+	mockClock.EXPECT().Now().Return(mocks.now).AnyTimes()
+	unitOfWork := uow.NewMockIUnitOfWork(ctrl)
+
+	mocks.onboardingRepository.EXPECT().WithTx(gomock.Any()).Return(mocks.onboardingRepository).AnyTimes()
+	mocks.accountService.EXPECT().WithTx(gomock.Any()).Return(mocks.accountService).AnyTimes()
+	mocks.ledgerService.EXPECT().WithTx(gomock.Any()).Return(mocks.ledgerService).AnyTimes()
+	unitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+		return fn(nil)
+	}).AnyTimes()
+
+	return NewOnboardingService(mocks.onboardingRepository, policy, mocks.rateConverter, mocks.accountService, mocks.ledgerService, unitOfWork, mockClock), mocks
+}
+
+func testPolicy() Policy {
+	endsAt := time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)
+	return Policy{
+		ReferenceCurrencyCode: "USD",
+		Amount:                decimal.NewFromInt(100),
+		ExpiresAfter:          Duration{Duration: 30 * 24 * time.Hour},
+		Campaigns: []Campaign{
+			{Code: "WELCOME", Amount: decimal.NewFromInt(250), MaxGrants: 2},
+			{Code: "OVER", Amount: decimal.NewFromInt(250), EndsAt: &endsAt, Budget: decimal.NewFromInt(600)},
+			{Code: "LAUNCH", Amount: decimal.NewFromInt(50), EndsAt: &time.Time{}},
+		},
+	}
+}
+
+func TestOnboardingService_GrantOnboardingCredit(t *testing.T) {
+	userId := uint(7)
+
+	t.Run("credit of the policy converted into the currency", func(t *testing.T) {
+		onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+		mocks.rateConverter.EXPECT().GetMidRate("USD", "TRY").Return(decimal.RequireFromString("18.634"), nil)
+		mocks.onboardingRepository.EXPECT().CreateGrant(gomock.Any()).DoAndReturn(func(grant Grant) (*Grant, error) {
+			assert.True(t, grant.Amount.Equal(decimal.RequireFromString("1863.40")))
+			assert.True(t, grant.ReferenceAmount.Equal(decimal.NewFromInt(100)))
+			assert.Equal(t, GrantStatusActive, grant.Status)
+			assert.Equal(t, mocks.now.Add(30*24*time.Hour), *grant.ExpiresAt)
+			assert.Empty(t, grant.CampaignCode)
+			grant.Id = 3
+			return &grant, nil
+		})
+		mocks.accountService.EXPECT().ApplyJournalEntry(gomock.Any()).DoAndReturn(func(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+			assert.Equal(t, ledger.EntryTypeRegistrationBonus, entry.Type)
+			assert.Equal(t, ledger.ReferenceTypeBonusGrant, entry.ReferenceType)
+			assert.Equal(t, uint(3), entry.ReferenceId)
+			return &entry, nil
+		})
+
+		grant, err := onboardingService.GrantOnboardingCredit(userId, "try", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "TRY", grant.CurrencyCode)
+	})
+
+	t.Run("no credit without a policy", func(t *testing.T) {
+		onboardingService, _ := newTestOnboardingService(t, Policy{})
+		grant, err := onboardingService.GrantOnboardingCredit(userId, "TRY", "")
+		assert.Nil(t, err)
+		assert.Nil(t, grant)
+	})
+
+	t.Run("credit of the campaign", func(t *testing.T) {
+		onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+		mocks.rateConverter.EXPECT().GetMidRate("USD", "USD").Return(decimal.NewFromInt(1), nil)
+		mocks.onboardingRepository.EXPECT().LockCampaignUsage("WELCOME").Return(&CampaignUsage{Code: "WELCOME", Grants: 1, ReferenceAmount: decimal.NewFromInt(250)}, nil)
+		mocks.onboardingRepository.EXPECT().UpdateCampaignUsage(gomock.Any()).DoAndReturn(func(usage CampaignUsage) error {
+			assert.Equal(t, 2, usage.Grants)
+			assert.True(t, usage.ReferenceAmount.Equal(decimal.NewFromInt(500)))
+			return nil
+		})
+		mocks.onboardingRepository.EXPECT().CreateGrant(gomock.Any()).DoAndReturn(func(grant Grant) (*Grant, error) {
+			assert.Equal(t, "WELCOME", grant.CampaignCode)
+			assert.True(t, grant.Amount.Equal(decimal.NewFromInt(250)))
+			return &grant, nil
+		})
+		mocks.accountService.EXPECT().ApplyJournalEntry(gomock.Any()).Return(&ledger.JournalEntry{}, nil)
+
+		grant, err := onboardingService.GrantOnboardingCredit(userId, "USD", "welcome")
+		assert.Nil(t, err)
+		assert.Equal(t, "WELCOME", grant.CampaignCode)
+	})
+
+	t.Run("campaign reached its users", func(t *testing.T) {
+		onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+		mocks.rateConverter.EXPECT().GetMidRate("USD", "USD").Return(decimal.NewFromInt(1), nil)
+		mocks.onboardingRepository.EXPECT().LockCampaignUsage("WELCOME").Return(&CampaignUsage{Code: "WELCOME", Grants: 2}, nil)
+
+		_, err := onboardingService.GrantOnboardingCredit(userId, "USD", "WELCOME")
+		assert.ErrorIs(t, err, ErrCampaignExhausted)
+	})
+
+	t.Run("campaign budget is used up", func(t *testing.T) {
+		onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+		mocks.rateConverter.EXPECT().GetMidRate("USD", "USD").Return(decimal.NewFromInt(1), nil)
+		mocks.onboardingRepository.EXPECT().LockCampaignUsage("OVER").Return(&CampaignUsage{Code: "OVER", Grants: 2, ReferenceAmount: decimal.NewFromInt(500)}, nil)
+
+		_, err := onboardingService.GrantOnboardingCredit(userId, "USD", "OVER")
+		assert.ErrorIs(t, err, ErrCampaignExhausted)
+	})
+
+	t.Run("campaign ended", func(t *testing.T) {
+		onboardingService, _ := newTestOnboardingService(t, testPolicy())
+		_, err := onboardingService.GrantOnboardingCredit(userId, "USD", "LAUNCH")
+		assert.ErrorIs(t, err, ErrInvalidCampaign)
+	})
+
+	t.Run("rate is not available", func(t *testing.T) {
+		onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+		mocks.rateConverter.EXPECT().GetMidRate("USD", "TRY").Return(decimal.Zero, errors.New("rate not found"))
+
+		_, err := onboardingService.GrantOnboardingCredit(userId, "TRY", "")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestOnboardingService_ValidateCampaignCode(t *testing.T) {
+	onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+
+	t.Run("no code", func(t *testing.T) {
+		assert.Nil(t, onboardingService.ValidateCampaignCode(" "))
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		assert.ErrorIs(t, onboardingService.ValidateCampaignCode("SPRING"), ErrInvalidCampaign)
+	})
+
+	t.Run("campaign reached its users", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetCampaignUsage("WELCOME").Return(&CampaignUsage{Code: "WELCOME", Grants: 2}, nil)
+		assert.ErrorIs(t, onboardingService.ValidateCampaignCode("WELCOME"), ErrCampaignExhausted)
+	})
+
+	t.Run("valid code", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetCampaignUsage("WELCOME").Return(&CampaignUsage{Code: "WELCOME"}, nil)
+		assert.Nil(t, onboardingService.ValidateCampaignCode("welcome"))
+	})
+}
+
+// expectLedger lists the postings of the user's account newest first, ending with the credit of the grant
+func (m onboardingServiceMocks) expectLedger(grant Grant, balance decimal.Decimal, postings ...ledger.UserPosting) {
+	postings = append(postings, ledger.UserPosting{
+		EntryType:     ledger.EntryTypeRegistrationBonus,
+		ReferenceType: ledger.ReferenceTypeBonusGrant,
+		ReferenceId:   grant.Id,
+		CurrencyCode:  grant.CurrencyCode,
+		Direction:     ledger.DirectionCredit,
+		Amount:        grant.Amount,
+	})
+	m.ledgerService.EXPECT().GetUserBalance(grant.UserId, grant.CurrencyCode).Return(balance, nil)
+	m.ledgerService.EXPECT().ListUserPostings(ledger.UserPostingFilter{UserId: grant.UserId, CurrencyCode: grant.CurrencyCode, Start: &grant.CreatedAt}).
+		Return(postings, nil)
+}
+
+func posting(entryType ledger.EntryType, direction ledger.Direction, amount int64) ledger.UserPosting {
+	return ledger.UserPosting{EntryType: entryType, CurrencyCode: "TRY", Direction: direction, Amount: decimal.NewFromInt(amount)}
+}
+
+func TestOnboardingService_ClawBack(t *testing.T) {
+	onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+	active := Grant{Id: 3, UserId: 7, CurrencyCode: "TRY", Amount: decimal.NewFromInt(1800), Status: GrantStatusActive}
+
+	t.Run("unspent part of the credit", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(3)).DoAndReturn(func(uint) (*Grant, error) {
+			grant := active
+			return &grant, nil
+		})
+		mocks.accountService.EXPECT().LockUserAccounts(uint(7), "TRY").Return(map[string]decimal.Decimal{"TRY": decimal.NewFromInt(1200)}, nil)
+		mocks.expectLedger(active, decimal.NewFromInt(1200), posting(ledger.EntryTypeConversion, ledger.DirectionDebit, 600))
+		mocks.accountService.EXPECT().ApplyJournalEntry(gomock.Any()).DoAndReturn(func(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+			assert.Equal(t, ledger.EntryTypeBonusClawback, entry.Type)
+			assert.True(t, entry.Postings[0].Amount.Equal(decimal.NewFromInt(1200)))
+			return &entry, nil
+		})
+		mocks.onboardingRepository.EXPECT().UpdateGrant(gomock.Any()).DoAndReturn(func(grant Grant) error {
+			assert.Equal(t, GrantStatusClawedBack, grant.Status)
+			assert.True(t, grant.ClawedBackAmount.Equal(decimal.NewFromInt(1200)))
+			assert.Equal(t, mocks.now, *grant.ClawedBackAt)
+			return nil
+		})
+
+		grant, err := onboardingService.ClawBack(3)
+		assert.Nil(t, err)
+		assert.Equal(t, GrantStatusClawedBack, grant.Status)
+	})
+
+	t.Run("credit spent already", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(3)).DoAndReturn(func(uint) (*Grant, error) {
+			grant := active
+			return &grant, nil
+		})
+		mocks.accountService.EXPECT().LockUserAccounts(uint(7), "TRY").Return(map[string]decimal.Decimal{"TRY": decimal.Zero}, nil)
+		mocks.expectLedger(active, decimal.Zero, posting(ledger.EntryTypeConversion, ledger.DirectionDebit, 1800))
+		mocks.onboardingRepository.EXPECT().UpdateGrant(gomock.Any()).DoAndReturn(func(grant Grant) error {
+			assert.True(t, grant.ClawedBackAmount.IsZero())
+			return nil
+		})
+
+		_, err := onboardingService.ClawBack(3)
+		assert.Nil(t, err)
+	})
+
+	t.Run("deposit after the credit was spent is not taken back", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(3)).DoAndReturn(func(uint) (*Grant, error) {
+			grant := active
+			return &grant, nil
+		})
+		mocks.accountService.EXPECT().LockUserAccounts(uint(7), "TRY").Return(map[string]decimal.Decimal{"TRY": decimal.NewFromInt(1800)}, nil)
+		mocks.expectLedger(active, decimal.NewFromInt(1800),
+			posting(ledger.EntryTypeDeposit, ledger.DirectionCredit, 1800),
+			posting(ledger.EntryTypeConversion, ledger.DirectionDebit, 1800))
+		mocks.onboardingRepository.EXPECT().UpdateGrant(gomock.Any()).DoAndReturn(func(grant Grant) error {
+			assert.True(t, grant.ClawedBackAmount.IsZero())
+			return nil
+		})
+
+		_, err := onboardingService.ClawBack(3)
+		assert.Nil(t, err)
+	})
+
+	t.Run("partly spent credit topped up later", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(3)).DoAndReturn(func(uint) (*Grant, error) {
+			grant := active
+			return &grant, nil
+		})
+		mocks.accountService.EXPECT().LockUserAccounts(uint(7), "TRY").Return(map[string]decimal.Decimal{"TRY": decimal.NewFromInt(2500)}, nil)
+		mocks.expectLedger(active, decimal.NewFromInt(2500),
+			posting(ledger.EntryTypeDeposit, ledger.DirectionCredit, 2000),
+			posting(ledger.EntryTypeTransfer, ledger.DirectionDebit, 1300))
+		mocks.accountService.EXPECT().ApplyJournalEntry(gomock.Any()).DoAndReturn(func(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+			assert.True(t, entry.Postings[0].Amount.Equal(decimal.NewFromInt(500)))
+			return &entry, nil
+		})
+		mocks.onboardingRepository.EXPECT().UpdateGrant(gomock.Any()).DoAndReturn(func(grant Grant) error {
+			assert.True(t, grant.ClawedBackAmount.Equal(decimal.NewFromInt(500)))
+			return nil
+		})
+
+		_, err := onboardingService.ClawBack(3)
+		assert.Nil(t, err)
+	})
+
+	t.Run("clawed back already", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(3)).Return(&Grant{Id: 3, Status: GrantStatusExpired}, nil)
+		_, err := onboardingService.ClawBack(3)
+		assert.ErrorIs(t, err, ErrGrantClosed)
+	})
+
+	t.Run("grant not found", func(t *testing.T) {
+		mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(4)).Return(nil, gorm.ErrRecordNotFound)
+		_, err := onboardingService.ClawBack(4)
+		assert.ErrorIs(t, err, ErrGrantNotFound)
+	})
+}
+
+func TestOnboardingService_ExpireGrants(t *testing.T) {
+	onboardingService, mocks := newTestOnboardingService(t, testPolicy())
+	mocks.onboardingRepository.EXPECT().ListExpiredGrants(mocks.now, expiredGrantBatchSize).Return([]Grant{{Id: 3}, {Id: 4}}, nil)
+	expiring := Grant{Id: 3, UserId: 7, CurrencyCode: "TRY", Amount: decimal.NewFromInt(100), Status: GrantStatusActive}
+	mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(3)).Return(&expiring, nil)
+	mocks.accountService.EXPECT().LockUserAccounts(uint(7), "TRY").Return(map[string]decimal.Decimal{"TRY": decimal.NewFromInt(500)}, nil)
+	mocks.expectLedger(expiring, decimal.NewFromInt(500), posting(ledger.EntryTypeDeposit, ledger.DirectionCredit, 400))
+	mocks.accountService.EXPECT().ApplyJournalEntry(gomock.Any()).Return(&ledger.JournalEntry{}, nil)
+	mocks.onboardingRepository.EXPECT().UpdateGrant(gomock.Any()).DoAndReturn(func(grant Grant) error {
+		assert.Equal(t, GrantStatusExpired, grant.Status)
+		assert.True(t, grant.ClawedBackAmount.Equal(decimal.NewFromInt(100)))
+		return nil
+	})
+	// Clawed back by an admin in between
+	mocks.onboardingRepository.EXPECT().GetGrantForUpdate(uint(4)).Return(&Grant{Id: 4, Status: GrantStatusClawedBack}, nil)
+
+	expired, err := onboardingService.ExpireGrants()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, expired)
+}
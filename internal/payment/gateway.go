@@ -0,0 +1,167 @@
+package payment
+
+import (
+	// Go imports
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+)
+
+const PaymentGatewayFake = "fake"
+
+var (
+	ErrInvalidCallback  = errors.New("invalid gateway callback")
+	ErrInvalidSignature = errors.New("invalid gateway callback signature")
+)
+
+// GatewayEvent is the state of a payment reported by the gateway, either in the response to a request or in a callback
+type GatewayEvent struct {
+	PaymentId     uint          `json:"payment_id"`               // ID of the payment on this service
+	Reference     string        `json:"reference"`                // ID of the payment on the gateway
+	Status        PaymentStatus `json:"status"`                   // PENDING, SETTLED or FAILED
+	FailureReason string        `json:"failure_reason,omitempty"` // Reason of a failed payment
+}
+
+// IPaymentGateway moves money between the users' external accounts and the service. Gateways usually answer
+// a request with a pending payment and report the outcome later through a callback.
+type IPaymentGateway interface {
+	Name() string
+	// Deposit asks the gateway to collect the amount of the payment from the user
+	Deposit(payment Payment) (*GatewayEvent, error)
+	// Withdraw asks the gateway to pay the amount of the payment out to the user
+	Withdraw(payment Payment) (*GatewayEvent, error)
+	// ParseCallback verifies a callback request of the gateway and returns the event it reports
+	ParseCallback(body []byte, signature string) (*GatewayEvent, error)
+}
+
+// NewPaymentGateway builds the gateway selected in config, the fake gateway is used by default
+func NewPaymentGateway(config config.Config) (IPaymentGateway, error) {
+	switch strings.ToLower(config.PaymentGateway) {
+	case "", PaymentGatewayFake:
+		return NewFakeGateway(config.PaymentCallbackSecret, config.FakeGatewaySettleDelay), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway %s", config.PaymentGateway)
+	}
+}
+
+// FakeGateway is an in-process gateway for development and tests. Every payment is accepted as pending and
+// reported through the callback once Settle or Fail is called, or settled on its own after the settle delay.
+type FakeGateway struct {
+	secret      string
+	settleDelay time.Duration
+
+	mu       sync.Mutex
+	callback func(event GatewayEvent) error
+	pending  map[string]uint
+}
+
+func NewFakeGateway(secret string, settleDelay time.Duration) *FakeGateway {
+	return &FakeGateway{secret: secret, settleDelay: settleDelay, pending: make(map[string]uint)}
+}
+
+// SetCallback sets where the outcomes of the payments are reported to
+func (g *FakeGateway) SetCallback(callback func(event GatewayEvent) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.callback = callback
+}
+
+func (g *FakeGateway) Name() string {
+	return PaymentGatewayFake
+}
+
+func (g *FakeGateway) Deposit(payment Payment) (*GatewayEvent, error) {
+	return g.accept(payment)
+}
+
+func (g *FakeGateway) Withdraw(payment Payment) (*GatewayEvent, error) {
+	return g.accept(payment)
+}
+
+func (g *FakeGateway) accept(payment Payment) (*GatewayEvent, error) {
+	reference := fmt.Sprintf("fake_%s_%d", strings.ToLower(string(payment.Type)), payment.Id)
+
+	g.mu.Lock()
+	g.pending[reference] = payment.Id
+	g.mu.Unlock()
+
+	if g.settleDelay > 0 {
+		time.AfterFunc(g.settleDelay, func() {
+			if err := g.Settle(reference); err != nil {
+				log.Println("fake payment gateway:", err.Error())
+			}
+		})
+	}
+
+	return &GatewayEvent{PaymentId: payment.Id, Reference: reference, Status: PaymentStatusPending}, nil
+}
+
+// Settle reports the pending payment as settled
+func (g *FakeGateway) Settle(reference string) error {
+	return g.report(GatewayEvent{Reference: reference, Status: PaymentStatusSettled})
+}
+
+// Fail reports the pending payment as failed
+func (g *FakeGateway) Fail(reference, reason string) error {
+	return g.report(GatewayEvent{Reference: reference, Status: PaymentStatusFailed, FailureReason: reason})
+}
+
+func (g *FakeGateway) report(event GatewayEvent) error {
+	g.mu.Lock()
+	paymentId, ok := g.pending[event.Reference]
+	delete(g.pending, event.Reference)
+	callback := g.callback
+	g.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("payment %s is not pending", event.Reference)
+	}
+
+	if callback == nil {
+		return errors.New("callback is not set")
+	}
+
+	event.PaymentId = paymentId
+	return callback(event)
+}
+
+// ParseCallback reads a GatewayEvent signed with the hex encoded HMAC-SHA256 of the body,
+// callbacks are refused while no secret is configured
+func (g *FakeGateway) ParseCallback(body []byte, signature string) (*GatewayEvent, error) {
+	if g.secret == "" {
+		return nil, fmt.Errorf("%w: callback secret is not configured", ErrInvalidSignature)
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, g.sign(body)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event GatewayEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCallback, err.Error())
+	}
+
+	return &event, nil
+}
+
+// Sign returns the signature of a callback body, e.g. to send callbacks by hand while developing
+func (g *FakeGateway) Sign(body []byte) string {
+	return hex.EncodeToString(g.sign(body))
+}
+
+func (g *FakeGateway) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(g.secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
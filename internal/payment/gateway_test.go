@@ -0,0 +1,50 @@
+package payment
+
+import (
+	// Go imports
+	"testing"
+
+	// External imports
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeGateway(t *testing.T) {
+	gateway := NewFakeGateway("secret", 0)
+	var reported []GatewayEvent
+	gateway.SetCallback(func(event GatewayEvent) error {
+		reported = append(reported, event)
+		return nil
+	})
+
+	event, err := gateway.Deposit(Payment{Id: 5, Type: PaymentTypeDeposit})
+	assert.Nil(t, err)
+	assert.Equal(t, PaymentStatusPending, event.Status)
+
+	assert.Nil(t, gateway.Fail(event.Reference, "card declined"))
+	assert.Equal(t, []GatewayEvent{{PaymentId: 5, Reference: event.Reference, Status: PaymentStatusFailed, FailureReason: "card declined"}}, reported)
+
+	// The outcome of a payment is reported once
+	assert.NotNil(t, gateway.Settle(event.Reference))
+}
+
+func TestFakeGateway_ParseCallback(t *testing.T) {
+	gateway := NewFakeGateway("secret", 0)
+	body := []byte(`{"payment_id": 5, "reference": "fake_deposit_5", "status": "SETTLED"}`)
+
+	t.Run("signed callback", func(t *testing.T) {
+		event, err := gateway.ParseCallback(body, gateway.Sign(body))
+		assert.Nil(t, err)
+		assert.Equal(t, GatewayEvent{PaymentId: 5, Reference: "fake_deposit_5", Status: PaymentStatusSettled}, *event)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := gateway.ParseCallback(body, NewFakeGateway("other", 0).Sign(body))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("no secret configured", func(t *testing.T) {
+		unsigned := NewFakeGateway("", 0)
+		_, err := unsigned.ParseCallback(body, unsigned.Sign(body))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
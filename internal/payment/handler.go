@@ -0,0 +1,239 @@
+package payment
+
+import (
+	// Go imports
+	"net/http"
+	"strconv"
+
+	// External imports
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/common"
+)
+
+// callbackSignatureHeader carries the signature of a gateway callback body
+const callbackSignatureHeader = "X-Gateway-Signature"
+
+type Handler interface {
+	Deposit(c *gin.Context)
+	Withdraw(c *gin.Context)
+	ListPayments(c *gin.Context)
+	GetPayment(c *gin.Context)
+	Callback(c *gin.Context)
+	PaymentRoutes(router *gin.RouterGroup)
+	CallbackRoutes(router *gin.RouterGroup)
+}
+
+type paymentHandler struct {
+	paymentService IPaymentService
+}
+
+func NewPaymentHandler(paymentService IPaymentService) Handler {
+	return &paymentHandler{paymentService: paymentService}
+}
+
+func (h *paymentHandler) PaymentRoutes(router *gin.RouterGroup) {
+	router.POST("/deposit", h.Deposit)
+	router.POST("/withdraw", h.Withdraw)
+	router.GET("/payments", h.ListPayments)
+	router.GET("/payments/:id", h.GetPayment)
+}
+
+// CallbackRoutes are called by the gateway, they are authorized by the signature and not by a user token
+func (h *paymentHandler) CallbackRoutes(router *gin.RouterGroup) {
+	router.POST("/callback", h.Callback)
+}
+
+// Deposit godoc
+// @Summary Deposit Money
+// @Description Ask the payment gateway to collect an amount, the account is credited once the gateway settles the deposit
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request body PaymentRequest true "body params"
+// @Success 200 {object} helper.Response{data=PaymentResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Failure 502 {object} helper.Response{error=helper.ResponseError} "Bad Gateway"
+// @Router /account/deposit [post]
+func (h *paymentHandler) Deposit(c *gin.Context) {
+	h.pay(c, h.paymentService.Deposit)
+}
+
+// Withdraw godoc
+// @Summary Withdraw Money
+// @Description Ask the payment gateway to pay an amount out, the amount is held on the account until the withdrawal is settled or fails
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request body PaymentRequest true "body params"
+// @Success 200 {object} helper.Response{data=PaymentResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Failure 502 {object} helper.Response{error=helper.ResponseError} "Bad Gateway"
+// @Router /account/withdraw [post]
+func (h *paymentHandler) Withdraw(c *gin.Context) {
+	h.pay(c, h.paymentService.Withdraw)
+}
+
+func (h *paymentHandler) pay(c *gin.Context, pay func(userId uint, request PaymentRequest) (*PaymentResponse, error)) {
+	var req PaymentRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	payment, err := pay(userId, req)
+	if err != nil {
+		paymentError(c, err)
+		return
+	}
+
+	helper.Success(c, payment)
+}
+
+// ListPayments godoc
+// @Summary List Payments
+// @Description List the deposits and withdrawals of the user newest first
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query PaymentListRequest false "query params"
+// @Success 200 {object} helper.Response{data=PaymentListResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/payments [get]
+func (h *paymentHandler) ListPayments(c *gin.Context) {
+	var req PaymentListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	payments, err := h.paymentService.ListPayments(userId, req)
+	if err != nil {
+		paymentError(c, err)
+		return
+	}
+
+	helper.Success(c, payments)
+}
+
+// GetPayment godoc
+// @Summary Get Payment
+// @Description Get a deposit or withdrawal of the user with its current status
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param id path int true "ID of the payment"
+// @Success 200 {object} helper.Response{data=PaymentResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/payments/{id} [get]
+func (h *paymentHandler) GetPayment(c *gin.Context) {
+	paymentId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrPaymentError.Error(), "invalid payment id")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	payment, err := h.paymentService.GetPayment(userId, uint(paymentId))
+	if err != nil {
+		paymentError(c, err)
+		return
+	}
+
+	helper.Success(c, payment)
+}
+
+// Callback godoc
+// @Summary Payment Gateway Callback
+// @Description Report the outcome of a payment, called by the payment gateway. The body is a GatewayEvent signed by the gateway.
+// @Description A repeated event is accepted without changing the payment again.
+// @Tags Payment
+// @Accept  json
+// @Produce  json
+// @Param X-Gateway-Signature header string true "Signature of the body."
+// @Param request body GatewayEvent true "body params"
+// @Success 200 {object} helper.Response{data=PaymentResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Conflict"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /payment/callback [post]
+func (h *paymentHandler) Callback(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	payment, err := h.paymentService.HandleCallback(body, c.GetHeader(callbackSignatureHeader))
+	if err != nil {
+		paymentError(c, err)
+		return
+	}
+
+	helper.Success(c, payment)
+}
+
+func paymentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidPaymentFilter):
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+	case errors.Is(err, ErrInvalidPayment), errors.Is(err, ErrInvalidCallback), errors.Is(err, account.ErrNotEnoughBalance):
+		helper.Error(c, http.StatusBadRequest, errors.ErrPaymentError.Error(), err.Error())
+	case errors.Is(err, ErrInvalidSignature):
+		helper.Error(c, http.StatusForbidden, errors.ErrForbiddenError.Error(), err.Error())
+	case errors.Is(err, ErrPaymentNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrPaymentClosed):
+		helper.Error(c, http.StatusConflict, errors.ErrPaymentClosedError.Error(), err.Error())
+	case errors.Is(err, account.ErrAccountFrozen):
+		helper.Error(c, http.StatusConflict, errors.ErrAccountFrozenError.Error(), err.Error())
+	case errors.Is(err, ErrGatewayUnavailable):
+		helper.Error(c, http.StatusBadGateway, errors.ErrPaymentGatewayError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrPaymentError.Error(), err.Error())
+	}
+}
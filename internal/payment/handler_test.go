@@ -0,0 +1,121 @@
+package payment
+
+import (
+	// Go imports
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+)
+
+func TestPaymentHandler_Payments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentService := NewMockIPaymentService(ctrl)
+	httpHandler := NewPaymentHandler(mockPaymentService)
+	gin.SetMode(gin.TestMode)
+	userId := uint(1)
+	router := gin.Default()
+	group := router.Group("/account")
+	group.Use(func(c *gin.Context) {
+		c.Set("user_id", userId)
+	})
+	httpHandler.PaymentRoutes(group)
+
+	t.Run("missing amount", func(t *testing.T) {
+		body := []byte(`{"currency_code": "USD"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/deposit", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("successfully deposit", func(t *testing.T) {
+		mockPaymentService.EXPECT().Deposit(userId, PaymentRequest{CurrencyCode: "USD", Amount: decimal.RequireFromString("100.00")}).
+			Return(&PaymentResponse{Id: 5, Type: PaymentTypeDeposit, Status: PaymentStatusPending}, nil)
+		body := []byte(`{"currency_code": "USD", "amount": "100.00"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/deposit", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("gateway unavailable", func(t *testing.T) {
+		mockPaymentService.EXPECT().Deposit(userId, gomock.Any()).Return(nil, ErrGatewayUnavailable)
+		body := []byte(`{"currency_code": "USD", "amount": "100.00"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/deposit", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+	})
+
+	t.Run("withdraw more than balance", func(t *testing.T) {
+		mockPaymentService.EXPECT().Withdraw(userId, gomock.Any()).Return(nil, account.ErrNotEnoughBalance)
+		body := []byte(`{"currency_code": "USD", "amount": "100.00"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/withdraw", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list payments", func(t *testing.T) {
+		mockPaymentService.EXPECT().ListPayments(userId, PaymentListRequest{Status: "PENDING"}).Return(&PaymentListResponse{}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/account/payments?status=PENDING", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("get payment of another user", func(t *testing.T) {
+		mockPaymentService.EXPECT().GetPayment(userId, uint(5)).Return(nil, ErrPaymentNotFound)
+		req, _ := http.NewRequest(http.MethodGet, "/account/payments/5", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPaymentHandler_Callback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentService := NewMockIPaymentService(ctrl)
+	httpHandler := NewPaymentHandler(mockPaymentService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	httpHandler.CallbackRoutes(router.Group("/payment"))
+	body := []byte(`{"payment_id": 5, "reference": "fake_deposit_5", "status": "SETTLED"}`)
+
+	t.Run("invalid signature", func(t *testing.T) {
+		mockPaymentService.EXPECT().HandleCallback(body, "bad").Return(nil, ErrInvalidSignature)
+		req, _ := http.NewRequest(http.MethodPost, "/payment/callback", bytes.NewReader(body))
+		req.Header.Set("X-Gateway-Signature", "bad")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("payment already failed", func(t *testing.T) {
+		mockPaymentService.EXPECT().HandleCallback(body, "signature").Return(nil, ErrPaymentClosed)
+		req, _ := http.NewRequest(http.MethodPost, "/payment/callback", bytes.NewReader(body))
+		req.Header.Set("X-Gateway-Signature", "signature")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("settled", func(t *testing.T) {
+		mockPaymentService.EXPECT().HandleCallback(body, "signature").Return(&PaymentResponse{Id: 5, Status: PaymentStatusSettled}, nil)
+		req, _ := http.NewRequest(http.MethodPost, "/payment/callback", bytes.NewReader(body))
+		req.Header.Set("X-Gateway-Signature", "signature")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
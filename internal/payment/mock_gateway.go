@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/payment (interfaces: IPaymentGateway)
+
+// Package payment is a generated GoMock package.
+package payment
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPaymentGateway is a mock of IPaymentGateway interface.
+type MockIPaymentGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentGatewayMockRecorder
+}
+
+// MockIPaymentGatewayMockRecorder is the mock recorder for MockIPaymentGateway.
+type MockIPaymentGatewayMockRecorder struct {
+	mock *MockIPaymentGateway
+}
+
+// NewMockIPaymentGateway creates a new mock instance.
+func NewMockIPaymentGateway(ctrl *gomock.Controller) *MockIPaymentGateway {
+	mock := &MockIPaymentGateway{ctrl: ctrl}
+	mock.recorder = &MockIPaymentGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentGateway) EXPECT() *MockIPaymentGatewayMockRecorder {
+	return m.recorder
+}
+
+// Deposit mocks base method.
+func (m *MockIPaymentGateway) Deposit(arg0 Payment) (*GatewayEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", arg0)
+	ret0, _ := ret[0].(*GatewayEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockIPaymentGatewayMockRecorder) Deposit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockIPaymentGateway)(nil).Deposit), arg0)
+}
+
+// Name mocks base method.
+func (m *MockIPaymentGateway) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockIPaymentGatewayMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIPaymentGateway)(nil).Name))
+}
+
+// ParseCallback mocks base method.
+func (m *MockIPaymentGateway) ParseCallback(arg0 []byte, arg1 string) (*GatewayEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseCallback", arg0, arg1)
+	ret0, _ := ret[0].(*GatewayEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseCallback indicates an expected call of ParseCallback.
+func (mr *MockIPaymentGatewayMockRecorder) ParseCallback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseCallback", reflect.TypeOf((*MockIPaymentGateway)(nil).ParseCallback), arg0, arg1)
+}
+
+// Withdraw mocks base method.
+func (m *MockIPaymentGateway) Withdraw(arg0 Payment) (*GatewayEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", arg0)
+	ret0, _ := ret[0].(*GatewayEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockIPaymentGatewayMockRecorder) Withdraw(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockIPaymentGateway)(nil).Withdraw), arg0)
+}
@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/payment (interfaces: IPaymentRepository)
+
+// Package payment is a generated GoMock package.
+package payment
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockIPaymentRepository is a mock of IPaymentRepository interface.
+type MockIPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentRepositoryMockRecorder
+}
+
+// MockIPaymentRepositoryMockRecorder is the mock recorder for MockIPaymentRepository.
+type MockIPaymentRepositoryMockRecorder struct {
+	mock *MockIPaymentRepository
+}
+
+// NewMockIPaymentRepository creates a new mock instance.
+func NewMockIPaymentRepository(ctrl *gomock.Controller) *MockIPaymentRepository {
+	mock := &MockIPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockIPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentRepository) EXPECT() *MockIPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePayment mocks base method.
+func (m *MockIPaymentRepository) CreatePayment(arg0 Payment) (*Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", arg0)
+	ret0, _ := ret[0].(*Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockIPaymentRepositoryMockRecorder) CreatePayment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockIPaymentRepository)(nil).CreatePayment), arg0)
+}
+
+// GetPayment mocks base method.
+func (m *MockIPaymentRepository) GetPayment(arg0 uint) (*Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", arg0)
+	ret0, _ := ret[0].(*Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockIPaymentRepositoryMockRecorder) GetPayment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockIPaymentRepository)(nil).GetPayment), arg0)
+}
+
+// GetPaymentForUpdate mocks base method.
+func (m *MockIPaymentRepository) GetPaymentForUpdate(arg0 uint) (*Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentForUpdate", arg0)
+	ret0, _ := ret[0].(*Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentForUpdate indicates an expected call of GetPaymentForUpdate.
+func (mr *MockIPaymentRepositoryMockRecorder) GetPaymentForUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentForUpdate", reflect.TypeOf((*MockIPaymentRepository)(nil).GetPaymentForUpdate), arg0)
+}
+
+// ListUserPayments mocks base method.
+func (m *MockIPaymentRepository) ListUserPayments(arg0 PaymentFilter) ([]Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPayments", arg0)
+	ret0, _ := ret[0].([]Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPayments indicates an expected call of ListUserPayments.
+func (mr *MockIPaymentRepositoryMockRecorder) ListUserPayments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPayments", reflect.TypeOf((*MockIPaymentRepository)(nil).ListUserPayments), arg0)
+}
+
+// Migration mocks base method.
+func (m *MockIPaymentRepository) Migration() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migration")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migration indicates an expected call of Migration.
+func (mr *MockIPaymentRepositoryMockRecorder) Migration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockIPaymentRepository)(nil).Migration))
+}
+
+// UpdatePayment mocks base method.
+func (m *MockIPaymentRepository) UpdatePayment(arg0 Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayment indicates an expected call of UpdatePayment.
+func (mr *MockIPaymentRepositoryMockRecorder) UpdatePayment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayment", reflect.TypeOf((*MockIPaymentRepository)(nil).UpdatePayment), arg0)
+}
+
+// WithTx mocks base method.
+func (m *MockIPaymentRepository) WithTx(arg0 *gorm.DB) IPaymentRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IPaymentRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIPaymentRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIPaymentRepository)(nil).WithTx), arg0)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/payment (interfaces: IPaymentService)
+
+// Package payment is a generated GoMock package.
+package payment
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPaymentService is a mock of IPaymentService interface.
+type MockIPaymentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentServiceMockRecorder
+}
+
+// MockIPaymentServiceMockRecorder is the mock recorder for MockIPaymentService.
+type MockIPaymentServiceMockRecorder struct {
+	mock *MockIPaymentService
+}
+
+// NewMockIPaymentService creates a new mock instance.
+func NewMockIPaymentService(ctrl *gomock.Controller) *MockIPaymentService {
+	mock := &MockIPaymentService{ctrl: ctrl}
+	mock.recorder = &MockIPaymentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentService) EXPECT() *MockIPaymentServiceMockRecorder {
+	return m.recorder
+}
+
+// Deposit mocks base method.
+func (m *MockIPaymentService) Deposit(arg0 uint, arg1 PaymentRequest) (*PaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", arg0, arg1)
+	ret0, _ := ret[0].(*PaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockIPaymentServiceMockRecorder) Deposit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockIPaymentService)(nil).Deposit), arg0, arg1)
+}
+
+// GetPayment mocks base method.
+func (m *MockIPaymentService) GetPayment(arg0, arg1 uint) (*PaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", arg0, arg1)
+	ret0, _ := ret[0].(*PaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockIPaymentServiceMockRecorder) GetPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockIPaymentService)(nil).GetPayment), arg0, arg1)
+}
+
+// HandleCallback mocks base method.
+func (m *MockIPaymentService) HandleCallback(arg0 []byte, arg1 string) (*PaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCallback", arg0, arg1)
+	ret0, _ := ret[0].(*PaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCallback indicates an expected call of HandleCallback.
+func (mr *MockIPaymentServiceMockRecorder) HandleCallback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCallback", reflect.TypeOf((*MockIPaymentService)(nil).HandleCallback), arg0, arg1)
+}
+
+// HandleGatewayEvent mocks base method.
+func (m *MockIPaymentService) HandleGatewayEvent(arg0 GatewayEvent) (*PaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleGatewayEvent", arg0)
+	ret0, _ := ret[0].(*PaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleGatewayEvent indicates an expected call of HandleGatewayEvent.
+func (mr *MockIPaymentServiceMockRecorder) HandleGatewayEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleGatewayEvent", reflect.TypeOf((*MockIPaymentService)(nil).HandleGatewayEvent), arg0)
+}
+
+// ListPayments mocks base method.
+func (m *MockIPaymentService) ListPayments(arg0 uint, arg1 PaymentListRequest) (*PaymentListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayments", arg0, arg1)
+	ret0, _ := ret[0].(*PaymentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockIPaymentServiceMockRecorder) ListPayments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockIPaymentService)(nil).ListPayments), arg0, arg1)
+}
+
+// Withdraw mocks base method.
+func (m *MockIPaymentService) Withdraw(arg0 uint, arg1 PaymentRequest) (*PaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", arg0, arg1)
+	ret0, _ := ret[0].(*PaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockIPaymentServiceMockRecorder) Withdraw(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockIPaymentService)(nil).Withdraw), arg0, arg1)
+}
@@ -0,0 +1,82 @@
+package payment
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+)
+
+// PaymentType tells whether money comes in or goes out through the gateway
+type PaymentType string
+
+const (
+	PaymentTypeDeposit    PaymentType = "DEPOSIT"
+	PaymentTypeWithdrawal PaymentType = "WITHDRAWAL"
+)
+
+// PaymentStatus is the lifecycle state of a payment, settled and failed payments do not change again
+type PaymentStatus string
+
+const (
+	PaymentStatusPending PaymentStatus = "PENDING" // Waiting for the gateway to report the outcome
+	PaymentStatusSettled PaymentStatus = "SETTLED"
+	PaymentStatusFailed  PaymentStatus = "FAILED"
+)
+
+// Payment is a deposit or withdrawal through the payment gateway. A deposit is credited once it is settled,
+// the amount of a withdrawal is held when it is requested and debited once it is settled.
+type Payment struct {
+	Id               uint            `gorm:"primaryKey;autoIncrement"`
+	UserId           uint            `gorm:"not null;index"`
+	Type             PaymentType     `gorm:"type:varchar(16);not null"`
+	CurrencyCode     string          `gorm:"not null"`
+	Amount           decimal.Decimal `gorm:"type:numeric;not null"`
+	Status           PaymentStatus   `gorm:"type:varchar(16);not null;index"`
+	Gateway          string          `gorm:"not null"`
+	GatewayReference *string         `gorm:"uniqueIndex"` // Empty until the gateway accepted the payment
+	FailureReason    string          `gorm:"type:text"`
+	HoldId           uint            // Hold on the amount of a withdrawal, zero on deposits
+	SettledAt        *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// PaymentFilter narrows the payments of a user, payments are returned newest first
+type PaymentFilter struct {
+	UserId   uint
+	Type     PaymentType   // Empty for both deposits and withdrawals
+	Status   PaymentStatus // Empty for every status
+	BeforeId uint          // cursor, only payments with a smaller id are returned
+	Limit    int
+}
+
+type PaymentRequest struct {
+	CurrencyCode string          `json:"currency_code" extensions:"x-order=1" example:"USD" validate:"required" valid:"required~currency_code|invalid"`           // Currency of the payment
+	Amount       decimal.Decimal `json:"amount" extensions:"x-order=2" swaggertype:"string" example:"100.00" validate:"required" valid:"required~amount|invalid"` // Amount to deposit or withdraw
+}
+
+type PaymentListRequest struct {
+	Type   string `form:"type"`   // DEPOSIT or WITHDRAWAL, both by default
+	Status string `form:"status"` // PENDING, SETTLED or FAILED, every status by default
+	Cursor string `form:"cursor"` // Next cursor of the previous page
+	Limit  int    `form:"limit"`  // Page size, 20 by default and at most 100
+}
+
+type PaymentResponse struct {
+	Id            uint            `json:"id" extensions:"x-order=1" example:"5"`                                      // ID of the payment
+	Type          PaymentType     `json:"type" extensions:"x-order=2" swaggertype:"string" example:"DEPOSIT"`         // DEPOSIT or WITHDRAWAL
+	CurrencyCode  string          `json:"currency_code" extensions:"x-order=3" example:"USD"`                         // Currency of the payment
+	Amount        decimal.Decimal `json:"amount" extensions:"x-order=4" swaggertype:"string" example:"100.00"`        // Amount of the payment
+	Status        PaymentStatus   `json:"status" extensions:"x-order=5" swaggertype:"string" example:"PENDING"`       // PENDING, SETTLED or FAILED
+	FailureReason string          `json:"failure_reason,omitempty" extensions:"x-order=6" example:"card declined"`    // Reason reported by the gateway for a failed payment
+	SettledAt     *time.Time      `json:"settled_at,omitempty" extensions:"x-order=7" example:"2022-12-01T10:01:00Z"` // Time the payment was settled
+	CreatedAt     time.Time       `json:"created_at" extensions:"x-order=8" example:"2022-12-01T10:00:00Z"`           // Time the payment was requested
+	UpdatedAt     time.Time       `json:"updated_at" extensions:"x-order=9" example:"2022-12-01T10:01:00Z"`           // Time of the last status change
+}
+
+type PaymentListResponse struct {
+	Payments   []PaymentResponse `json:"payments" extensions:"x-order=1"`                          // Payments newest first
+	NextCursor string            `json:"next_cursor,omitempty" extensions:"x-order=2" example:"5"` // Cursor of the next page, empty on the last page
+}
@@ -0,0 +1,81 @@
+package payment
+
+import (
+	// External imports
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IPaymentRepository interface {
+	CreatePayment(payment Payment) (*Payment, error)
+	GetPayment(id uint) (*Payment, error)
+	GetPaymentForUpdate(id uint) (*Payment, error)
+	UpdatePayment(payment Payment) error
+	ListUserPayments(filter PaymentFilter) ([]Payment, error)
+	WithTx(tx *gorm.DB) IPaymentRepository
+	Migration() error
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) IPaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+func (r *paymentRepository) WithTx(tx *gorm.DB) IPaymentRepository {
+	return NewPaymentRepository(tx)
+}
+
+func (r *paymentRepository) Migration() error {
+	return r.db.AutoMigrate(Payment{})
+}
+
+func (r *paymentRepository) CreatePayment(payment Payment) (*Payment, error) {
+	if err := r.db.Create(&payment).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepository) GetPayment(id uint) (*Payment, error) {
+	var payment *Payment
+	if err := r.db.Where("id =?", id).First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) GetPaymentForUpdate(id uint) (*Payment, error) {
+	var payment *Payment
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id =?", id).First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) UpdatePayment(payment Payment) error {
+	return r.db.Save(&payment).Error
+}
+
+func (r *paymentRepository) ListUserPayments(filter PaymentFilter) ([]Payment, error) {
+	query := r.db.Model(&Payment{}).Where("user_id =?", filter.UserId)
+	if filter.Type != "" {
+		query = query.Where("type =?", filter.Type)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status =?", filter.Status)
+	}
+
+	if filter.BeforeId > 0 {
+		query = query.Where("id <?", filter.BeforeId)
+	}
+
+	var payments []Payment
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
@@ -0,0 +1,24 @@
+package payment
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentRepository_ListUserPayments(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewPaymentRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "payments" WHERE user_id =$1 AND type =$2 AND status =$3 AND id <$4 ORDER BY id DESC LIMIT 21`)).
+		WithArgs(1, PaymentTypeWithdrawal, PaymentStatusPending, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).AddRow(9, 1, PaymentTypeWithdrawal, PaymentStatusPending))
+
+	payments, err := r.ListUserPayments(PaymentFilter{UserId: 1, Type: PaymentTypeWithdrawal, Status: PaymentStatusPending, BeforeId: 10, Limit: 21})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, payments, 1)
+}
@@ -0,0 +1,339 @@
+package payment
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// External imports
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+const (
+	defaultPaymentPageSize = 20
+	maxPaymentPageSize     = 100
+)
+
+var (
+	ErrInvalidPayment       = errors.New("invalid payment")
+	ErrInvalidPaymentFilter = errors.New("invalid payment filter")
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrPaymentClosed        = errors.New("payment is already settled or failed")
+	ErrGatewayUnavailable   = errors.New("payment gateway unavailable")
+)
+
+type IPaymentService interface {
+	Deposit(userId uint, request PaymentRequest) (*PaymentResponse, error)
+	Withdraw(userId uint, request PaymentRequest) (*PaymentResponse, error)
+	ListPayments(userId uint, request PaymentListRequest) (*PaymentListResponse, error)
+	GetPayment(userId, paymentId uint) (*PaymentResponse, error)
+	HandleCallback(body []byte, signature string) (*PaymentResponse, error)
+	HandleGatewayEvent(event GatewayEvent) (*PaymentResponse, error)
+}
+
+type paymentService struct {
+	paymentRepo     IPaymentRepository
+	gateway         IPaymentGateway
+	accountService  account.IAccountService
+	currencyService currency.Service
+	unitOfWork      uow.IUnitOfWork
+	clock           clock.IClock
+}
+
+func NewPaymentService(paymentRepository IPaymentRepository, gateway IPaymentGateway, accountService account.IAccountService, currencyService currency.Service, unitOfWork uow.IUnitOfWork, clock clock.IClock) IPaymentService {
+	return &paymentService{paymentRepo: paymentRepository, gateway: gateway, accountService: accountService, currencyService: currencyService, unitOfWork: unitOfWork, clock: clock}
+}
+
+// Deposit records a pending deposit and asks the gateway to collect it, the user is credited once it is settled
+func (s *paymentService) Deposit(userId uint, request PaymentRequest) (*PaymentResponse, error) {
+	payment, err := s.newPayment(userId, PaymentTypeDeposit, request)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.paymentRepo.CreatePayment(*payment)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.submit(*created, s.gateway.Deposit)
+}
+
+// Withdraw holds the amount and asks the gateway to pay it out, the hold is captured once the payout is settled and
+// released if it fails
+func (s *paymentService) Withdraw(userId uint, request PaymentRequest) (*PaymentResponse, error) {
+	payment, err := s.newPayment(userId, PaymentTypeWithdrawal, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok := s.accountService.IsUserHasAccountOnGivenCurrency(userId, payment.CurrencyCode); !ok {
+		return nil, fmt.Errorf("%w: %s account not found", ErrInvalidPayment, payment.CurrencyCode)
+	}
+
+	var created *Payment
+	if err = s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txPaymentRepo := s.paymentRepo.WithTx(tx)
+		var err error
+		if created, err = txPaymentRepo.CreatePayment(*payment); err != nil {
+			return err
+		}
+
+		hold, err := s.accountService.WithTx(tx).CreateHold(userId, created.CurrencyCode, created.Amount, ledger.ReferenceTypePayment, created.Id)
+		if err != nil {
+			return err
+		}
+
+		created.HoldId = hold.Id
+		return txPaymentRepo.UpdatePayment(*created)
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.submit(*created, s.gateway.Withdraw)
+}
+
+func (s *paymentService) newPayment(userId uint, paymentType PaymentType, request PaymentRequest) (*Payment, error) {
+	currencyCode := strings.ToUpper(request.CurrencyCode)
+	if ok := s.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+		return nil, fmt.Errorf("%w: currency %s not found", ErrInvalidPayment, currencyCode)
+	}
+
+	amount := currency.Round(request.Amount, currencyCode)
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidPayment)
+	}
+
+	now := s.clock.Now()
+	return &Payment{
+		UserId:       userId,
+		Type:         paymentType,
+		CurrencyCode: currencyCode,
+		Amount:       amount,
+		Status:       PaymentStatusPending,
+		Gateway:      s.gateway.Name(),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// submit sends the recorded payment to the gateway, a payment the gateway could not be reached for is failed
+func (s *paymentService) submit(payment Payment, send func(payment Payment) (*GatewayEvent, error)) (*PaymentResponse, error) {
+	event, err := send(payment)
+	if err != nil {
+		if _, failErr := s.HandleGatewayEvent(GatewayEvent{PaymentId: payment.Id, Status: PaymentStatusFailed, FailureReason: err.Error()}); failErr != nil {
+			return nil, failErr
+		}
+		return nil, fmt.Errorf("%w: %s", ErrGatewayUnavailable, err.Error())
+	}
+
+	event.PaymentId = payment.Id
+	return s.HandleGatewayEvent(*event)
+}
+
+// HandleCallback applies the event of a callback request once the gateway verified it
+func (s *paymentService) HandleCallback(body []byte, signature string) (*PaymentResponse, error) {
+	event, err := s.gateway.ParseCallback(body, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.HandleGatewayEvent(*event)
+}
+
+// HandleGatewayEvent moves a pending payment to the reported status and posts its money on the ledger.
+// Gateways may report an event more than once, an event repeating the current status changes nothing.
+func (s *paymentService) HandleGatewayEvent(event GatewayEvent) (*PaymentResponse, error) {
+	switch event.Status {
+	case PaymentStatusPending, PaymentStatusSettled, PaymentStatusFailed:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %s", ErrInvalidCallback, event.Status)
+	}
+
+	var payment *Payment
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txPaymentRepo := s.paymentRepo.WithTx(tx)
+		var err error
+		payment, err = txPaymentRepo.GetPaymentForUpdate(event.PaymentId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: %d", ErrPaymentNotFound, event.PaymentId)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if event.Reference != "" && payment.GatewayReference != nil && *payment.GatewayReference != event.Reference {
+			return fmt.Errorf("%w: reference %s does not belong to payment %d", ErrInvalidCallback, event.Reference, payment.Id)
+		}
+
+		// A pending event arriving after the outcome is stale
+		if event.Status == PaymentStatusPending && payment.Status != PaymentStatusPending {
+			return nil
+		}
+
+		// Nothing to record on a repeated event
+		if payment.Status == event.Status && (event.Reference == "" || payment.GatewayReference != nil) {
+			return nil
+		}
+
+		if payment.Status != PaymentStatusPending && payment.Status != event.Status {
+			return fmt.Errorf("%w: payment %d is %s", ErrPaymentClosed, payment.Id, payment.Status)
+		}
+
+		if event.Reference != "" {
+			reference := event.Reference
+			payment.GatewayReference = &reference
+		}
+
+		status := event.Status
+		failureReason := event.FailureReason
+		if payment.Status != status {
+			err = s.post(s.accountService.WithTx(tx), *payment, status)
+			// A deposit can not be credited to a frozen account, it is failed instead of being left pending
+			if payment.Type == PaymentTypeDeposit && errors.Is(err, account.ErrAccountFrozen) {
+				status = PaymentStatusFailed
+				failureReason = err.Error()
+			} else if err != nil {
+				return err
+			}
+		}
+
+		now := s.clock.Now()
+		if status == PaymentStatusSettled && payment.SettledAt == nil {
+			payment.SettledAt = &now
+		}
+
+		if status == PaymentStatusFailed && payment.FailureReason == "" {
+			payment.FailureReason = failureReason
+		}
+
+		payment.Status = status
+		payment.UpdatedAt = now
+		return txPaymentRepo.UpdatePayment(*payment)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toPaymentResponse(*payment)
+	return &response, nil
+}
+
+// post moves the money of a payment closed with given status, it has to run inside a transaction
+func (s *paymentService) post(txAccountService account.IAccountService, payment Payment, status PaymentStatus) error {
+	switch {
+	case payment.Type == PaymentTypeDeposit && status == PaymentStatusSettled:
+		// Deposits may be made on a currency the user has no account on yet
+		if ok := txAccountService.IsUserHasAccountOnGivenCurrency(payment.UserId, payment.CurrencyCode); !ok {
+			if _, err := txAccountService.CreateUserAccount(payment.UserId, payment.CurrencyCode); err != nil {
+				return err
+			}
+		}
+
+		_, err := txAccountService.ApplyJournalEntry(ledger.NewDepositEntry(payment.UserId, payment.Id, payment.CurrencyCode, payment.Amount))
+		return err
+	case payment.Type == PaymentTypeWithdrawal && status == PaymentStatusSettled:
+		_, err := txAccountService.CaptureHold(payment.HoldId, ledger.NewWithdrawalEntry(payment.UserId, payment.Id, payment.CurrencyCode, payment.Amount))
+		return err
+	case payment.Type == PaymentTypeWithdrawal && status == PaymentStatusFailed:
+		_, err := txAccountService.ReleaseHold(payment.HoldId)
+		return err
+	}
+
+	return nil
+}
+
+// ListPayments pages through the deposits and withdrawals of the user newest first
+func (s *paymentService) ListPayments(userId uint, request PaymentListRequest) (*PaymentListResponse, error) {
+	filter := PaymentFilter{
+		UserId: userId,
+		Type:   PaymentType(strings.ToUpper(request.Type)),
+		Status: PaymentStatus(strings.ToUpper(request.Status)),
+		Limit:  request.Limit,
+	}
+
+	switch filter.Type {
+	case "", PaymentTypeDeposit, PaymentTypeWithdrawal:
+	default:
+		return nil, fmt.Errorf("%w: unknown type %s", ErrInvalidPaymentFilter, request.Type)
+	}
+
+	switch filter.Status {
+	case "", PaymentStatusPending, PaymentStatusSettled, PaymentStatusFailed:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %s", ErrInvalidPaymentFilter, request.Status)
+	}
+
+	if request.Cursor != "" {
+		cursor, err := strconv.ParseUint(request.Cursor, 10, 64)
+		if err != nil || cursor == 0 {
+			return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidPaymentFilter)
+		}
+		filter.BeforeId = uint(cursor)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPaymentPageSize
+	}
+
+	if filter.Limit > maxPaymentPageSize {
+		filter.Limit = maxPaymentPageSize
+	}
+
+	// Fetch one more payment to know whether there is a next page
+	pageSize := filter.Limit
+	filter.Limit++
+	payments, err := s.paymentRepo.ListUserPayments(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PaymentListResponse{Payments: []PaymentResponse{}}
+	if len(payments) > pageSize {
+		payments = payments[:pageSize]
+		response.NextCursor = strconv.FormatUint(uint64(payments[pageSize-1].Id), 10)
+	}
+
+	for _, payment := range payments {
+		response.Payments = append(response.Payments, toPaymentResponse(payment))
+	}
+
+	return response, nil
+}
+
+func (s *paymentService) GetPayment(userId, paymentId uint) (*PaymentResponse, error) {
+	payment, err := s.paymentRepo.GetPayment(paymentId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && payment.UserId != userId) {
+		return nil, fmt.Errorf("%w: %d", ErrPaymentNotFound, paymentId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPaymentResponse(*payment)
+	return &response, nil
+}
+
+func toPaymentResponse(payment Payment) PaymentResponse {
+	return PaymentResponse{
+		Id:            payment.Id,
+		Type:          payment.Type,
+		CurrencyCode:  payment.CurrencyCode,
+		Amount:        payment.Amount,
+		Status:        payment.Status,
+		FailureReason: payment.FailureReason,
+		SettledAt:     payment.SettledAt,
+		CreatedAt:     payment.CreatedAt,
+		UpdatedAt:     payment.UpdatedAt,
+	}
+}
@@ -0,0 +1,269 @@
+package payment
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+type paymentServiceMocks struct {
+	paymentRepository *MockIPaymentRepository
+	gateway           *MockIPaymentGateway
+	accountService    *account.MockIAccountService
+	unitOfWork        *uow.MockIUnitOfWork
+	now               time.Time
+}
+
+func newTestPaymentService(t *testing.T) (IPaymentService, paymentServiceMocks) {
+	ctrl := gomock.NewController(t)
+	mocks := paymentServiceMocks{
+		paymentRepository: NewMockIPaymentRepository(ctrl),
+		gateway:           NewMockIPaymentGateway(ctrl),
+		accountService:    account.NewMockIAccountService(ctrl),
+		unitOfWork:        uow.NewMockIUnitOfWork(ctrl),
+		now:               time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC),
+	}
+	mockClock := clock.NewMockIClock(ctrl)
+	mockClock.EXPECT().Now().Return(mocks.now).AnyTimes()
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	currencyService.Cache.Set("USD", "USD", cache.NoExpiration)
+
+	mocks.gateway.EXPECT().Name().Return(PaymentGatewayFake).AnyTimes()
+	mocks.paymentRepository.EXPECT().WithTx(gomock.Any()).Return(mocks.paymentRepository).AnyTimes()
+	mocks.accountService.EXPECT().WithTx(gomock.Any()).Return(mocks.accountService).AnyTimes()
+	mocks.unitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+		return fn(nil)
+	}).AnyTimes()
+
+	return NewPaymentService(mocks.paymentRepository, mocks.gateway, mocks.accountService, currencyService, mocks.unitOfWork, mockClock), mocks
+}
+
+func TestPaymentService_Deposit(t *testing.T) {
+	paymentService, mocks := newTestPaymentService(t)
+	userId := uint(1)
+
+	t.Run("amount is not positive", func(t *testing.T) {
+		_, err := paymentService.Deposit(userId, PaymentRequest{CurrencyCode: "USD", Amount: decimal.Zero})
+		assert.ErrorIs(t, err, ErrInvalidPayment)
+	})
+
+	t.Run("pending deposit", func(t *testing.T) {
+		pending := Payment{Id: 5, UserId: userId, Type: PaymentTypeDeposit, CurrencyCode: "USD", Amount: decimal.NewFromInt(100), Status: PaymentStatusPending}
+		mocks.paymentRepository.EXPECT().CreatePayment(gomock.Any()).DoAndReturn(func(payment Payment) (*Payment, error) {
+			assert.Equal(t, PaymentStatusPending, payment.Status)
+			assert.Equal(t, PaymentGatewayFake, payment.Gateway)
+			return &pending, nil
+		})
+		mocks.gateway.EXPECT().Deposit(pending).Return(&GatewayEvent{Reference: "ref_5", Status: PaymentStatusPending}, nil)
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).DoAndReturn(func(uint) (*Payment, error) {
+			payment := pending
+			return &payment, nil
+		})
+		mocks.paymentRepository.EXPECT().UpdatePayment(gomock.Any()).DoAndReturn(func(payment Payment) error {
+			assert.Equal(t, "ref_5", *payment.GatewayReference)
+			return nil
+		})
+
+		payment, err := paymentService.Deposit(userId, PaymentRequest{CurrencyCode: "usd", Amount: decimal.NewFromInt(100)})
+		assert.Nil(t, err)
+		assert.Equal(t, PaymentStatusPending, payment.Status)
+	})
+
+	t.Run("gateway unavailable", func(t *testing.T) {
+		pending := Payment{Id: 6, UserId: userId, Type: PaymentTypeDeposit, CurrencyCode: "USD", Amount: decimal.NewFromInt(100), Status: PaymentStatusPending}
+		mocks.paymentRepository.EXPECT().CreatePayment(gomock.Any()).Return(&pending, nil)
+		mocks.gateway.EXPECT().Deposit(pending).Return(nil, errors.New("connection refused"))
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(6)).Return(&pending, nil)
+		mocks.paymentRepository.EXPECT().UpdatePayment(gomock.Any()).DoAndReturn(func(payment Payment) error {
+			assert.Equal(t, PaymentStatusFailed, payment.Status)
+			assert.Equal(t, "connection refused", payment.FailureReason)
+			return nil
+		})
+
+		_, err := paymentService.Deposit(userId, PaymentRequest{CurrencyCode: "USD", Amount: decimal.NewFromInt(100)})
+		assert.ErrorIs(t, err, ErrGatewayUnavailable)
+	})
+}
+
+func TestPaymentService_Withdraw(t *testing.T) {
+	paymentService, mocks := newTestPaymentService(t)
+	userId := uint(1)
+
+	t.Run("account not found", func(t *testing.T) {
+		mocks.accountService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(false)
+		_, err := paymentService.Withdraw(userId, PaymentRequest{CurrencyCode: "USD", Amount: decimal.NewFromInt(100)})
+		assert.ErrorIs(t, err, ErrInvalidPayment)
+	})
+
+	t.Run("not enough balance", func(t *testing.T) {
+		mocks.accountService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		mocks.paymentRepository.EXPECT().CreatePayment(gomock.Any()).Return(&Payment{Id: 6, UserId: userId, CurrencyCode: "USD", Amount: decimal.NewFromInt(100)}, nil)
+		mocks.accountService.EXPECT().CreateHold(userId, "USD", decimal.NewFromInt(100), ledger.ReferenceTypePayment, uint(6)).Return(nil, account.ErrNotEnoughBalance)
+		_, err := paymentService.Withdraw(userId, PaymentRequest{CurrencyCode: "USD", Amount: decimal.NewFromInt(100)})
+		assert.ErrorIs(t, err, account.ErrNotEnoughBalance)
+	})
+
+	t.Run("held when requested", func(t *testing.T) {
+		holdId := uint(3)
+		pending := Payment{Id: 7, UserId: userId, Type: PaymentTypeWithdrawal, CurrencyCode: "USD", Amount: decimal.NewFromInt(100), Status: PaymentStatusPending}
+		held := pending
+		held.HoldId = holdId
+		mocks.accountService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		mocks.paymentRepository.EXPECT().CreatePayment(gomock.Any()).DoAndReturn(func(Payment) (*Payment, error) {
+			payment := pending
+			return &payment, nil
+		})
+		mocks.accountService.EXPECT().CreateHold(userId, "USD", decimal.NewFromInt(100), ledger.ReferenceTypePayment, uint(7)).Return(&account.Hold{Id: holdId}, nil)
+		mocks.paymentRepository.EXPECT().UpdatePayment(held).Return(nil)
+		mocks.gateway.EXPECT().Withdraw(held).Return(&GatewayEvent{Reference: "ref_7", Status: PaymentStatusPending}, nil)
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(7)).DoAndReturn(func(uint) (*Payment, error) {
+			payment := held
+			return &payment, nil
+		})
+		mocks.paymentRepository.EXPECT().UpdatePayment(gomock.Any()).Return(nil)
+
+		payment, err := paymentService.Withdraw(userId, PaymentRequest{CurrencyCode: "USD", Amount: decimal.NewFromInt(100)})
+		assert.Nil(t, err)
+		assert.Equal(t, PaymentTypeWithdrawal, payment.Type)
+		assert.Equal(t, PaymentStatusPending, payment.Status)
+	})
+}
+
+func TestPaymentService_HandleGatewayEvent(t *testing.T) {
+	paymentService, mocks := newTestPaymentService(t)
+	userId := uint(1)
+	reference := "ref_5"
+	holdId := uint(3)
+	pendingPayment := func(paymentType PaymentType) *Payment {
+		return &Payment{Id: 5, UserId: userId, Type: paymentType, CurrencyCode: "USD", Amount: decimal.NewFromInt(100), Status: PaymentStatusPending, GatewayReference: &reference}
+	}
+
+	t.Run("settled deposit is credited", func(t *testing.T) {
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).Return(pendingPayment(PaymentTypeDeposit), nil)
+		mocks.accountService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(false)
+		mocks.accountService.EXPECT().CreateUserAccount(userId, "USD").Return(&account.Account{}, nil)
+		mocks.accountService.EXPECT().ApplyJournalEntry(gomock.Any()).DoAndReturn(func(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+			assert.Equal(t, ledger.EntryTypeDeposit, entry.Type)
+			return &entry, nil
+		})
+		mocks.paymentRepository.EXPECT().UpdatePayment(gomock.Any()).Return(nil)
+
+		payment, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 5, Reference: reference, Status: PaymentStatusSettled})
+		assert.Nil(t, err)
+		assert.Equal(t, PaymentStatusSettled, payment.Status)
+		assert.Equal(t, mocks.now, *payment.SettledAt)
+	})
+
+	t.Run("settled deposit into a frozen account fails", func(t *testing.T) {
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).Return(pendingPayment(PaymentTypeDeposit), nil)
+		mocks.accountService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		mocks.accountService.EXPECT().ApplyJournalEntry(gomock.Any()).Return(nil, fmt.Errorf("%w: USD account of user %d", account.ErrAccountFrozen, userId))
+		mocks.paymentRepository.EXPECT().UpdatePayment(gomock.Any()).DoAndReturn(func(payment Payment) error {
+			assert.Equal(t, PaymentStatusFailed, payment.Status)
+			assert.Nil(t, payment.SettledAt)
+			return nil
+		})
+
+		payment, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 5, Reference: reference, Status: PaymentStatusSettled})
+		assert.Nil(t, err)
+		assert.Equal(t, PaymentStatusFailed, payment.Status)
+		assert.Contains(t, payment.FailureReason, "account is frozen")
+	})
+
+	t.Run("settled withdrawal captures the hold", func(t *testing.T) {
+		withdrawal := pendingPayment(PaymentTypeWithdrawal)
+		withdrawal.HoldId = holdId
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).Return(withdrawal, nil)
+		mocks.accountService.EXPECT().CaptureHold(holdId, ledger.NewWithdrawalEntry(userId, 5, "USD", decimal.NewFromInt(100))).
+			DoAndReturn(func(_ uint, entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+				return &entry, nil
+			})
+		mocks.paymentRepository.EXPECT().UpdatePayment(gomock.Any()).Return(nil)
+
+		payment, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 5, Reference: reference, Status: PaymentStatusSettled})
+		assert.Nil(t, err)
+		assert.Equal(t, PaymentStatusSettled, payment.Status)
+	})
+
+	t.Run("failed withdrawal releases the hold", func(t *testing.T) {
+		withdrawal := pendingPayment(PaymentTypeWithdrawal)
+		withdrawal.HoldId = holdId
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).Return(withdrawal, nil)
+		mocks.accountService.EXPECT().ReleaseHold(holdId).Return(&account.Hold{Id: holdId, Status: account.HoldStatusReleased}, nil)
+		mocks.paymentRepository.EXPECT().UpdatePayment(gomock.Any()).Return(nil)
+
+		payment, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 5, Status: PaymentStatusFailed, FailureReason: "account closed"})
+		assert.Nil(t, err)
+		assert.Equal(t, PaymentStatusFailed, payment.Status)
+		assert.Equal(t, "account closed", payment.FailureReason)
+	})
+
+	t.Run("repeated event changes nothing", func(t *testing.T) {
+		settled := pendingPayment(PaymentTypeDeposit)
+		settled.Status = PaymentStatusSettled
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).Return(settled, nil)
+
+		payment, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 5, Reference: reference, Status: PaymentStatusSettled})
+		assert.Nil(t, err)
+		assert.Equal(t, PaymentStatusSettled, payment.Status)
+	})
+
+	t.Run("settled payment can not fail", func(t *testing.T) {
+		settled := pendingPayment(PaymentTypeDeposit)
+		settled.Status = PaymentStatusSettled
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).Return(settled, nil)
+
+		_, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 5, Status: PaymentStatusFailed})
+		assert.ErrorIs(t, err, ErrPaymentClosed)
+	})
+
+	t.Run("reference of another payment", func(t *testing.T) {
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(5)).Return(pendingPayment(PaymentTypeDeposit), nil)
+
+		_, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 5, Reference: "ref_6", Status: PaymentStatusSettled})
+		assert.ErrorIs(t, err, ErrInvalidCallback)
+	})
+
+	t.Run("unknown payment", func(t *testing.T) {
+		mocks.paymentRepository.EXPECT().GetPaymentForUpdate(uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := paymentService.HandleGatewayEvent(GatewayEvent{PaymentId: 9, Status: PaymentStatusSettled})
+		assert.ErrorIs(t, err, ErrPaymentNotFound)
+	})
+}
+
+func TestPaymentService_ListPayments(t *testing.T) {
+	paymentService, mocks := newTestPaymentService(t)
+	userId := uint(1)
+
+	t.Run("unknown status", func(t *testing.T) {
+		_, err := paymentService.ListPayments(userId, PaymentListRequest{Status: "REVERSED"})
+		assert.ErrorIs(t, err, ErrInvalidPaymentFilter)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		mocks.paymentRepository.EXPECT().ListUserPayments(PaymentFilter{UserId: userId, Type: PaymentTypeDeposit, Limit: 3}).
+			Return([]Payment{{Id: 9}, {Id: 8}, {Id: 5}}, nil)
+
+		payments, err := paymentService.ListPayments(userId, PaymentListRequest{Type: "deposit", Limit: 2})
+		assert.Nil(t, err)
+		assert.Len(t, payments.Payments, 2)
+		assert.Equal(t, "8", payments.NextCursor)
+	})
+}
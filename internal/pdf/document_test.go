@@ -0,0 +1,54 @@
+package pdf
+
+import (
+	// Go imports
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	// External imports
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	t.Run("empty document has one page", func(t *testing.T) {
+		out := NewDocument().Bytes()
+		assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+		assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+		assert.Contains(t, string(out), "/Count 1")
+	})
+
+	t.Run("text is escaped", func(t *testing.T) {
+		document := NewDocument()
+		document.AddLine(`Balance (EUR) \ 10 €`)
+		assert.Contains(t, string(document.Bytes()), `(Balance \(EUR\) \\ 10 ?) Tj T*`)
+	})
+
+	t.Run("long document is split into pages", func(t *testing.T) {
+		document := NewDocument()
+		for i := 0; i < linesPerPage+1; i++ {
+			document.AddLine(fmt.Sprintf("line %d", i))
+		}
+		assert.Contains(t, string(document.Bytes()), "/Kids [4 0 R 6 0 R] /Count 2")
+	})
+
+	t.Run("xref points at the objects", func(t *testing.T) {
+		document := NewDocument()
+		document.AddLine("Account Statement")
+		out := document.Bytes()
+
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+		assert.NotNil(t, startxref)
+		xref, _ := strconv.Atoi(string(startxref[1]))
+		assert.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 6\n")))
+
+		offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out, -1)
+		assert.Len(t, offsets, 5)
+		for i, offset := range offsets {
+			at, _ := strconv.Atoi(string(offset[1]))
+			assert.True(t, bytes.HasPrefix(out[at:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+		}
+	})
+}
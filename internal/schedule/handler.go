@@ -0,0 +1,196 @@
+package schedule
+
+import (
+	// Go imports
+	"net/http"
+	"strconv"
+
+	// External imports
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/common"
+)
+
+type Handler interface {
+	CreatePlan(c *gin.Context)
+	ListPlans(c *gin.Context)
+	CancelPlan(c *gin.Context)
+	ListRuns(c *gin.Context)
+	ScheduleRoutes(router *gin.RouterGroup)
+}
+
+type scheduleHandler struct {
+	scheduleService IScheduleService
+}
+
+func NewScheduleHandler(scheduleService IScheduleService) Handler {
+	return &scheduleHandler{scheduleService: scheduleService}
+}
+
+func (h *scheduleHandler) ScheduleRoutes(router *gin.RouterGroup) {
+	router.POST("/plans", h.CreatePlan)
+	router.GET("/plans", h.ListPlans)
+	router.DELETE("/plans/:id", h.CancelPlan)
+	router.GET("/plans/:id/runs", h.ListRuns)
+}
+
+// CreatePlan godoc
+// @Summary Create Conversion Plan
+// @Description Convert the same amount on every day, week or month through an offer accepted on the user's behalf
+// @Tags Schedule
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request body PlanRequest true "body params"
+// @Success 200 {object} helper.Response{data=PlanResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/plans [post]
+func (h *scheduleHandler) CreatePlan(c *gin.Context) {
+	var req PlanRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	plan, err := h.scheduleService.CreatePlan(userId, req)
+	if err != nil {
+		planError(c, err)
+		return
+	}
+
+	helper.Success(c, plan)
+}
+
+// ListPlans godoc
+// @Summary List Conversion Plans
+// @Description List the conversion plans of the user newest first
+// @Tags Schedule
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Success 200 {object} helper.Response{data=[]PlanResponse} "Success"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/plans [get]
+func (h *scheduleHandler) ListPlans(c *gin.Context) {
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	plans, err := h.scheduleService.ListPlans(userId)
+	if err != nil {
+		planError(c, err)
+		return
+	}
+
+	helper.Success(c, plans)
+}
+
+// CancelPlan godoc
+// @Summary Cancel Conversion Plan
+// @Description Stop an active conversion plan, its run history is kept
+// @Tags Schedule
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param id path int true "ID of the conversion plan"
+// @Success 200 {object} helper.Response{data=PlanResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 409 {object} helper.Response{error=helper.ResponseError} "Conversion Plan Not Active"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/plans/{id} [delete]
+func (h *scheduleHandler) CancelPlan(c *gin.Context) {
+	planId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrConversionPlanError.Error(), "invalid conversion plan id")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	plan, err := h.scheduleService.CancelPlan(userId, uint(planId))
+	if err != nil {
+		planError(c, err)
+		return
+	}
+
+	helper.Success(c, plan)
+}
+
+// ListRuns godoc
+// @Summary List Conversion Plan Runs
+// @Description List every attempt of a conversion plan with its outcome newest first
+// @Tags Schedule
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param id path int true "ID of the conversion plan"
+// @Success 200 {object} helper.Response{data=[]RunResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /exchange/plans/{id}/runs [get]
+func (h *scheduleHandler) ListRuns(c *gin.Context) {
+	planId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrConversionPlanError.Error(), "invalid conversion plan id")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	runs, err := h.scheduleService.ListRuns(userId, uint(planId))
+	if err != nil {
+		planError(c, err)
+		return
+	}
+
+	helper.Success(c, runs)
+}
+
+func planError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidPlan):
+		helper.Error(c, http.StatusBadRequest, errors.ErrConversionPlanError.Error(), err.Error())
+	case errors.Is(err, ErrPlanNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, ErrPlanClosed):
+		helper.Error(c, http.StatusConflict, errors.ErrConversionPlanClosedError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrConversionPlanError.Error(), err.Error())
+	}
+}
@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	// Go imports
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleHandler_Plans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockScheduleService := NewMockIScheduleService(ctrl)
+	httpHandler := NewScheduleHandler(mockScheduleService)
+	gin.SetMode(gin.TestMode)
+	userId := uint(1)
+	router := gin.Default()
+	group := router.Group("/exchange")
+	group.Use(func(c *gin.Context) {
+		c.Set("user_id", userId)
+	})
+	httpHandler.ScheduleRoutes(group)
+
+	t.Run("missing interval", func(t *testing.T) {
+		body := []byte(`{"from_currency_code": "USD", "to_currency_code": "TRY", "amount": "1000.00"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/exchange/plans", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		mockScheduleService.EXPECT().CreatePlan(userId, PlanRequest{
+			FromCurrencyCode: "USD",
+			ToCurrencyCode:   "TRY",
+			Amount:           decimal.RequireFromString("1000.00"),
+			Interval:         "HOURLY",
+		}).Return(nil, ErrInvalidPlan)
+		body := []byte(`{"from_currency_code": "USD", "to_currency_code": "TRY", "amount": "1000.00", "interval": "HOURLY"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/exchange/plans", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("successfully create plan", func(t *testing.T) {
+		mockScheduleService.EXPECT().CreatePlan(userId, gomock.Any()).Return(&PlanResponse{Id: 3, Status: PlanStatusActive}, nil)
+		body := []byte(`{"from_currency_code": "USD", "to_currency_code": "TRY", "amount": "1000.00", "interval": "MONTHLY", "on_insufficient_balance": "RETRY"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/exchange/plans", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("list plans", func(t *testing.T) {
+		mockScheduleService.EXPECT().ListPlans(userId).Return([]PlanResponse{}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/plans", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("cancel cancelled plan", func(t *testing.T) {
+		mockScheduleService.EXPECT().CancelPlan(userId, uint(3)).Return(nil, ErrPlanClosed)
+		req, _ := http.NewRequest(http.MethodDelete, "/exchange/plans/3", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("runs of another user's plan", func(t *testing.T) {
+		mockScheduleService.EXPECT().ListRuns(userId, uint(4)).Return(nil, ErrPlanNotFound)
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/plans/4/runs", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("list runs", func(t *testing.T) {
+		mockScheduleService.EXPECT().ListRuns(userId, uint(3)).Return([]RunResponse{{Id: 12, Outcome: RunOutcomeSucceeded}}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/exchange/plans/3/runs", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
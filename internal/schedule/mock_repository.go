@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/schedule (interfaces: IScheduleRepository)
+
+// Package schedule is a generated GoMock package.
+package schedule
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockIScheduleRepository is a mock of IScheduleRepository interface.
+type MockIScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScheduleRepositoryMockRecorder
+}
+
+// MockIScheduleRepositoryMockRecorder is the mock recorder for MockIScheduleRepository.
+type MockIScheduleRepositoryMockRecorder struct {
+	mock *MockIScheduleRepository
+}
+
+// NewMockIScheduleRepository creates a new mock instance.
+func NewMockIScheduleRepository(ctrl *gomock.Controller) *MockIScheduleRepository {
+	mock := &MockIScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockIScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScheduleRepository) EXPECT() *MockIScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimPlan mocks base method.
+func (m *MockIScheduleRepository) ClaimPlan(arg0 uint, arg1, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPlan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPlan indicates an expected call of ClaimPlan.
+func (mr *MockIScheduleRepositoryMockRecorder) ClaimPlan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPlan", reflect.TypeOf((*MockIScheduleRepository)(nil).ClaimPlan), arg0, arg1, arg2)
+}
+
+// CreatePlan mocks base method.
+func (m *MockIScheduleRepository) CreatePlan(arg0 ConversionPlan) (*ConversionPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlan", arg0)
+	ret0, _ := ret[0].(*ConversionPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlan indicates an expected call of CreatePlan.
+func (mr *MockIScheduleRepositoryMockRecorder) CreatePlan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlan", reflect.TypeOf((*MockIScheduleRepository)(nil).CreatePlan), arg0)
+}
+
+// CreateRun mocks base method.
+func (m *MockIScheduleRepository) CreateRun(arg0 ConversionRun) (*ConversionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", arg0)
+	ret0, _ := ret[0].(*ConversionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockIScheduleRepositoryMockRecorder) CreateRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockIScheduleRepository)(nil).CreateRun), arg0)
+}
+
+// GetPlan mocks base method.
+func (m *MockIScheduleRepository) GetPlan(arg0 uint) (*ConversionPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlan", arg0)
+	ret0, _ := ret[0].(*ConversionPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlan indicates an expected call of GetPlan.
+func (mr *MockIScheduleRepositoryMockRecorder) GetPlan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*MockIScheduleRepository)(nil).GetPlan), arg0)
+}
+
+// GetPlanForUpdate mocks base method.
+func (m *MockIScheduleRepository) GetPlanForUpdate(arg0 uint) (*ConversionPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanForUpdate", arg0)
+	ret0, _ := ret[0].(*ConversionPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlanForUpdate indicates an expected call of GetPlanForUpdate.
+func (mr *MockIScheduleRepositoryMockRecorder) GetPlanForUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanForUpdate", reflect.TypeOf((*MockIScheduleRepository)(nil).GetPlanForUpdate), arg0)
+}
+
+// ListDuePlans mocks base method.
+func (m *MockIScheduleRepository) ListDuePlans(arg0 time.Time) ([]ConversionPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDuePlans", arg0)
+	ret0, _ := ret[0].([]ConversionPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDuePlans indicates an expected call of ListDuePlans.
+func (mr *MockIScheduleRepositoryMockRecorder) ListDuePlans(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuePlans", reflect.TypeOf((*MockIScheduleRepository)(nil).ListDuePlans), arg0)
+}
+
+// ListPlanRuns mocks base method.
+func (m *MockIScheduleRepository) ListPlanRuns(arg0 uint) ([]ConversionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlanRuns", arg0)
+	ret0, _ := ret[0].([]ConversionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlanRuns indicates an expected call of ListPlanRuns.
+func (mr *MockIScheduleRepositoryMockRecorder) ListPlanRuns(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlanRuns", reflect.TypeOf((*MockIScheduleRepository)(nil).ListPlanRuns), arg0)
+}
+
+// ListUserPlans mocks base method.
+func (m *MockIScheduleRepository) ListUserPlans(arg0 uint) ([]ConversionPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPlans", arg0)
+	ret0, _ := ret[0].([]ConversionPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPlans indicates an expected call of ListUserPlans.
+func (mr *MockIScheduleRepositoryMockRecorder) ListUserPlans(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPlans", reflect.TypeOf((*MockIScheduleRepository)(nil).ListUserPlans), arg0)
+}
+
+// Migration mocks base method.
+func (m *MockIScheduleRepository) Migration() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migration")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migration indicates an expected call of Migration.
+func (mr *MockIScheduleRepositoryMockRecorder) Migration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockIScheduleRepository)(nil).Migration))
+}
+
+// UpdatePlan mocks base method.
+func (m *MockIScheduleRepository) UpdatePlan(arg0 ConversionPlan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlan", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlan indicates an expected call of UpdatePlan.
+func (mr *MockIScheduleRepositoryMockRecorder) UpdatePlan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlan", reflect.TypeOf((*MockIScheduleRepository)(nil).UpdatePlan), arg0)
+}
+
+// WithTx mocks base method.
+func (m *MockIScheduleRepository) WithTx(arg0 *gorm.DB) IScheduleRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IScheduleRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIScheduleRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIScheduleRepository)(nil).WithTx), arg0)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/schedule (interfaces: IScheduleService)
+
+// Package schedule is a generated GoMock package.
+package schedule
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIScheduleService is a mock of IScheduleService interface.
+type MockIScheduleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScheduleServiceMockRecorder
+}
+
+// MockIScheduleServiceMockRecorder is the mock recorder for MockIScheduleService.
+type MockIScheduleServiceMockRecorder struct {
+	mock *MockIScheduleService
+}
+
+// NewMockIScheduleService creates a new mock instance.
+func NewMockIScheduleService(ctrl *gomock.Controller) *MockIScheduleService {
+	mock := &MockIScheduleService{ctrl: ctrl}
+	mock.recorder = &MockIScheduleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScheduleService) EXPECT() *MockIScheduleServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelPlan mocks base method.
+func (m *MockIScheduleService) CancelPlan(arg0, arg1 uint) (*PlanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPlan", arg0, arg1)
+	ret0, _ := ret[0].(*PlanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPlan indicates an expected call of CancelPlan.
+func (mr *MockIScheduleServiceMockRecorder) CancelPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPlan", reflect.TypeOf((*MockIScheduleService)(nil).CancelPlan), arg0, arg1)
+}
+
+// CreatePlan mocks base method.
+func (m *MockIScheduleService) CreatePlan(arg0 uint, arg1 PlanRequest) (*PlanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlan", arg0, arg1)
+	ret0, _ := ret[0].(*PlanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlan indicates an expected call of CreatePlan.
+func (mr *MockIScheduleServiceMockRecorder) CreatePlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlan", reflect.TypeOf((*MockIScheduleService)(nil).CreatePlan), arg0, arg1)
+}
+
+// ListPlans mocks base method.
+func (m *MockIScheduleService) ListPlans(arg0 uint) ([]PlanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlans", arg0)
+	ret0, _ := ret[0].([]PlanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlans indicates an expected call of ListPlans.
+func (mr *MockIScheduleServiceMockRecorder) ListPlans(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlans", reflect.TypeOf((*MockIScheduleService)(nil).ListPlans), arg0)
+}
+
+// ListRuns mocks base method.
+func (m *MockIScheduleService) ListRuns(arg0, arg1 uint) ([]RunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", arg0, arg1)
+	ret0, _ := ret[0].([]RunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockIScheduleServiceMockRecorder) ListRuns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockIScheduleService)(nil).ListRuns), arg0, arg1)
+}
+
+// RunDuePlans mocks base method.
+func (m *MockIScheduleService) RunDuePlans() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDuePlans")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunDuePlans indicates an expected call of RunDuePlans.
+func (mr *MockIScheduleServiceMockRecorder) RunDuePlans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDuePlans", reflect.TypeOf((*MockIScheduleService)(nil).RunDuePlans))
+}
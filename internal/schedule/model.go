@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+)
+
+// Interval is how often a conversion plan runs, later runs keep the time of day of the first run
+type Interval string
+
+const (
+	IntervalDaily   Interval = "DAILY"
+	IntervalWeekly  Interval = "WEEKLY"
+	IntervalMonthly Interval = "MONTHLY" // Runs on the day of month of the first run, or the last day of shorter months
+)
+
+// PlanStatus is the lifecycle state of a conversion plan, a cancelled plan does not run again
+type PlanStatus string
+
+const (
+	PlanStatusActive    PlanStatus = "ACTIVE"
+	PlanStatusCancelled PlanStatus = "CANCELLED"
+)
+
+// InsufficientBalancePolicy is what a plan does when the user can not afford a run
+type InsufficientBalancePolicy string
+
+const (
+	// InsufficientBalanceSkip skips the period and runs again on the next one
+	InsufficientBalanceSkip InsufficientBalancePolicy = "SKIP"
+	// InsufficientBalanceRetry tries again later in the same period up to the max retries of the plan, then skips the period
+	InsufficientBalanceRetry InsufficientBalancePolicy = "RETRY"
+)
+
+// RunOutcome is the result of a single attempt of a conversion plan
+type RunOutcome string
+
+const (
+	RunOutcomeSucceeded RunOutcome = "SUCCEEDED"
+	RunOutcomeRetrying  RunOutcome = "RETRYING" // Not enough balance, the period is tried again later
+	RunOutcomeSkipped   RunOutcome = "SKIPPED"  // Not enough balance, the period is given up
+	RunOutcomeFailed    RunOutcome = "FAILED"   // Offer could not be quoted or accepted for another reason, the period is given up
+)
+
+// ConversionPlan sells the same amount on every period through an offer accepted on the user's behalf
+type ConversionPlan struct {
+	Id                    uint                      `gorm:"primaryKey;autoIncrement"`
+	UserId                uint                      `gorm:"not null;index"`
+	FromCurrencyCode      string                    `gorm:"not null"`
+	ToCurrencyCode        string                    `gorm:"not null"`
+	Amount                decimal.Decimal           `gorm:"type:numeric;not null"` // In from currency, sold on every run
+	Interval              Interval                  `gorm:"type:varchar(16);not null"`
+	StartAt               time.Time                 `gorm:"not null"` // Time of the first run, every period is scheduled from it
+	Period                int                       `gorm:"not null"` // Number of periods the plan ran or gave up
+	OnInsufficientBalance InsufficientBalancePolicy `gorm:"type:varchar(16);not null"`
+	MaxRetries            int                       `gorm:"not null"`
+	Attempt               int                       `gorm:"not null"` // Failed attempts in the current period
+	NextRunAt             time.Time                 `gorm:"not null;index"`
+	Status                PlanStatus                `gorm:"type:varchar(16);not null;index"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// ConversionRun is the history record of one attempt of a conversion plan
+type ConversionRun struct {
+	Id           uint                `gorm:"primaryKey;autoIncrement"`
+	PlanId       uint                `gorm:"not null;index"`
+	UserId       uint                `gorm:"not null"`
+	ScheduledAt  time.Time           `gorm:"not null"` // Scheduled time of the period the attempt belongs to
+	Attempt      int                 `gorm:"not null"`
+	Outcome      RunOutcome          `gorm:"type:varchar(16);not null"`
+	OfferId      *uint               // Offer the run was quoted with, empty when no offer could be quoted
+	ExchangeRate decimal.NullDecimal `gorm:"type:numeric"`
+	SellAmount   decimal.NullDecimal `gorm:"type:numeric"`
+	BuyAmount    decimal.NullDecimal `gorm:"type:numeric"`
+	Error        string              `gorm:"type:text"`
+	CreatedAt    time.Time
+}
+
+type PlanRequest struct {
+	FromCurrencyCode      string          `json:"from_currency_code" extensions:"x-order=1" example:"USD" validate:"required" valid:"required~from_currency_code|invalid"`  // From currency code
+	ToCurrencyCode        string          `json:"to_currency_code" extensions:"x-order=2" example:"TRY" validate:"required" valid:"required~to_currency_code|invalid"`      // To currency code
+	Amount                decimal.Decimal `json:"amount" extensions:"x-order=3" swaggertype:"string" example:"1000.00" validate:"required" valid:"required~amount|invalid"` // Amount to sell in from currency on every run
+	Interval              string          `json:"interval" extensions:"x-order=4" example:"MONTHLY" validate:"required" valid:"required~interval|invalid"`                  // DAILY, WEEKLY or MONTHLY
+	StartAt               *time.Time      `json:"start_at" extensions:"x-order=5" example:"2022-12-25T09:00:00Z" valid:"optional"`                                          // Time of the first run, now by default
+	OnInsufficientBalance string          `json:"on_insufficient_balance" extensions:"x-order=6" example:"RETRY" valid:"optional"`                                          // SKIP or RETRY, SKIP by default
+	MaxRetries            int             `json:"max_retries" extensions:"x-order=7" example:"3" valid:"optional"`                                                          // Retries in a period on RETRY policy, 3 by default
+}
+
+type PlanResponse struct {
+	Id                    uint                      `json:"id" extensions:"x-order=1" example:"3"`                                               // ID of the plan
+	FromCurrencyCode      string                    `json:"from_currency_code" extensions:"x-order=2" example:"USD"`                             // From currency code
+	ToCurrencyCode        string                    `json:"to_currency_code" extensions:"x-order=3" example:"TRY"`                               // To currency code
+	Amount                decimal.Decimal           `json:"amount" extensions:"x-order=4" swaggertype:"string" example:"1000.00"`                // Amount to sell in from currency on every run
+	Interval              Interval                  `json:"interval" extensions:"x-order=5" swaggertype:"string" example:"MONTHLY"`              // DAILY, WEEKLY or MONTHLY
+	OnInsufficientBalance InsufficientBalancePolicy `json:"on_insufficient_balance" extensions:"x-order=6" swaggertype:"string" example:"RETRY"` // SKIP or RETRY
+	MaxRetries            int                       `json:"max_retries" extensions:"x-order=7" example:"3"`                                      // Retries in a period on RETRY policy
+	Status                PlanStatus                `json:"status" extensions:"x-order=8" swaggertype:"string" example:"ACTIVE"`                 // ACTIVE or CANCELLED
+	NextRunAt             time.Time                 `json:"next_run_at" extensions:"x-order=9" example:"2023-01-25T09:00:00Z"`                   // Time of the next attempt
+	CreatedAt             time.Time                 `json:"created_at" extensions:"x-order=10" example:"2022-12-01T10:00:00Z"`                   // Time the plan was created
+}
+
+type RunResponse struct {
+	Id           uint                `json:"id" extensions:"x-order=1" example:"12"`                                              // ID of the run
+	ScheduledAt  time.Time           `json:"scheduled_at" extensions:"x-order=2" example:"2022-12-25T09:00:00Z"`                  // Scheduled time of the period
+	Attempt      int                 `json:"attempt" extensions:"x-order=3" example:"1"`                                          // Attempt in the period starting from 1
+	Outcome      RunOutcome          `json:"outcome" extensions:"x-order=4" swaggertype:"string" example:"SUCCEEDED"`             // SUCCEEDED, RETRYING, SKIPPED or FAILED
+	OfferId      *uint               `json:"offer_id,omitempty" extensions:"x-order=5" example:"4"`                               // Offer the run was quoted with
+	ExchangeRate decimal.NullDecimal `json:"exchange_rate,omitempty" extensions:"x-order=6" swaggertype:"string" example:"18.63"` // Offered exchange rate
+	SellAmount   decimal.NullDecimal `json:"sell_amount,omitempty" extensions:"x-order=7" swaggertype:"string" example:"1000.00"` // Amount debited in from currency
+	BuyAmount    decimal.NullDecimal `json:"buy_amount,omitempty" extensions:"x-order=8" swaggertype:"string" example:"18630.00"` // Amount credited in to currency
+	Error        string              `json:"error,omitempty" extensions:"x-order=9" example:"not enough balance"`                 // Reason of an unsuccessful run
+	CreatedAt    time.Time           `json:"created_at" extensions:"x-order=10" example:"2022-12-25T09:00:05Z"`                   // Time of the attempt
+}
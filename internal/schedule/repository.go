@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IScheduleRepository interface {
+	CreatePlan(plan ConversionPlan) (*ConversionPlan, error)
+	GetPlan(id uint) (*ConversionPlan, error)
+	GetPlanForUpdate(id uint) (*ConversionPlan, error)
+	UpdatePlan(plan ConversionPlan) error
+	ListUserPlans(userId uint) ([]ConversionPlan, error)
+	ListDuePlans(now time.Time) ([]ConversionPlan, error)
+	ClaimPlan(id uint, nextRunAt, leaseUntil time.Time) (bool, error)
+	CreateRun(run ConversionRun) (*ConversionRun, error)
+	ListPlanRuns(planId uint) ([]ConversionRun, error)
+	WithTx(tx *gorm.DB) IScheduleRepository
+	Migration() error
+}
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) IScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) WithTx(tx *gorm.DB) IScheduleRepository {
+	return NewScheduleRepository(tx)
+}
+
+func (r *scheduleRepository) Migration() error {
+	return r.db.AutoMigrate(ConversionPlan{}, ConversionRun{})
+}
+
+func (r *scheduleRepository) CreatePlan(plan ConversionPlan) (*ConversionPlan, error) {
+	if err := r.db.Create(&plan).Error; err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *scheduleRepository) GetPlan(id uint) (*ConversionPlan, error) {
+	var plan *ConversionPlan
+	if err := r.db.Where("id =?", id).First(&plan).Error; err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (r *scheduleRepository) GetPlanForUpdate(id uint) (*ConversionPlan, error) {
+	var plan *ConversionPlan
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id =?", id).First(&plan).Error; err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (r *scheduleRepository) UpdatePlan(plan ConversionPlan) error {
+	return r.db.Save(&plan).Error
+}
+
+// ListUserPlans returns the user's plans newest first
+func (r *scheduleRepository) ListUserPlans(userId uint) ([]ConversionPlan, error) {
+	var plans []ConversionPlan
+	if err := r.db.Where("user_id =?", userId).Order("id DESC").Find(&plans).Error; err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// ListDuePlans returns the active plans whose next run is not after now, the longest waiting first
+func (r *scheduleRepository) ListDuePlans(now time.Time) ([]ConversionPlan, error) {
+	var plans []ConversionPlan
+	if err := r.db.Where("status =? AND next_run_at <= ?", PlanStatusActive, now).Order("next_run_at").Find(&plans).Error; err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// ClaimPlan moves the next run of an active plan to the lease time only when it is still the given time,
+// so a run is started once even when more than one instance of the service picks the same due plan
+func (r *scheduleRepository) ClaimPlan(id uint, nextRunAt, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&ConversionPlan{}).
+		Where("id =? AND status =? AND next_run_at =?", id, PlanStatusActive, nextRunAt).
+		Update("next_run_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *scheduleRepository) CreateRun(run ConversionRun) (*ConversionRun, error) {
+	if err := r.db.Create(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListPlanRuns returns the runs of the plan newest first
+func (r *scheduleRepository) ListPlanRuns(planId uint) ([]ConversionRun, error) {
+	var runs []ConversionRun
+	if err := r.db.Where("plan_id =?", planId).Order("id DESC").Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,73 @@
+package schedule
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleRepository_ListDuePlans(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewScheduleRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "conversion_plans" WHERE status =$1 AND next_run_at <= $2 ORDER BY next_run_at`)).
+		WithArgs(PlanStatusActive, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(3, PlanStatusActive).AddRow(4, PlanStatusActive))
+
+	plans, err := r.ListDuePlans(now)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, plans, 2)
+}
+
+func TestScheduleRepository_ClaimPlan(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewScheduleRepository(db)
+	nextRunAt := time.Now()
+	leaseUntil := nextRunAt.Add(planLease)
+
+	t.Run("claimed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "conversion_plans" SET "next_run_at"=$1,"updated_at"=$2 WHERE id =$3 AND status =$4 AND next_run_at =$5`)).
+			WithArgs(leaseUntil, sqlmock.AnyArg(), 3, PlanStatusActive, nextRunAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		claimed, err := r.ClaimPlan(3, nextRunAt, leaseUntil)
+		assert.Nil(t, err)
+		assert.True(t, claimed)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("claimed by another run", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "conversion_plans" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		claimed, err := r.ClaimPlan(3, nextRunAt, leaseUntil)
+		assert.Nil(t, err)
+		assert.False(t, claimed)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestScheduleRepository_ListPlanRuns(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewScheduleRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "conversion_runs" WHERE plan_id =$1 ORDER BY id DESC`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "plan_id", "outcome"}).AddRow(12, 3, RunOutcomeSkipped))
+
+	runs, err := r.ListPlanRuns(3)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Len(t, runs, 1)
+	assert.Equal(t, RunOutcomeSkipped, runs[0].Outcome)
+}
@@ -0,0 +1,52 @@
+package schedule
+
+import (
+	// Go imports
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultRunInterval is used when no run interval is configured
+const DefaultRunInterval = time.Minute
+
+// Scheduler runs the due conversion plans on every interval
+type Scheduler struct {
+	scheduleService IScheduleService
+	interval        time.Duration
+	done            chan struct{}
+}
+
+func NewScheduler(scheduleService IScheduleService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultRunInterval
+	}
+	return &Scheduler{scheduleService: scheduleService, interval: interval, done: make(chan struct{})}
+}
+
+// Start runs the due plans on every interval until the context is done, a run in progress is finished first
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := s.scheduleService.RunDuePlans(); err != nil {
+				log.Println("conversion plans run:", err.Error())
+			}
+		}
+	}()
+}
+
+// Done is closed once the scheduler stopped after its context is done
+func (s *Scheduler) Done() <-chan struct{} {
+	return s.done
+}
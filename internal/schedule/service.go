@@ -0,0 +1,372 @@
+package schedule
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+const (
+	defaultMaxRetries = 3
+	maxMaxRetries     = 24
+	// retryInterval is the wait before another attempt when the user can not afford a run
+	retryInterval = time.Hour
+	// planLease is how long a claimed plan is not picked again while it is being run
+	planLease = 10 * time.Minute
+)
+
+var (
+	ErrInvalidPlan  = errors.New("invalid conversion plan")
+	ErrPlanNotFound = errors.New("conversion plan not found")
+	ErrPlanClosed   = errors.New("conversion plan is not active")
+)
+
+type IScheduleService interface {
+	CreatePlan(userId uint, request PlanRequest) (*PlanResponse, error)
+	ListPlans(userId uint) ([]PlanResponse, error)
+	CancelPlan(userId, planId uint) (*PlanResponse, error)
+	ListRuns(userId, planId uint) ([]RunResponse, error)
+	RunDuePlans() error
+}
+
+type scheduleService struct {
+	scheduleRepo    IScheduleRepository
+	exchangeService exchange.IExchangeService
+	currencyService currency.Service
+	accountService  account.IAccountService
+	unitOfWork      uow.IUnitOfWork
+	clock           clock.IClock
+}
+
+func NewScheduleService(scheduleRepository IScheduleRepository, exchangeService exchange.IExchangeService, currencyService currency.Service, accountService account.IAccountService, unitOfWork uow.IUnitOfWork, clock clock.IClock) IScheduleService {
+	return &scheduleService{scheduleRepo: scheduleRepository, exchangeService: exchangeService, currencyService: currencyService, accountService: accountService, unitOfWork: unitOfWork, clock: clock}
+}
+
+func (s *scheduleService) CreatePlan(userId uint, request PlanRequest) (*PlanResponse, error) {
+	fromCurrencyCode := strings.ToUpper(request.FromCurrencyCode)
+	toCurrencyCode := strings.ToUpper(request.ToCurrencyCode)
+	if fromCurrencyCode == toCurrencyCode {
+		return nil, fmt.Errorf("%w: currencies must differ", ErrInvalidPlan)
+	}
+
+	for _, currencyCode := range []string{fromCurrencyCode, toCurrencyCode} {
+		if ok := s.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+			return nil, fmt.Errorf("%w: currency %s not found", ErrInvalidPlan, currencyCode)
+		}
+	}
+
+	amount := currency.Round(request.Amount, fromCurrencyCode)
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidPlan)
+	}
+
+	interval := Interval(strings.ToUpper(request.Interval))
+	switch interval {
+	case IntervalDaily, IntervalWeekly, IntervalMonthly:
+	default:
+		return nil, fmt.Errorf("%w: unknown interval %s", ErrInvalidPlan, request.Interval)
+	}
+
+	policy := InsufficientBalancePolicy(strings.ToUpper(request.OnInsufficientBalance))
+	maxRetries := request.MaxRetries
+	switch policy {
+	case "", InsufficientBalanceSkip:
+		policy = InsufficientBalanceSkip
+		maxRetries = 0
+	case InsufficientBalanceRetry:
+		if maxRetries == 0 {
+			maxRetries = defaultMaxRetries
+		}
+		if maxRetries < 0 || maxRetries > maxMaxRetries {
+			return nil, fmt.Errorf("%w: max retries must be between 1 and %d", ErrInvalidPlan, maxMaxRetries)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown insufficient balance policy %s", ErrInvalidPlan, request.OnInsufficientBalance)
+	}
+
+	now := s.clock.Now()
+	startAt := now
+	if request.StartAt != nil {
+		if request.StartAt.Before(now) {
+			return nil, fmt.Errorf("%w: start can not be in the past", ErrInvalidPlan)
+		}
+		startAt = *request.StartAt
+	}
+
+	if ok := s.accountService.IsUserHasAccountOnGivenCurrency(userId, fromCurrencyCode); !ok {
+		return nil, fmt.Errorf("%s account not found", fromCurrencyCode)
+	}
+
+	plan, err := s.scheduleRepo.CreatePlan(ConversionPlan{
+		UserId:                userId,
+		FromCurrencyCode:      fromCurrencyCode,
+		ToCurrencyCode:        toCurrencyCode,
+		Amount:                amount,
+		Interval:              interval,
+		StartAt:               startAt,
+		OnInsufficientBalance: policy,
+		MaxRetries:            maxRetries,
+		NextRunAt:             startAt,
+		Status:                PlanStatusActive,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPlanResponse(*plan)
+	return &response, nil
+}
+
+func (s *scheduleService) ListPlans(userId uint) ([]PlanResponse, error) {
+	plans, err := s.scheduleRepo.ListUserPlans(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []PlanResponse{}
+	for _, plan := range plans {
+		responses = append(responses, toPlanResponse(plan))
+	}
+
+	return responses, nil
+}
+
+// CancelPlan stops an active plan of the user, a run in progress still completes and is recorded
+func (s *scheduleService) CancelPlan(userId, planId uint) (*PlanResponse, error) {
+	var cancelled *ConversionPlan
+	if err := s.unitOfWork.Do(func(tx *gorm.DB) error {
+		plan, err := s.scheduleRepo.WithTx(tx).GetPlanForUpdate(planId)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && plan.UserId != userId) {
+			return fmt.Errorf("%w: %d", ErrPlanNotFound, planId)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if plan.Status != PlanStatusActive {
+			return fmt.Errorf("%w: plan is %s", ErrPlanClosed, plan.Status)
+		}
+
+		plan.Status = PlanStatusCancelled
+		plan.UpdatedAt = s.clock.Now()
+		cancelled = plan
+		return s.scheduleRepo.WithTx(tx).UpdatePlan(*plan)
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toPlanResponse(*cancelled)
+	return &response, nil
+}
+
+func (s *scheduleService) ListRuns(userId, planId uint) ([]RunResponse, error) {
+	plan, err := s.scheduleRepo.GetPlan(planId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && plan.UserId != userId) {
+		return nil, fmt.Errorf("%w: %d", ErrPlanNotFound, planId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.scheduleRepo.ListPlanRuns(planId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []RunResponse{}
+	for _, run := range runs {
+		responses = append(responses, toRunResponse(run))
+	}
+
+	return responses, nil
+}
+
+// RunDuePlans runs every due plan once. A run which could not convert is recorded with its outcome,
+// only the plans whose run could not be recorded are reported.
+func (s *scheduleService) RunDuePlans() error {
+	plans, err := s.scheduleRepo.ListDuePlans(s.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, plan := range plans {
+		if err = s.runPlan(plan); err != nil {
+			failures = append(failures, fmt.Sprintf("%d: %s", plan.Id, err.Error()))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("can not run conversion plans: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+func (s *scheduleService) runPlan(plan ConversionPlan) error {
+	now := s.clock.Now()
+	claimed, err := s.scheduleRepo.ClaimPlan(plan.Id, plan.NextRunAt, now.Add(planLease))
+	if err != nil || !claimed {
+		return err
+	}
+
+	run := ConversionRun{
+		PlanId:      plan.Id,
+		UserId:      plan.UserId,
+		ScheduledAt: scheduledAt(plan, plan.Period),
+		Attempt:     plan.Attempt + 1,
+		CreatedAt:   now,
+	}
+
+	offer, convertErr := s.convert(plan)
+	if offer != nil {
+		run.OfferId = &offer.OfferId
+		run.ExchangeRate = decimal.NewNullDecimal(offer.ExchangeRate)
+		run.SellAmount = offer.SellAmount
+		run.BuyAmount = offer.BuyAmount
+	}
+
+	if convertErr != nil {
+		run.Error = convertErr.Error()
+	}
+
+	run.Outcome, plan = nextAttempt(plan, convertErr, now)
+
+	// The plan is locked again so a cancel made while the run was in progress is kept
+	return s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txScheduleRepo := s.scheduleRepo.WithTx(tx)
+		if _, err := txScheduleRepo.CreateRun(run); err != nil {
+			return err
+		}
+
+		locked, err := txScheduleRepo.GetPlanForUpdate(plan.Id)
+		if err != nil {
+			return err
+		}
+
+		locked.Period = plan.Period
+		locked.Attempt = plan.Attempt
+		locked.NextRunAt = plan.NextRunAt
+		locked.UpdatedAt = now
+		return txScheduleRepo.UpdatePlan(*locked)
+	})
+}
+
+// convert quotes an offer for the amount of the plan and accepts it, the offer is returned even when it could not be accepted
+func (s *scheduleService) convert(plan ConversionPlan) (*exchange.OfferResponse, error) {
+	offer, err := s.exchangeService.GetExchangeRateOffer(plan.UserId, exchange.OfferRequest{
+		FromCurrencyCode: plan.FromCurrencyCode,
+		ToCurrencyCode:   plan.ToCurrencyCode,
+		SellAmount:       plan.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.exchangeService.AcceptExchangeRateOffer(plan.UserId, exchange.AcceptOfferRequest{OfferId: offer.OfferId})
+	return offer, err
+}
+
+// nextAttempt returns the outcome of an attempt and the plan scheduled for its next attempt.
+// An attempt short of balance is retried in the same period only when the plan asks for it,
+// it has retries left and the retry does not reach the next period.
+func nextAttempt(plan ConversionPlan, err error, now time.Time) (RunOutcome, ConversionPlan) {
+	if err == nil {
+		return RunOutcomeSucceeded, advance(plan, now)
+	}
+
+	if !errors.Is(err, account.ErrNotEnoughBalance) {
+		return RunOutcomeFailed, advance(plan, now)
+	}
+
+	retryAt := now.Add(retryInterval)
+	if plan.OnInsufficientBalance == InsufficientBalanceRetry && plan.Attempt < plan.MaxRetries && retryAt.Before(scheduledAt(plan, plan.Period+1)) {
+		plan.Attempt++
+		plan.NextRunAt = retryAt
+		return RunOutcomeRetrying, plan
+	}
+
+	return RunOutcomeSkipped, advance(plan, now)
+}
+
+// advance moves the plan to its next period after now, periods missed while the service was down are not run
+func advance(plan ConversionPlan, now time.Time) ConversionPlan {
+	plan.Period++
+	for !scheduledAt(plan, plan.Period).After(now) {
+		plan.Period++
+	}
+
+	plan.Attempt = 0
+	plan.NextRunAt = scheduledAt(plan, plan.Period)
+	return plan
+}
+
+// scheduledAt returns the scheduled time of the given period of the plan, the first period is 0
+func scheduledAt(plan ConversionPlan, period int) time.Time {
+	switch plan.Interval {
+	case IntervalDaily:
+		return plan.StartAt.AddDate(0, 0, period)
+	case IntervalWeekly:
+		return plan.StartAt.AddDate(0, 0, 7*period)
+	default:
+		return addMonths(plan.StartAt, period)
+	}
+}
+
+// addMonths adds months keeping the day of month, days missing in shorter months fall on their last day
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func toPlanResponse(plan ConversionPlan) PlanResponse {
+	return PlanResponse{
+		Id:                    plan.Id,
+		FromCurrencyCode:      plan.FromCurrencyCode,
+		ToCurrencyCode:        plan.ToCurrencyCode,
+		Amount:                plan.Amount,
+		Interval:              plan.Interval,
+		OnInsufficientBalance: plan.OnInsufficientBalance,
+		MaxRetries:            plan.MaxRetries,
+		Status:                plan.Status,
+		NextRunAt:             plan.NextRunAt,
+		CreatedAt:             plan.CreatedAt,
+	}
+}
+
+func toRunResponse(run ConversionRun) RunResponse {
+	return RunResponse{
+		Id:           run.Id,
+		ScheduledAt:  run.ScheduledAt,
+		Attempt:      run.Attempt,
+		Outcome:      run.Outcome,
+		OfferId:      run.OfferId,
+		ExchangeRate: run.ExchangeRate,
+		SellAmount:   run.SellAmount,
+		BuyAmount:    run.BuyAmount,
+		Error:        run.Error,
+		CreatedAt:    run.CreatedAt,
+	}
+}
@@ -0,0 +1,208 @@
+package schedule
+
+import (
+	// Go imports
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+)
+
+func TestScheduleService_CreatePlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockScheduleRepository := NewMockIScheduleRepository(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	scheduleService := NewScheduleService(mockScheduleRepository, exchange.NewMockIExchangeService(ctrl), currencyService, accService, uow.NewMockIUnitOfWork(ctrl), clock.NewClock())
+	userId := uint(1)
+
+	t.Run("unknown interval", func(t *testing.T) {
+		_, err := scheduleService.CreatePlan(userId, PlanRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Amount: decimal.NewFromInt(100), Interval: "HOURLY"})
+		assert.ErrorIs(t, err, ErrInvalidPlan)
+	})
+
+	t.Run("too many retries", func(t *testing.T) {
+		_, err := scheduleService.CreatePlan(userId, PlanRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Amount: decimal.NewFromInt(100), Interval: "DAILY", OnInsufficientBalance: "RETRY", MaxRetries: 100})
+		assert.ErrorIs(t, err, ErrInvalidPlan)
+	})
+
+	t.Run("start in the past", func(t *testing.T) {
+		startAt := time.Now().Add(-time.Hour)
+		_, err := scheduleService.CreatePlan(userId, PlanRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", Amount: decimal.NewFromInt(100), Interval: "DAILY", StartAt: &startAt})
+		assert.ErrorIs(t, err, ErrInvalidPlan)
+	})
+
+	t.Run("plan created with default retries", func(t *testing.T) {
+		startAt := time.Now().Add(time.Hour)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(userId, "USD").Return(true)
+		mockScheduleRepository.EXPECT().CreatePlan(gomock.Any()).DoAndReturn(func(plan ConversionPlan) (*ConversionPlan, error) {
+			assert.Equal(t, PlanStatusActive, plan.Status)
+			assert.Equal(t, startAt, plan.NextRunAt)
+			plan.Id = 3
+			return &plan, nil
+		})
+
+		plan, err := scheduleService.CreatePlan(userId, PlanRequest{FromCurrencyCode: "usd", ToCurrencyCode: "try", Amount: decimal.NewFromInt(100), Interval: "monthly", StartAt: &startAt, OnInsufficientBalance: "retry"})
+		assert.Nil(t, err)
+		assert.Equal(t, uint(3), plan.Id)
+		assert.Equal(t, IntervalMonthly, plan.Interval)
+		assert.Equal(t, defaultMaxRetries, plan.MaxRetries)
+	})
+}
+
+func TestScheduleService_RunDuePlans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockScheduleRepository := NewMockIScheduleRepository(ctrl)
+	mockExchangeService := exchange.NewMockIExchangeService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	scheduleService := NewScheduleService(mockScheduleRepository, mockExchangeService, currency.Service{}, account.NewMockIAccountService(ctrl), mockUnitOfWork, clock.NewClock())
+	userId := uint(1)
+
+	mockScheduleRepository.EXPECT().WithTx(gomock.Any()).Return(mockScheduleRepository).AnyTimes()
+	duePlan := func(id uint, policy InsufficientBalancePolicy) ConversionPlan {
+		startAt := time.Now().Add(-time.Minute)
+		return ConversionPlan{
+			Id:                    id,
+			UserId:                userId,
+			FromCurrencyCode:      "USD",
+			ToCurrencyCode:        "TRY",
+			Amount:                decimal.NewFromInt(100),
+			Interval:              IntervalDaily,
+			StartAt:               startAt,
+			OnInsufficientBalance: policy,
+			MaxRetries:            1,
+			NextRunAt:             startAt,
+			Status:                PlanStatusActive,
+		}
+	}
+	offerRequest := exchange.OfferRequest{FromCurrencyCode: "USD", ToCurrencyCode: "TRY", SellAmount: decimal.NewFromInt(100)}
+	offer := &exchange.OfferResponse{OfferId: 9, ExchangeRate: decimal.NewFromInt(19), SellAmount: decimal.NewNullDecimal(decimal.NewFromInt(100)), BuyAmount: decimal.NewNullDecimal(decimal.NewFromInt(1900))}
+	expectRecorded := func(plan ConversionPlan, outcome RunOutcome, period, attempt int) {
+		mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+			return fn(nil)
+		})
+		mockScheduleRepository.EXPECT().CreateRun(gomock.Any()).DoAndReturn(func(run ConversionRun) (*ConversionRun, error) {
+			assert.Equal(t, plan.Id, run.PlanId)
+			assert.Equal(t, outcome, run.Outcome)
+			return &run, nil
+		})
+		mockScheduleRepository.EXPECT().GetPlanForUpdate(plan.Id).Return(&plan, nil)
+		mockScheduleRepository.EXPECT().UpdatePlan(gomock.Any()).DoAndReturn(func(updated ConversionPlan) error {
+			assert.Equal(t, period, updated.Period)
+			assert.Equal(t, attempt, updated.Attempt)
+			assert.True(t, updated.NextRunAt.After(time.Now()))
+			return nil
+		})
+	}
+
+	succeeded := duePlan(1, InsufficientBalanceSkip)
+	retrying := duePlan(2, InsufficientBalanceRetry)
+	skipped := duePlan(3, InsufficientBalanceSkip)
+	failed := duePlan(4, InsufficientBalanceSkip)
+	taken := duePlan(5, InsufficientBalanceSkip)
+	mockScheduleRepository.EXPECT().ListDuePlans(gomock.Any()).Return([]ConversionPlan{succeeded, retrying, skipped, failed, taken}, nil)
+	for _, plan := range []ConversionPlan{succeeded, retrying, skipped, failed} {
+		mockScheduleRepository.EXPECT().ClaimPlan(plan.Id, plan.NextRunAt, gomock.Any()).Return(true, nil)
+	}
+
+	// Another instance claimed the last plan first
+	mockScheduleRepository.EXPECT().ClaimPlan(taken.Id, taken.NextRunAt, gomock.Any()).Return(false, nil)
+
+	gomock.InOrder(
+		mockExchangeService.EXPECT().GetExchangeRateOffer(userId, offerRequest).Return(offer, nil),
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, exchange.AcceptOfferRequest{OfferId: 9}).Return(nil, nil),
+		mockExchangeService.EXPECT().GetExchangeRateOffer(userId, offerRequest).Return(offer, nil),
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, exchange.AcceptOfferRequest{OfferId: 9}).Return(nil, account.ErrNotEnoughBalance),
+		mockExchangeService.EXPECT().GetExchangeRateOffer(userId, offerRequest).Return(offer, nil),
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(userId, exchange.AcceptOfferRequest{OfferId: 9}).Return(nil, account.ErrNotEnoughBalance),
+		mockExchangeService.EXPECT().GetExchangeRateOffer(userId, offerRequest).Return(nil, errors.New("exchange rate not found")),
+	)
+	expectRecorded(succeeded, RunOutcomeSucceeded, 1, 0)
+	expectRecorded(retrying, RunOutcomeRetrying, 0, 1)
+	expectRecorded(skipped, RunOutcomeSkipped, 1, 0)
+	expectRecorded(failed, RunOutcomeFailed, 1, 0)
+
+	assert.Nil(t, scheduleService.RunDuePlans())
+}
+
+func TestNextAttempt(t *testing.T) {
+	now := time.Date(2022, 12, 1, 9, 0, 0, 0, time.UTC)
+	plan := ConversionPlan{Interval: IntervalDaily, StartAt: now, OnInsufficientBalance: InsufficientBalanceRetry, MaxRetries: 2}
+
+	t.Run("retries in the period", func(t *testing.T) {
+		outcome, next := nextAttempt(plan, account.ErrNotEnoughBalance, now)
+		assert.Equal(t, RunOutcomeRetrying, outcome)
+		assert.Equal(t, 1, next.Attempt)
+		assert.Equal(t, now.Add(retryInterval), next.NextRunAt)
+	})
+
+	t.Run("skips when retries are used", func(t *testing.T) {
+		exhausted := plan
+		exhausted.Attempt = 2
+		outcome, next := nextAttempt(exhausted, account.ErrNotEnoughBalance, now)
+		assert.Equal(t, RunOutcomeSkipped, outcome)
+		assert.Equal(t, 0, next.Attempt)
+		assert.Equal(t, now.AddDate(0, 0, 1), next.NextRunAt)
+	})
+
+	t.Run("skips when retry reaches the next period", func(t *testing.T) {
+		outcome, _ := nextAttempt(plan, account.ErrNotEnoughBalance, now.Add(23*time.Hour+30*time.Minute))
+		assert.Equal(t, RunOutcomeSkipped, outcome)
+	})
+
+	t.Run("missed periods are not run", func(t *testing.T) {
+		outcome, next := nextAttempt(plan, nil, now.AddDate(0, 0, 3).Add(time.Minute))
+		assert.Equal(t, RunOutcomeSucceeded, outcome)
+		assert.Equal(t, 4, next.Period)
+		assert.Equal(t, now.AddDate(0, 0, 4), next.NextRunAt)
+	})
+}
+
+func TestScheduledAt(t *testing.T) {
+	startAt := time.Date(2023, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2023, 2, 14, 9, 0, 0, 0, time.UTC), scheduledAt(ConversionPlan{Interval: IntervalWeekly, StartAt: startAt}, 2))
+	monthly := ConversionPlan{Interval: IntervalMonthly, StartAt: startAt}
+	assert.Equal(t, time.Date(2023, 2, 28, 9, 0, 0, 0, time.UTC), scheduledAt(monthly, 1))
+	assert.Equal(t, time.Date(2023, 3, 31, 9, 0, 0, 0, time.UTC), scheduledAt(monthly, 2))
+	assert.Equal(t, time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), scheduledAt(monthly, 13))
+}
+
+func TestScheduler_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockScheduleService := NewMockIScheduleService(ctrl)
+	scheduler := NewScheduler(mockScheduleService, time.Millisecond)
+
+	ran := make(chan struct{}, 2)
+	mockScheduleService.EXPECT().RunDuePlans().DoAndReturn(func() error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+		return nil
+	}).MinTimes(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	<-ran
+	<-ran
+	cancel()
+	<-scheduler.Done()
+}
@@ -0,0 +1,170 @@
+package transfer
+
+import (
+	// Go imports
+	"net/http"
+	"strconv"
+
+	// External imports
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/errors"
+	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/common"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+)
+
+type Handler interface {
+	Transfer(c *gin.Context)
+	ListTransfers(c *gin.Context)
+	GetTransfer(c *gin.Context)
+	TransferRoutes(router *gin.RouterGroup)
+}
+
+type transferHandler struct {
+	transferService ITransferService
+}
+
+func NewTransferHandler(transferService ITransferService) Handler {
+	return &transferHandler{transferService: transferService}
+}
+
+func (h *transferHandler) TransferRoutes(router *gin.RouterGroup) {
+	router.POST("/transfer", h.Transfer)
+	router.GET("/transfers", h.ListTransfers)
+	router.GET("/transfers/:id", h.GetTransfer)
+}
+
+// Transfer godoc
+// @Summary Transfer Money
+// @Description Send an amount to another user found by username or email, optionally converted into the default currency of the recipient
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request body TransferRequest true "body params"
+// @Success 200 {object} helper.Response{data=TransferResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/transfer [post]
+func (h *transferHandler) Transfer(c *gin.Context) {
+	var req TransferRequest
+	if err := c.BindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindJson.Error(), err.Error())
+		return
+	}
+
+	_, err := govalidator.ValidateStruct(req)
+	warnings := helper.WarningsFromValidationError(err)
+	if warnings != nil {
+		helper.Warning(c, warnings)
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	transfer, err := h.transferService.Transfer(userId, req)
+	if err != nil {
+		transferError(c, err)
+		return
+	}
+
+	helper.Success(c, transfer)
+}
+
+// ListTransfers godoc
+// @Summary List Transfers
+// @Description List the transfers the user sent or received newest first
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param request query TransferListRequest false "query params"
+// @Success 200 {object} helper.Response{data=TransferListResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/transfers [get]
+func (h *transferHandler) ListTransfers(c *gin.Context) {
+	var req TransferListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	transfers, err := h.transferService.ListTransfers(userId, req)
+	if err != nil {
+		transferError(c, err)
+		return
+	}
+
+	helper.Success(c, transfers)
+}
+
+// GetTransfer godoc
+// @Summary Get Transfer
+// @Description Get a transfer the user sent or received
+// @Tags Account
+// @Accept  json
+// @Produce  json
+// @Param X-Auth-Token header string true "Auth token of logged-in user."
+// @Param id path int true "ID of the transfer"
+// @Success 200 {object} helper.Response{data=TransferResponse} "Success"
+// @Failure 400 {object} helper.Response{error=helper.ResponseError} "Bad Request"
+// @Failure 403 {object} helper.Response{error=helper.ResponseError} "Forbidden"
+// @Failure 404 {object} helper.Response{error=helper.ResponseError} "Not Found"
+// @Failure 500 {object} helper.Response{error=helper.ResponseError} "Internal Server Error"
+// @Router /account/transfers/{id} [get]
+func (h *transferHandler) GetTransfer(c *gin.Context) {
+	transferId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, errors.ErrTransferError.Error(), "invalid transfer id")
+		return
+	}
+
+	userId, ok := common.GetUserIdFromContext(c)
+	if !ok {
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), "can not get user from context")
+		return
+	}
+
+	transfer, err := h.transferService.GetTransfer(userId, uint(transferId))
+	if err != nil {
+		transferError(c, err)
+		return
+	}
+
+	helper.Success(c, transfer)
+}
+
+func transferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidTransferFilter):
+		helper.Error(c, http.StatusBadRequest, errors.ErrBindQuery.Error(), err.Error())
+	case errors.Is(err, ErrInvalidTransfer), errors.Is(err, account.ErrNotEnoughBalance),
+		errors.Is(err, exchange.ErrRateNotFound), errors.Is(err, exchange.ErrInvalidOfferAmount):
+		helper.Error(c, http.StatusBadRequest, errors.ErrTransferError.Error(), err.Error())
+	case errors.Is(err, ErrRecipientNotFound), errors.Is(err, ErrTransferNotFound):
+		helper.Error(c, http.StatusNotFound, errors.ErrNotFoundError.Error(), err.Error())
+	case errors.Is(err, account.ErrAccountFrozen):
+		helper.Error(c, http.StatusConflict, errors.ErrAccountFrozenError.Error(), err.Error())
+	default:
+		helper.Error(c, http.StatusInternalServerError, errors.ErrTransferError.Error(), err.Error())
+	}
+}
@@ -0,0 +1,83 @@
+package transfer
+
+import (
+	// Go imports
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// External imports
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+)
+
+func TestTransferHandler_Transfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTransferService := NewMockITransferService(ctrl)
+	httpHandler := NewTransferHandler(mockTransferService)
+	gin.SetMode(gin.TestMode)
+	userId := uint(1)
+	router := gin.Default()
+	group := router.Group("/account")
+	group.Use(func(c *gin.Context) {
+		c.Set("user_id", userId)
+	})
+	httpHandler.TransferRoutes(group)
+
+	t.Run("missing recipient", func(t *testing.T) {
+		body := []byte(`{"currency_code": "USD", "amount": "10.00"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/transfer", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("recipient not found", func(t *testing.T) {
+		mockTransferService.EXPECT().Transfer(userId, TransferRequest{Recipient: "carol", CurrencyCode: "USD", Amount: decimal.RequireFromString("10.00")}).Return(nil, ErrRecipientNotFound)
+		body := []byte(`{"recipient": "carol", "currency_code": "USD", "amount": "10.00"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/transfer", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("not enough balance", func(t *testing.T) {
+		mockTransferService.EXPECT().Transfer(userId, gomock.Any()).Return(nil, account.ErrNotEnoughBalance)
+		body := []byte(`{"recipient": "bob", "currency_code": "USD", "amount": "10.00"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/transfer", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("successfully transfer", func(t *testing.T) {
+		mockTransferService.EXPECT().Transfer(userId, gomock.Any()).Return(&TransferResponse{Id: 7, Direction: DirectionSent}, nil)
+		body := []byte(`{"recipient": "bob", "currency_code": "USD", "amount": "10.00", "convert_to_recipient_currency": true, "note": "rent"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/account/transfer", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("list transfers with invalid filter", func(t *testing.T) {
+		mockTransferService.EXPECT().ListTransfers(userId, TransferListRequest{Direction: "BOTH"}).Return(nil, ErrInvalidTransferFilter)
+		req, _ := http.NewRequest(http.MethodGet, "/account/transfers?direction=BOTH", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get transfer of another user", func(t *testing.T) {
+		mockTransferService.EXPECT().GetTransfer(userId, uint(7)).Return(nil, ErrTransferNotFound)
+		req, _ := http.NewRequest(http.MethodGet, "/account/transfers/7", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/transfer (interfaces: ITransferRepository)
+
+// Package transfer is a generated GoMock package.
+package transfer
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockITransferRepository is a mock of ITransferRepository interface.
+type MockITransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITransferRepositoryMockRecorder
+}
+
+// MockITransferRepositoryMockRecorder is the mock recorder for MockITransferRepository.
+type MockITransferRepositoryMockRecorder struct {
+	mock *MockITransferRepository
+}
+
+// NewMockITransferRepository creates a new mock instance.
+func NewMockITransferRepository(ctrl *gomock.Controller) *MockITransferRepository {
+	mock := &MockITransferRepository{ctrl: ctrl}
+	mock.recorder = &MockITransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITransferRepository) EXPECT() *MockITransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTransfer mocks base method.
+func (m *MockITransferRepository) CreateTransfer(arg0 Transfer) (*Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0)
+	ret0, _ := ret[0].(*Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockITransferRepositoryMockRecorder) CreateTransfer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockITransferRepository)(nil).CreateTransfer), arg0)
+}
+
+// GetTransfer mocks base method.
+func (m *MockITransferRepository) GetTransfer(arg0 uint) (*Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0)
+	ret0, _ := ret[0].(*Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockITransferRepositoryMockRecorder) GetTransfer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockITransferRepository)(nil).GetTransfer), arg0)
+}
+
+// ListUserTransfers mocks base method.
+func (m *MockITransferRepository) ListUserTransfers(arg0 TransferFilter) ([]Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserTransfers", arg0)
+	ret0, _ := ret[0].([]Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserTransfers indicates an expected call of ListUserTransfers.
+func (mr *MockITransferRepositoryMockRecorder) ListUserTransfers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTransfers", reflect.TypeOf((*MockITransferRepository)(nil).ListUserTransfers), arg0)
+}
+
+// Migration mocks base method.
+func (m *MockITransferRepository) Migration() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migration")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migration indicates an expected call of Migration.
+func (mr *MockITransferRepositoryMockRecorder) Migration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockITransferRepository)(nil).Migration))
+}
+
+// WithTx mocks base method.
+func (m *MockITransferRepository) WithTx(arg0 *gorm.DB) ITransferRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(ITransferRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockITransferRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockITransferRepository)(nil).WithTx), arg0)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/transfer (interfaces: ITransferService)
+
+// Package transfer is a generated GoMock package.
+package transfer
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITransferService is a mock of ITransferService interface.
+type MockITransferService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITransferServiceMockRecorder
+}
+
+// MockITransferServiceMockRecorder is the mock recorder for MockITransferService.
+type MockITransferServiceMockRecorder struct {
+	mock *MockITransferService
+}
+
+// NewMockITransferService creates a new mock instance.
+func NewMockITransferService(ctrl *gomock.Controller) *MockITransferService {
+	mock := &MockITransferService{ctrl: ctrl}
+	mock.recorder = &MockITransferServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITransferService) EXPECT() *MockITransferServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTransfer mocks base method.
+func (m *MockITransferService) GetTransfer(arg0, arg1 uint) (*TransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(*TransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockITransferServiceMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockITransferService)(nil).GetTransfer), arg0, arg1)
+}
+
+// ListTransfers mocks base method.
+func (m *MockITransferService) ListTransfers(arg0 uint, arg1 TransferListRequest) (*TransferListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
+	ret0, _ := ret[0].(*TransferListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockITransferServiceMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockITransferService)(nil).ListTransfers), arg0, arg1)
+}
+
+// Transfer mocks base method.
+func (m *MockITransferService) Transfer(arg0 uint, arg1 TransferRequest) (*TransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", arg0, arg1)
+	ret0, _ := ret[0].(*TransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockITransferServiceMockRecorder) Transfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockITransferService)(nil).Transfer), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mehmetokdemir/currency-conversion-service/internal/transfer (interfaces: IUserResolver)
+
+// Package transfer is a generated GoMock package.
+package transfer
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	user "github.com/mehmetokdemir/currency-conversion-service/internal/user"
+)
+
+// MockIUserResolver is a mock of IUserResolver interface.
+type MockIUserResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserResolverMockRecorder
+}
+
+// MockIUserResolverMockRecorder is the mock recorder for MockIUserResolver.
+type MockIUserResolverMockRecorder struct {
+	mock *MockIUserResolver
+}
+
+// NewMockIUserResolver creates a new mock instance.
+func NewMockIUserResolver(ctrl *gomock.Controller) *MockIUserResolver {
+	mock := &MockIUserResolver{ctrl: ctrl}
+	mock.recorder = &MockIUserResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserResolver) EXPECT() *MockIUserResolverMockRecorder {
+	return m.recorder
+}
+
+// GetUserById mocks base method.
+func (m *MockIUserResolver) GetUserById(arg0 uint) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", arg0)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockIUserResolverMockRecorder) GetUserById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockIUserResolver)(nil).GetUserById), arg0)
+}
+
+// GetUserByUsernameOrEmail mocks base method.
+func (m *MockIUserResolver) GetUserByUsernameOrEmail(arg0 string) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsernameOrEmail", arg0)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsernameOrEmail indicates an expected call of GetUserByUsernameOrEmail.
+func (mr *MockIUserResolverMockRecorder) GetUserByUsernameOrEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsernameOrEmail", reflect.TypeOf((*MockIUserResolver)(nil).GetUserByUsernameOrEmail), arg0)
+}
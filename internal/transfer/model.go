@@ -0,0 +1,77 @@
+package transfer
+
+import (
+	// Go imports
+	"time"
+
+	// External imports
+	"github.com/shopspring/decimal"
+)
+
+// Direction is the side of a transfer the user is on
+type Direction string
+
+const (
+	DirectionSent     Direction = "SENT"
+	DirectionReceived Direction = "RECEIVED"
+)
+
+// Transfer moves money from one user to another. The recipient is credited in the transferred currency,
+// or in their default currency when the sender asked for a conversion through an exchange offer.
+type Transfer struct {
+	Id                    uint                `gorm:"primaryKey;autoIncrement"`
+	SenderUserId          uint                `gorm:"not null;index"`
+	SenderUsername        string              `gorm:"not null"`
+	RecipientUserId       uint                `gorm:"not null;index"`
+	RecipientUsername     string              `gorm:"not null"`
+	CurrencyCode          string              `gorm:"not null"`              // Currency debited from the sender
+	Amount                decimal.Decimal     `gorm:"type:numeric;not null"` // Amount debited from the sender
+	RecipientCurrencyCode string              `gorm:"not null"`              // Currency credited to the recipient
+	RecipientAmount       decimal.Decimal     `gorm:"type:numeric;not null"` // Amount credited to the recipient
+	OfferId               *uint               // Offer the amount was converted with, empty when it was not converted
+	ExchangeRate          decimal.NullDecimal `gorm:"type:numeric"`
+	Note                  string              `gorm:"type:text"`
+	CreatedAt             time.Time
+}
+
+// TransferFilter narrows the transfers of a user, transfers are returned newest first
+type TransferFilter struct {
+	UserId    uint
+	Direction Direction // Empty for both sent and received transfers
+	BeforeId  uint      // cursor, only transfers with a smaller id are returned
+	Limit     int
+}
+
+type TransferRequest struct {
+	Recipient                  string          `json:"recipient" extensions:"x-order=1" example:"jane@gmail.com" validate:"required" valid:"required~recipient|invalid"`        // Username or email of the recipient
+	CurrencyCode               string          `json:"currency_code" extensions:"x-order=2" example:"USD" validate:"required" valid:"required~currency_code|invalid"`           // Currency to send from
+	Amount                     decimal.Decimal `json:"amount" extensions:"x-order=3" swaggertype:"string" example:"100.00" validate:"required" valid:"required~amount|invalid"` // Amount to send in currency
+	ConvertToRecipientCurrency bool            `json:"convert_to_recipient_currency" extensions:"x-order=4" example:"true" valid:"optional"`                                    // Convert the amount into the default currency of the recipient
+	Note                       string          `json:"note" extensions:"x-order=5" example:"Dinner" valid:"optional"`                                                           // Message shown to both parties, at most 140 characters
+}
+
+type TransferListRequest struct {
+	Direction string `form:"direction"` // SENT or RECEIVED, both by default
+	Cursor    string `form:"cursor"`    // Next cursor of the previous page
+	Limit     int    `form:"limit"`     // Page size, 20 by default and at most 100
+}
+
+type TransferResponse struct {
+	Id                    uint                `json:"id" extensions:"x-order=1" example:"3"`                                                // ID of the transfer
+	Direction             Direction           `json:"direction" extensions:"x-order=2" swaggertype:"string" example:"SENT"`                 // SENT or RECEIVED from the user's side
+	SenderUsername        string              `json:"sender_username" extensions:"x-order=3" example:"john"`                                // Username of the sender
+	RecipientUsername     string              `json:"recipient_username" extensions:"x-order=4" example:"jane"`                             // Username of the recipient
+	CurrencyCode          string              `json:"currency_code" extensions:"x-order=5" example:"USD"`                                   // Currency debited from the sender
+	Amount                decimal.Decimal     `json:"amount" extensions:"x-order=6" swaggertype:"string" example:"100.00"`                  // Amount debited from the sender
+	RecipientCurrencyCode string              `json:"recipient_currency_code" extensions:"x-order=7" example:"TRY"`                         // Currency credited to the recipient
+	RecipientAmount       decimal.Decimal     `json:"recipient_amount" extensions:"x-order=8" swaggertype:"string" example:"1833.00"`       // Amount credited to the recipient
+	OfferId               *uint               `json:"offer_id,omitempty" extensions:"x-order=9" example:"4"`                                // Offer the amount was converted with
+	ExchangeRate          decimal.NullDecimal `json:"exchange_rate,omitempty" extensions:"x-order=10" swaggertype:"string" example:"18.33"` // Rate the amount was converted with
+	Note                  string              `json:"note,omitempty" extensions:"x-order=11" example:"Dinner"`                              // Message of the sender
+	CreatedAt             time.Time           `json:"created_at" extensions:"x-order=12" example:"2022-12-01T10:00:00Z"`                    // Time of the transfer
+}
+
+type TransferListResponse struct {
+	Transfers  []TransferResponse `json:"transfers" extensions:"x-order=1"`                         // Transfers newest first
+	NextCursor string             `json:"next_cursor,omitempty" extensions:"x-order=2" example:"3"` // Cursor of the next page, empty on the last page
+}
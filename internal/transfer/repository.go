@@ -0,0 +1,67 @@
+package transfer
+
+import (
+	// External imports
+	"gorm.io/gorm"
+)
+
+type ITransferRepository interface {
+	CreateTransfer(transfer Transfer) (*Transfer, error)
+	GetTransfer(id uint) (*Transfer, error)
+	ListUserTransfers(filter TransferFilter) ([]Transfer, error)
+	WithTx(tx *gorm.DB) ITransferRepository
+	Migration() error
+}
+
+type transferRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) ITransferRepository {
+	return &transferRepository{db: db}
+}
+
+func (r *transferRepository) WithTx(tx *gorm.DB) ITransferRepository {
+	return NewTransferRepository(tx)
+}
+
+func (r *transferRepository) Migration() error {
+	return r.db.AutoMigrate(Transfer{})
+}
+
+func (r *transferRepository) CreateTransfer(transfer Transfer) (*Transfer, error) {
+	if err := r.db.Create(&transfer).Error; err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *transferRepository) GetTransfer(id uint) (*Transfer, error) {
+	var transfer *Transfer
+	if err := r.db.Where("id =?", id).First(&transfer).Error; err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (r *transferRepository) ListUserTransfers(filter TransferFilter) ([]Transfer, error) {
+	query := r.db.Model(&Transfer{})
+	switch filter.Direction {
+	case DirectionSent:
+		query = query.Where("sender_user_id =?", filter.UserId)
+	case DirectionReceived:
+		query = query.Where("recipient_user_id =?", filter.UserId)
+	default:
+		query = query.Where("sender_user_id =? OR recipient_user_id =?", filter.UserId, filter.UserId)
+	}
+
+	if filter.BeforeId > 0 {
+		query = query.Where("id <?", filter.BeforeId)
+	}
+
+	var transfers []Transfer
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
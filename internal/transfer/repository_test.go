@@ -0,0 +1,37 @@
+package transfer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferRepository_ListUserTransfers(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewTransferRepository(db)
+
+	t.Run("sent and received", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transfers" WHERE (sender_user_id =$1 OR recipient_user_id =$2) AND id <$3 ORDER BY id DESC LIMIT 21`)).
+			WithArgs(1, 1, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "sender_user_id", "recipient_user_id"}).AddRow(9, 1, 2).AddRow(8, 2, 1))
+
+		transfers, err := r.ListUserTransfers(TransferFilter{UserId: 1, BeforeId: 10, Limit: 21})
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+		assert.Len(t, transfers, 2)
+	})
+
+	t.Run("sent", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transfers" WHERE sender_user_id =$1 ORDER BY id DESC LIMIT 21`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "sender_user_id"}).AddRow(9, 1))
+
+		transfers, err := r.ListUserTransfers(TransferFilter{UserId: 1, Direction: DirectionSent, Limit: 21})
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+		assert.Len(t, transfers, 1)
+	})
+}
@@ -0,0 +1,297 @@
+package transfer
+
+import (
+	// Go imports
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	// External imports
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/user"
+)
+
+const (
+	defaultTransferPageSize = 20
+	maxTransferPageSize     = 100
+	maxNoteLength           = 140
+)
+
+var (
+	ErrInvalidTransfer       = errors.New("invalid transfer")
+	ErrInvalidTransferFilter = errors.New("invalid transfer filter")
+	ErrRecipientNotFound     = errors.New("recipient not found")
+	ErrTransferNotFound      = errors.New("transfer not found")
+)
+
+// IUserResolver finds the parties of a transfer
+type IUserResolver interface {
+	GetUserById(userId uint) (*user.User, error)
+	GetUserByUsernameOrEmail(usernameOrEmail string) (*user.User, error)
+}
+
+type ITransferService interface {
+	Transfer(senderUserId uint, request TransferRequest) (*TransferResponse, error)
+	ListTransfers(userId uint, request TransferListRequest) (*TransferListResponse, error)
+	GetTransfer(userId, transferId uint) (*TransferResponse, error)
+}
+
+type transferService struct {
+	transferRepo    ITransferRepository
+	userResolver    IUserResolver
+	accountService  account.IAccountService
+	exchangeService exchange.IExchangeService
+	currencyService currency.Service
+	unitOfWork      uow.IUnitOfWork
+	clock           clock.IClock
+}
+
+func NewTransferService(transferRepository ITransferRepository, userResolver IUserResolver, accountService account.IAccountService, exchangeService exchange.IExchangeService, currencyService currency.Service, unitOfWork uow.IUnitOfWork, clock clock.IClock) ITransferService {
+	return &transferService{transferRepo: transferRepository, userResolver: userResolver, accountService: accountService, exchangeService: exchangeService, currencyService: currencyService, unitOfWork: unitOfWork, clock: clock}
+}
+
+// Transfer debits the sender and credits the recipient in one transaction. A conversion into the default currency
+// of the recipient is done on the sender's accounts through an exchange offer accepted in the same transaction.
+func (s *transferService) Transfer(senderUserId uint, request TransferRequest) (*TransferResponse, error) {
+	currencyCode := strings.ToUpper(request.CurrencyCode)
+	if ok := s.currencyService.CheckIsCurrencyCodeExist(currencyCode); !ok {
+		return nil, fmt.Errorf("%w: currency %s not found", ErrInvalidTransfer, currencyCode)
+	}
+
+	amount := currency.Round(request.Amount, currencyCode)
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidTransfer)
+	}
+
+	note := strings.TrimSpace(request.Note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, fmt.Errorf("%w: note can be at most %d characters", ErrInvalidTransfer, maxNoteLength)
+	}
+
+	recipient, err := s.userResolver.GetUserByUsernameOrEmail(request.Recipient)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrRecipientNotFound, request.Recipient)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if recipient.Id == senderUserId {
+		return nil, fmt.Errorf("%w: can not transfer to yourself", ErrInvalidTransfer)
+	}
+
+	sender, err := s.userResolver.GetUserById(senderUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok := s.accountService.IsUserHasAccountOnGivenCurrency(senderUserId, currencyCode); !ok {
+		return nil, fmt.Errorf("%w: %s account not found", ErrInvalidTransfer, currencyCode)
+	}
+
+	recipientCurrencyCode := currencyCode
+	if request.ConvertToRecipientCurrency && recipient.DefaultCurrencyCode != "" {
+		recipientCurrencyCode = strings.ToUpper(recipient.DefaultCurrencyCode)
+	}
+
+	transfer := Transfer{
+		SenderUserId:          sender.Id,
+		SenderUsername:        sender.Username,
+		RecipientUserId:       recipient.Id,
+		RecipientUsername:     recipient.Username,
+		CurrencyCode:          currencyCode,
+		Amount:                amount,
+		RecipientCurrencyCode: recipientCurrencyCode,
+		RecipientAmount:       amount,
+		Note:                  note,
+		CreatedAt:             s.clock.Now(),
+	}
+
+	var created *Transfer
+	if err = s.unitOfWork.Do(func(tx *gorm.DB) error {
+		txAccountService := s.accountService.WithTx(tx)
+		if err := s.lockAccounts(txAccountService, transfer); err != nil {
+			return err
+		}
+
+		if recipientCurrencyCode != currencyCode {
+			if err := s.convert(s.exchangeService.WithTx(tx), &transfer); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if created, err = s.transferRepo.WithTx(tx).CreateTransfer(transfer); err != nil {
+			return err
+		}
+
+		_, err = txAccountService.ApplyJournalEntry(ledger.NewTransferEntry(created.SenderUserId, created.RecipientUserId, created.Id, created.RecipientCurrencyCode, created.RecipientAmount))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	response := toTransferResponse(*created, senderUserId)
+	return &response, nil
+}
+
+// lockAccounts opens the missing accounts the transfer credits and locks every account it touches.
+// Users are locked in id order so transfers between the same users in opposite directions can not deadlock.
+func (s *transferService) lockAccounts(txAccountService account.IAccountService, transfer Transfer) error {
+	currencyCodes := map[uint][]string{
+		transfer.SenderUserId:    {transfer.CurrencyCode},
+		transfer.RecipientUserId: {transfer.RecipientCurrencyCode},
+	}
+
+	// The sender is credited with the converted amount before it is transferred
+	if transfer.RecipientCurrencyCode != transfer.CurrencyCode {
+		currencyCodes[transfer.SenderUserId] = append(currencyCodes[transfer.SenderUserId], transfer.RecipientCurrencyCode)
+	}
+
+	userIds := []uint{transfer.SenderUserId, transfer.RecipientUserId}
+	sort.Slice(userIds, func(i, j int) bool { return userIds[i] < userIds[j] })
+	for _, userId := range userIds {
+		for _, currencyCode := range currencyCodes[userId] {
+			if ok := txAccountService.IsUserHasAccountOnGivenCurrency(userId, currencyCode); ok {
+				continue
+			}
+
+			if _, err := txAccountService.CreateUserAccount(userId, currencyCode); err != nil {
+				return err
+			}
+		}
+
+		balances, err := txAccountService.LockUserAccounts(userId, currencyCodes[userId]...)
+		if err != nil {
+			return err
+		}
+
+		if userId == transfer.SenderUserId && transfer.Amount.GreaterThan(balances[transfer.CurrencyCode]) {
+			return account.ErrNotEnoughBalance
+		}
+	}
+
+	return nil
+}
+
+// convert sells the amount for the recipient currency on the sender's accounts, it has to run inside a transaction
+func (s *transferService) convert(txExchangeService exchange.IExchangeService, transfer *Transfer) error {
+	offer, err := txExchangeService.GetExchangeRateOffer(transfer.SenderUserId, exchange.OfferRequest{
+		FromCurrencyCode: transfer.CurrencyCode,
+		ToCurrencyCode:   transfer.RecipientCurrencyCode,
+		SellAmount:       transfer.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = txExchangeService.AcceptExchangeRateOffer(transfer.SenderUserId, exchange.AcceptOfferRequest{OfferId: offer.OfferId}); err != nil {
+		return err
+	}
+
+	transfer.OfferId = &offer.OfferId
+	transfer.ExchangeRate = decimal.NewNullDecimal(offer.ExchangeRate)
+	transfer.RecipientAmount = offer.BuyAmount.Decimal
+	return nil
+}
+
+// ListTransfers pages through the transfers the user sent or received newest first
+func (s *transferService) ListTransfers(userId uint, request TransferListRequest) (*TransferListResponse, error) {
+	filter := TransferFilter{
+		UserId:    userId,
+		Direction: Direction(strings.ToUpper(request.Direction)),
+		Limit:     request.Limit,
+	}
+
+	switch filter.Direction {
+	case "", DirectionSent, DirectionReceived:
+	default:
+		return nil, fmt.Errorf("%w: unknown direction %s", ErrInvalidTransferFilter, request.Direction)
+	}
+
+	if request.Cursor != "" {
+		cursor, err := strconv.ParseUint(request.Cursor, 10, 64)
+		if err != nil || cursor == 0 {
+			return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidTransferFilter)
+		}
+		filter.BeforeId = uint(cursor)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransferPageSize
+	}
+
+	if filter.Limit > maxTransferPageSize {
+		filter.Limit = maxTransferPageSize
+	}
+
+	// Fetch one more transfer to know whether there is a next page
+	pageSize := filter.Limit
+	filter.Limit++
+	transfers, err := s.transferRepo.ListUserTransfers(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransferListResponse{Transfers: []TransferResponse{}}
+	if len(transfers) > pageSize {
+		transfers = transfers[:pageSize]
+		response.NextCursor = strconv.FormatUint(uint64(transfers[pageSize-1].Id), 10)
+	}
+
+	for _, transfer := range transfers {
+		response.Transfers = append(response.Transfers, toTransferResponse(transfer, userId))
+	}
+
+	return response, nil
+}
+
+// GetTransfer returns a transfer to either of its parties
+func (s *transferService) GetTransfer(userId, transferId uint) (*TransferResponse, error) {
+	transfer, err := s.transferRepo.GetTransfer(transferId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && transfer.SenderUserId != userId && transfer.RecipientUserId != userId) {
+		return nil, fmt.Errorf("%w: %d", ErrTransferNotFound, transferId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTransferResponse(*transfer, userId)
+	return &response, nil
+}
+
+func toTransferResponse(transfer Transfer, userId uint) TransferResponse {
+	direction := DirectionReceived
+	if transfer.SenderUserId == userId {
+		direction = DirectionSent
+	}
+
+	return TransferResponse{
+		Id:                    transfer.Id,
+		Direction:             direction,
+		SenderUsername:        transfer.SenderUsername,
+		RecipientUsername:     transfer.RecipientUsername,
+		CurrencyCode:          transfer.CurrencyCode,
+		Amount:                transfer.Amount,
+		RecipientCurrencyCode: transfer.RecipientCurrencyCode,
+		RecipientAmount:       transfer.RecipientAmount,
+		OfferId:               transfer.OfferId,
+		ExchangeRate:          transfer.ExchangeRate,
+		Note:                  transfer.Note,
+		CreatedAt:             transfer.CreatedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package transfer
+
+import (
+	// Go imports
+	"testing"
+	"time"
+
+	// External imports
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/user"
+)
+
+func TestTransferService_Transfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTransferRepository := NewMockITransferRepository(ctrl)
+	mockUserResolver := NewMockIUserResolver(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	mockExchangeService := exchange.NewMockIExchangeService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	for _, currencyCode := range []string{"USD", "TRY"} {
+		currencyService.Cache.Set(currencyCode, currencyCode, cache.NoExpiration)
+	}
+	mockClock := clock.NewMockIClock(ctrl)
+	now := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now).AnyTimes()
+	transferService := NewTransferService(mockTransferRepository, mockUserResolver, accService, mockExchangeService, currencyService, mockUnitOfWork, mockClock)
+
+	sender := &user.User{Id: 1, Username: "alice", DefaultCurrencyCode: "USD"}
+	recipient := &user.User{Id: 2, Username: "bob", Email: "bob@example.com", DefaultCurrencyCode: "TRY"}
+	accService.EXPECT().WithTx(gomock.Any()).Return(accService).AnyTimes()
+	mockTransferRepository.EXPECT().WithTx(gomock.Any()).Return(mockTransferRepository).AnyTimes()
+	mockExchangeService.EXPECT().WithTx(gomock.Any()).Return(mockExchangeService).AnyTimes()
+	mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	mockTransferRepository.EXPECT().CreateTransfer(gomock.Any()).DoAndReturn(func(transfer Transfer) (*Transfer, error) {
+		transfer.Id = 7
+		return &transfer, nil
+	}).AnyTimes()
+
+	t.Run("unknown currency", func(t *testing.T) {
+		_, err := transferService.Transfer(sender.Id, TransferRequest{Recipient: "bob", CurrencyCode: "XYZ", Amount: decimal.NewFromInt(10)})
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	t.Run("recipient not found", func(t *testing.T) {
+		mockUserResolver.EXPECT().GetUserByUsernameOrEmail("carol").Return(nil, user.ErrUserNotFound)
+		_, err := transferService.Transfer(sender.Id, TransferRequest{Recipient: "carol", CurrencyCode: "USD", Amount: decimal.NewFromInt(10)})
+		assert.ErrorIs(t, err, ErrRecipientNotFound)
+	})
+
+	t.Run("transfer to yourself", func(t *testing.T) {
+		mockUserResolver.EXPECT().GetUserByUsernameOrEmail("alice").Return(sender, nil)
+		_, err := transferService.Transfer(sender.Id, TransferRequest{Recipient: "alice", CurrencyCode: "USD", Amount: decimal.NewFromInt(10)})
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	t.Run("not enough balance", func(t *testing.T) {
+		mockUserResolver.EXPECT().GetUserByUsernameOrEmail("bob").Return(recipient, nil)
+		mockUserResolver.EXPECT().GetUserById(sender.Id).Return(sender, nil)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(sender.Id, "USD").Return(true).Times(2)
+		accService.EXPECT().LockUserAccounts(sender.Id, "USD").Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(5)}, nil)
+		_, err := transferService.Transfer(sender.Id, TransferRequest{Recipient: "bob", CurrencyCode: "USD", Amount: decimal.NewFromInt(10)})
+		assert.ErrorIs(t, err, account.ErrNotEnoughBalance)
+	})
+
+	t.Run("same currency", func(t *testing.T) {
+		mockUserResolver.EXPECT().GetUserByUsernameOrEmail("bob@example.com").Return(recipient, nil)
+		mockUserResolver.EXPECT().GetUserById(sender.Id).Return(sender, nil)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(sender.Id, "USD").Return(true).Times(2)
+		accService.EXPECT().LockUserAccounts(sender.Id, "USD").Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(100)}, nil)
+		// The recipient has no USD account yet
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(recipient.Id, "USD").Return(false)
+		accService.EXPECT().CreateUserAccount(recipient.Id, "USD").Return(&account.Account{}, nil)
+		accService.EXPECT().LockUserAccounts(recipient.Id, "USD").Return(map[string]decimal.Decimal{"USD": decimal.Zero}, nil)
+		accService.EXPECT().ApplyJournalEntry(gomock.Any()).DoAndReturn(func(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+			assert.Equal(t, ledger.EntryTypeTransfer, entry.Type)
+			assert.Len(t, entry.Postings, 2)
+			return &entry, nil
+		})
+
+		transfer, err := transferService.Transfer(sender.Id, TransferRequest{Recipient: "bob@example.com", CurrencyCode: "usd", Amount: decimal.NewFromInt(10), Note: " rent "})
+		assert.Nil(t, err)
+		assert.Equal(t, uint(7), transfer.Id)
+		assert.Equal(t, DirectionSent, transfer.Direction)
+		assert.Equal(t, "bob", transfer.RecipientUsername)
+		assert.Equal(t, "USD", transfer.RecipientCurrencyCode)
+		assert.True(t, decimal.NewFromInt(10).Equal(transfer.RecipientAmount))
+		assert.Equal(t, "rent", transfer.Note)
+		assert.Nil(t, transfer.OfferId)
+		assert.Equal(t, now, transfer.CreatedAt)
+	})
+
+	t.Run("converted to recipient currency", func(t *testing.T) {
+		mockUserResolver.EXPECT().GetUserByUsernameOrEmail("bob").Return(recipient, nil)
+		mockUserResolver.EXPECT().GetUserById(sender.Id).Return(sender, nil)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(sender.Id, "USD").Return(true).Times(2)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(sender.Id, "TRY").Return(true)
+		accService.EXPECT().LockUserAccounts(sender.Id, "USD", "TRY").Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(100), "TRY": decimal.Zero}, nil)
+		accService.EXPECT().IsUserHasAccountOnGivenCurrency(recipient.Id, "TRY").Return(true)
+		accService.EXPECT().LockUserAccounts(recipient.Id, "TRY").Return(map[string]decimal.Decimal{"TRY": decimal.Zero}, nil)
+		mockExchangeService.EXPECT().GetExchangeRateOffer(sender.Id, gomock.Any()).DoAndReturn(func(userId uint, request exchange.OfferRequest) (*exchange.OfferResponse, error) {
+			assert.Equal(t, "TRY", request.ToCurrencyCode)
+			assert.True(t, decimal.NewFromInt(10).Equal(request.SellAmount))
+			return &exchange.OfferResponse{OfferId: 9, ExchangeRate: decimal.NewFromInt(19), BuyAmount: decimal.NewNullDecimal(decimal.NewFromInt(190))}, nil
+		})
+		mockExchangeService.EXPECT().AcceptExchangeRateOffer(sender.Id, exchange.AcceptOfferRequest{OfferId: 9}).Return(nil, nil)
+		accService.EXPECT().ApplyJournalEntry(gomock.Any()).DoAndReturn(func(entry ledger.JournalEntry) (*ledger.JournalEntry, error) {
+			for _, posting := range entry.Postings {
+				assert.Equal(t, "TRY", posting.CurrencyCode)
+				assert.True(t, decimal.NewFromInt(190).Equal(posting.Amount))
+			}
+			return &entry, nil
+		})
+
+		transfer, err := transferService.Transfer(sender.Id, TransferRequest{Recipient: "bob", CurrencyCode: "USD", Amount: decimal.NewFromInt(10), ConvertToRecipientCurrency: true})
+		assert.Nil(t, err)
+		assert.Equal(t, "TRY", transfer.RecipientCurrencyCode)
+		assert.True(t, decimal.NewFromInt(190).Equal(transfer.RecipientAmount))
+		assert.Equal(t, uint(9), *transfer.OfferId)
+	})
+}
+
+func TestTransferService_ListTransfers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTransferRepository := NewMockITransferRepository(ctrl)
+	transferService := NewTransferService(mockTransferRepository, NewMockIUserResolver(ctrl), account.NewMockIAccountService(ctrl), exchange.NewMockIExchangeService(ctrl), currency.Service{}, uow.NewMockIUnitOfWork(ctrl), clock.NewClock())
+	userId := uint(1)
+
+	t.Run("unknown direction", func(t *testing.T) {
+		_, err := transferService.ListTransfers(userId, TransferListRequest{Direction: "BOTH"})
+		assert.ErrorIs(t, err, ErrInvalidTransferFilter)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		mockTransferRepository.EXPECT().ListUserTransfers(TransferFilter{UserId: userId, Direction: DirectionReceived, BeforeId: 10, Limit: 3}).
+			Return([]Transfer{{Id: 9, RecipientUserId: userId}, {Id: 8, RecipientUserId: userId}, {Id: 5, RecipientUserId: userId}}, nil)
+
+		transfers, err := transferService.ListTransfers(userId, TransferListRequest{Direction: "received", Cursor: "10", Limit: 2})
+		assert.Nil(t, err)
+		assert.Len(t, transfers.Transfers, 2)
+		assert.Equal(t, DirectionReceived, transfers.Transfers[0].Direction)
+		assert.Equal(t, "8", transfers.NextCursor)
+	})
+}
+
+func TestTransferService_GetTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTransferRepository := NewMockITransferRepository(ctrl)
+	transferService := NewTransferService(mockTransferRepository, NewMockIUserResolver(ctrl), account.NewMockIAccountService(ctrl), exchange.NewMockIExchangeService(ctrl), currency.Service{}, uow.NewMockIUnitOfWork(ctrl), clock.NewClock())
+	mockTransferRepository.EXPECT().GetTransfer(uint(7)).Return(&Transfer{Id: 7, SenderUserId: 1, RecipientUserId: 2}, nil).Times(2)
+
+	t.Run("transfer of another user", func(t *testing.T) {
+		_, err := transferService.GetTransfer(3, 7)
+		assert.ErrorIs(t, err, ErrTransferNotFound)
+	})
+
+	t.Run("received transfer", func(t *testing.T) {
+		transfer, err := transferService.GetTransfer(2, 7)
+		assert.Nil(t, err)
+		assert.Equal(t, DirectionReceived, transfer.Direction)
+	})
+}
@@ -0,0 +1,27 @@
+package uow
+
+import (
+	// External imports
+	"gorm.io/gorm"
+)
+
+// IUnitOfWork runs a set of repository calls inside a single database transaction.
+// Repositories join the transaction through their WithTx methods.
+type IUnitOfWork interface {
+	Do(fn func(tx *gorm.DB) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) IUnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+// Do commits when fn returns nil and rolls back on error or panic
+func (u *unitOfWork) Do(fn func(tx *gorm.DB) error) error {
+	return u.db.Transaction(fn)
+}
@@ -0,0 +1,44 @@
+package uow
+
+import (
+	// Go imports
+	"errors"
+	"testing"
+
+	// External imports
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/config"
+)
+
+func TestUnitOfWork_Do(t *testing.T) {
+	t.Run("commit on success", func(t *testing.T) {
+		db, mock := config.ConnectMockDb()
+		u := NewUnitOfWork(db)
+
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		err := u.Do(func(tx *gorm.DB) error {
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		db, mock := config.ConnectMockDb()
+		u := NewUnitOfWork(db)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		err := u.Do(func(tx *gorm.DB) error {
+			return errors.New("not enough balance")
+		})
+		assert.NotNil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
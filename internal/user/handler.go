@@ -12,6 +12,7 @@ import (
 	// Internal imports
 	"github.com/mehmetokdemir/currency-conversion-service/errors"
 	"github.com/mehmetokdemir/currency-conversion-service/helper"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/onboarding"
 )
 
 type Handler interface {
@@ -66,8 +67,12 @@ func (h *userHandler) Register(c *gin.Context) {
 		DefaultCurrencyCode: req.CurrencyCode,
 		CreatedAt:           time.Now().Local(),
 		UpdatedAt:           time.Now().Local(),
-	})
+	}, req.CampaignCode)
 	if err != nil {
+		if errors.Is(err, onboarding.ErrInvalidCampaign) || errors.Is(err, onboarding.ErrCampaignExhausted) {
+			helper.Error(c, http.StatusBadRequest, errors.ErrOnboardingError.Error(), err.Error())
+			return
+		}
 		helper.Error(c, http.StatusInternalServerError, errors.ErrCreateError.Error(), err.Error())
 		return
 	}
@@ -60,7 +60,7 @@ func TestUserHandler_Register(t *testing.T) {
 			UpdatedAt:           time.Now(),
 		}
 
-		mockUserService.EXPECT().CreateUser(expected).Return(&expected, nil)
+		mockUserService.EXPECT().CreateUser(expected, "").Return(&expected, nil)
 
 		reqBytes, _ := json.Marshal(register)
 		req, err := http.NewRequest(http.MethodPost, "/register", bytes.NewReader(reqBytes))
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
 )
 
 // MockIUserRepository is a mock of IUserRepository interface.
@@ -48,6 +49,21 @@ func (mr *MockIUserRepositoryMockRecorder) CreateUser(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIUserRepository)(nil).CreateUser), arg0)
 }
 
+// GetUserById mocks base method.
+func (m *MockIUserRepository) GetUserById(arg0 uint) (*User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", arg0)
+	ret0, _ := ret[0].(*User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockIUserRepositoryMockRecorder) GetUserById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockIUserRepository)(nil).GetUserById), arg0)
+}
+
 // GetUserByUsername mocks base method.
 func (m *MockIUserRepository) GetUserByUsername(arg0 string) (*User, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +79,21 @@ func (mr *MockIUserRepositoryMockRecorder) GetUserByUsername(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockIUserRepository)(nil).GetUserByUsername), arg0)
 }
 
+// GetUserByUsernameOrEmail mocks base method.
+func (m *MockIUserRepository) GetUserByUsernameOrEmail(arg0 string) (*User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsernameOrEmail", arg0)
+	ret0, _ := ret[0].(*User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsernameOrEmail indicates an expected call of GetUserByUsernameOrEmail.
+func (mr *MockIUserRepositoryMockRecorder) GetUserByUsernameOrEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsernameOrEmail", reflect.TypeOf((*MockIUserRepository)(nil).GetUserByUsernameOrEmail), arg0)
+}
+
 // IsUserExistWithSameEmail mocks base method.
 func (m *MockIUserRepository) IsUserExistWithSameEmail(arg0 string) bool {
 	m.ctrl.T.Helper()
@@ -104,3 +135,17 @@ func (mr *MockIUserRepositoryMockRecorder) Migration() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migration", reflect.TypeOf((*MockIUserRepository)(nil).Migration))
 }
+
+// WithTx mocks base method.
+func (m *MockIUserRepository) WithTx(arg0 *gorm.DB) IUserRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0)
+	ret0, _ := ret[0].(IUserRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockIUserRepositoryMockRecorder) WithTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIUserRepository)(nil).WithTx), arg0)
+}
@@ -49,18 +49,78 @@ func (mr *MockIUserServiceMockRecorder) CreateToken(arg0, arg1 interface{}) *gom
 }
 
 // CreateUser mocks base method.
-func (m *MockIUserService) CreateUser(arg0 User) (*User, error) {
+func (m *MockIUserService) CreateUser(arg0 User, arg1 string) (*User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0)
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
 	ret0, _ := ret[0].(*User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockIUserServiceMockRecorder) CreateUser(arg0 interface{}) *gomock.Call {
+func (mr *MockIUserServiceMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIUserService)(nil).CreateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIUserService)(nil).CreateUser), arg0, arg1)
+}
+
+// GetUserById mocks base method.
+func (m *MockIUserService) GetUserById(arg0 uint) (*User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", arg0)
+	ret0, _ := ret[0].(*User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockIUserServiceMockRecorder) GetUserById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockIUserService)(nil).GetUserById), arg0)
+}
+
+// GetUserByUsernameOrEmail mocks base method.
+func (m *MockIUserService) GetUserByUsernameOrEmail(arg0 string) (*User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsernameOrEmail", arg0)
+	ret0, _ := ret[0].(*User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsernameOrEmail indicates an expected call of GetUserByUsernameOrEmail.
+func (mr *MockIUserServiceMockRecorder) GetUserByUsernameOrEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsernameOrEmail", reflect.TypeOf((*MockIUserService)(nil).GetUserByUsernameOrEmail), arg0)
+}
+
+// GetUserDefaultCurrency mocks base method.
+func (m *MockIUserService) GetUserDefaultCurrency(arg0 uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDefaultCurrency", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDefaultCurrency indicates an expected call of GetUserDefaultCurrency.
+func (mr *MockIUserServiceMockRecorder) GetUserDefaultCurrency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDefaultCurrency", reflect.TypeOf((*MockIUserService)(nil).GetUserDefaultCurrency), arg0)
+}
+
+// GetUserSegment mocks base method.
+func (m *MockIUserService) GetUserSegment(arg0 uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSegment", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSegment indicates an expected call of GetUserSegment.
+func (mr *MockIUserServiceMockRecorder) GetUserSegment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSegment", reflect.TypeOf((*MockIUserService)(nil).GetUserSegment), arg0)
 }
 
 // HashPassword mocks base method.
@@ -14,6 +14,8 @@ type User struct {
 	Email               string `gorm:"uniqueIndex;not null" binding:"required"`
 	Password            string `gorm:"not null" binding:"required"`
 	DefaultCurrencyCode string
+	IsAdmin             bool           `gorm:"not null;default:false"` // Granted by operators on the database, never through the api
+	Segment             string         // Pricing segment of the user, e.g. VIP, empty for every user
 	CreatedAt           time.Time      `json:"created_at,omitempty"`
 	UpdatedAt           time.Time      `json:"updated_at,omitempty"`
 	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -28,6 +30,7 @@ type RegisterRequest struct {
 	Email        string `json:"email" extensions:"x-order=2" example:"john@gmail.com" validate:"required" valid:"required~email|invalid"`      // Email of the creating user
 	Password     string `json:"password" extensions:"x-order=3" example:"TopSecret!!!" validate:"required" valid:"required~password|invalid"`  // Password of the creating user
 	CurrencyCode string `json:"currency_code" extensions:"x-order=4" example:"TRY" validate:"required" valid:"required~currency_code|invalid"` // Currency code for default wallet which is given currency
+	CampaignCode string `json:"campaign_code" extensions:"x-order=5" example:"WELCOME" valid:"optional"`                                       // Code of the campaign the user registers with for its onboarding credit
 }
 
 type LoginRequest struct {
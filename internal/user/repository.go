@@ -10,11 +10,16 @@ import (
 	"gorm.io/gorm"
 )
 
+var ErrUserNotFound = errors.New("user not found")
+
 type IUserRepository interface {
 	CreateUser(user User) (*User, error)
 	IsUserExistWithSameUsername(username string) bool
 	IsUserExistWithSameEmail(email string) bool
 	GetUserByUsername(username string) (*User, error)
+	GetUserById(id uint) (*User, error)
+	GetUserByUsernameOrEmail(usernameOrEmail string) (*User, error)
+	WithTx(tx *gorm.DB) IUserRepository
 	Migration() error
 }
 
@@ -28,6 +33,10 @@ func NewUserRepository(db *gorm.DB) IUserRepository {
 	}
 }
 
+func (r *userRepository) WithTx(tx *gorm.DB) IUserRepository {
+	return NewUserRepository(tx)
+}
+
 func (r *userRepository) CreateUser(user User) (*User, error) {
 	if err := r.db.Create(&user).Error; err != nil {
 		return nil, err
@@ -38,7 +47,27 @@ func (r *userRepository) CreateUser(user User) (*User, error) {
 func (r *userRepository) GetUserByUsername(username string) (*User, error) {
 	var user *User
 	if err := r.db.Model(&User{}).Where("username =?", username).First(&user).Error; err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *userRepository) GetUserById(id uint) (*User, error) {
+	var user *User
+	if err := r.db.Model(&User{}).Where("id =?", id).First(&user).Error; err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// GetUserByUsernameOrEmail reports ErrUserNotFound only when no user matches, other errors are returned as they are
+func (r *userRepository) GetUserByUsernameOrEmail(usernameOrEmail string) (*User, error) {
+	var user *User
+	if err := r.db.Model(&User{}).Where("username =? OR email =?", usernameOrEmail, usernameOrEmail).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
 	}
 	return user, nil
 }
@@ -31,9 +31,9 @@ func TestUserRepository_CreateUser(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(
-		regexp.QuoteMeta(` INSERT INTO "users" ("username","email","password","default_currency_code","created_at","updated_at","deleted_at","id") 
- 							VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
-		WithArgs(u.Username, u.Email, u.Password, u.DefaultCurrencyCode, u.CreatedAt, u.UpdatedAt, nil, u.Id).
+		regexp.QuoteMeta(` INSERT INTO "users" ("username","email","password","default_currency_code","is_admin","segment","created_at","updated_at","deleted_at","id") 
+ 							VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(u.Username, u.Email, u.Password, u.DefaultCurrencyCode, u.IsAdmin, u.Segment, u.CreatedAt, u.UpdatedAt, nil, u.Id).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "email", "username", "created_at", "updated_at"}).
 				AddRow(u.Id, u.Email, u.Username, u.CreatedAt, u.UpdatedAt))
@@ -127,3 +127,42 @@ func TestUserRepository_IsUserExistWithSameUsername(t *testing.T) {
 	assert.Nil(t, mock.ExpectationsWereMet())
 	assert.Equal(t, exist, true)
 }
+
+func TestUserRepository_GetUserById(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id =$1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT 1`)).
+		WithArgs(uint(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "segment"}).AddRow(3, "john", "VIP"))
+
+	user, err := r.GetUserById(3)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "VIP", user.Segment)
+}
+
+func TestUserRepository_GetUserByUsernameOrEmail(t *testing.T) {
+	db, mock := config.ConnectMockDb()
+	r := NewUserRepository(db)
+	sqlSelectOne := `SELECT * FROM "users" WHERE (username =$1 OR email =$2) AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT 1`
+
+	t.Run("found by email", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlSelectOne)).WithArgs("jane@gmail.com", "jane@gmail.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email"}).AddRow(2, "jane", "jane@gmail.com"))
+
+		dbUser, err := r.GetUserByUsernameOrEmail("jane@gmail.com")
+		assert.Nil(t, err)
+		assert.Equal(t, uint(2), dbUser.Id)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlSelectOne)).WithArgs("nobody", "nobody").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := r.GetUserByUsernameOrEmail("nobody")
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -10,33 +10,45 @@ import (
 	// External imports
 	"github.com/dgrijalva/jwt-go"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 
 	// Internal imports
 	"github.com/mehmetokdemir/currency-conversion-service/config"
 	"github.com/mehmetokdemir/currency-conversion-service/dto"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/onboarding"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
 )
 
 type IUserService interface {
-	CreateUser(user User) (*User, error)
+	CreateUser(user User, campaignCode string) (*User, error)
 	CreateToken(username, password string) (*LoginResponse, error)
 	VerifyPassword(hashedPassword, requestedPassword string) bool
 	HashPassword(password string) (string, error)
+	GetUserSegment(userId uint) (string, error)
+	GetUserDefaultCurrency(userId uint) (string, error)
+	GetUserById(userId uint) (*User, error)
+	GetUserByUsernameOrEmail(usernameOrEmail string) (*User, error)
 }
 
 type userService struct {
-	config          config.Config
-	userRepository  IUserRepository
-	accountService  account.IAccountService
-	currencyService currency.Service
+	config            config.Config
+	userRepository    IUserRepository
+	accountService    account.IAccountService
+	currencyService   currency.Service
+	onboardingService onboarding.IOnboardingService
+	unitOfWork        uow.IUnitOfWork
 }
 
-func NewUserService(userRepository IUserRepository, config config.Config, currencyService currency.Service, accountService account.IAccountService) IUserService {
-	return &userService{userRepository: userRepository, config: config, currencyService: currencyService, accountService: accountService}
+func NewUserService(userRepository IUserRepository, config config.Config, currencyService currency.Service, accountService account.IAccountService, onboardingService onboarding.IOnboardingService, unitOfWork uow.IUnitOfWork) IUserService {
+	return &userService{userRepository: userRepository, config: config, currencyService: currencyService, accountService: accountService, onboardingService: onboardingService, unitOfWork: unitOfWork}
 }
 
-func (s *userService) CreateUser(user User) (*User, error) {
+// CreateUser registers the user with an account in the default currency, credited by the onboarding policy
+// or by the campaign of the code when given. The user, the account and the credit are created in one transaction,
+// a credit which can not be granted fails the registration so the user can register again.
+func (s *userService) CreateUser(user User, campaignCode string) (*User, error) {
 
 	if s.userRepository.IsUserExistWithSameEmail(user.Email) || s.userRepository.IsUserExistWithSameUsername(user.Username) {
 		return nil, errors.New("duplicated users")
@@ -46,6 +58,10 @@ func (s *userService) CreateUser(user User) (*User, error) {
 		return nil, errors.New("currency not found")
 	}
 
+	if err := s.onboardingService.ValidateCampaignCode(campaignCode); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := s.HashPassword(user.Password)
 	if err != nil {
 		return nil, err
@@ -53,19 +69,53 @@ func (s *userService) CreateUser(user User) (*User, error) {
 
 	user.Password = hashedPassword
 
-	createdUser, err := s.userRepository.CreateUser(user)
-	if err != nil {
-		return nil, err
-	}
+	if err = s.unitOfWork.Do(func(tx *gorm.DB) error {
+		createdUser, err := s.userRepository.WithTx(tx).CreateUser(user)
+		if err != nil {
+			return err
+		}
+
+		// First create default account for given currency
+		if _, err = s.accountService.WithTx(tx).CreateUserAccount(createdUser.Id, user.DefaultCurrencyCode); err != nil {
+			return err
+		}
 
-	// First create default account for given currency
-	if _, err = s.accountService.CreateUserAccount(createdUser.Id, user.DefaultCurrencyCode, true); err != nil {
+		_, err = s.onboardingService.WithTx(tx).GrantOnboardingCredit(createdUser.Id, user.DefaultCurrencyCode, campaignCode)
+		return err
+	}); err != nil {
 		return nil, err
 	}
 
 	return &user, nil
 }
 
+// GetUserSegment returns the pricing segment of the user, exchange offers are marked up by it
+func (s *userService) GetUserSegment(userId uint) (string, error) {
+	user, err := s.userRepository.GetUserById(userId)
+	if err != nil {
+		return "", err
+	}
+	return user.Segment, nil
+}
+
+// GetUserDefaultCurrency returns the currency the user registered with, the accounts of the user are valued in it
+func (s *userService) GetUserDefaultCurrency(userId uint) (string, error) {
+	user, err := s.userRepository.GetUserById(userId)
+	if err != nil {
+		return "", err
+	}
+	return user.DefaultCurrencyCode, nil
+}
+
+func (s *userService) GetUserById(userId uint) (*User, error) {
+	return s.userRepository.GetUserById(userId)
+}
+
+// GetUserByUsernameOrEmail finds another user by the credential they are known by, e.g. the recipient of a transfer
+func (s *userService) GetUserByUsernameOrEmail(usernameOrEmail string) (*User, error) {
+	return s.userRepository.GetUserByUsernameOrEmail(strings.TrimSpace(usernameOrEmail))
+}
+
 func (s *userService) CreateToken(username, password string) (*LoginResponse, error) {
 	user, err := s.userRepository.GetUserByUsername(username)
 	if err != nil {
@@ -77,7 +127,8 @@ func (s *userService) CreateToken(username, password string) (*LoginResponse, er
 	}
 
 	tk := &dto.Token{
-		UserId: user.Id,
+		UserId:  user.Id,
+		IsAdmin: user.IsAdmin,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(50 * time.Minute).Unix(),
 		},
@@ -10,18 +10,22 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 
 	// Internal imports
 	"github.com/mehmetokdemir/currency-conversion-service/config"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/onboarding"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
 )
 
 func TestUserService_CreateToken(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUserRepository := NewMockIUserRepository(ctrl)
 	accService := account.NewMockIAccountService(ctrl)
-	uService := NewUserService(mockUserRepository, config.Config{}, currency.Service{}, accService)
+	onboardingService := onboarding.NewMockIOnboardingService(ctrl)
+	uService := NewUserService(mockUserRepository, config.Config{}, currency.Service{}, accService, onboardingService, uow.NewMockIUnitOfWork(ctrl))
 
 	t.Run("user not found error", func(t *testing.T) {
 		username := "test-1"
@@ -84,10 +88,11 @@ func TestUserService_CreateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUserRepository := NewMockIUserRepository(ctrl)
 	accService := account.NewMockIAccountService(ctrl)
+	onboardingService := onboarding.NewMockIOnboardingService(ctrl)
 	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
 	currencyService.SetLocalCacheToCurrencies()
 	mockUService := NewMockIUserService(ctrl)
-	uService := NewUserService(mockUserRepository, config.Config{}, currencyService, accService)
+	uService := NewUserService(mockUserRepository, config.Config{}, currencyService, accService, onboardingService, uow.NewMockIUnitOfWork(ctrl))
 
 	t.Run("Duplicated user error with same email", func(t *testing.T) {
 		request := User{
@@ -101,7 +106,7 @@ func TestUserService_CreateUser(t *testing.T) {
 		}
 
 		mockUserRepository.EXPECT().IsUserExistWithSameEmail(request.Email).Return(true)
-		_, err := uService.CreateUser(request)
+		_, err := uService.CreateUser(request, "")
 		assert.NotNil(t, err)
 		assert.Error(t, err, "duplicated users")
 	})
@@ -119,7 +124,7 @@ func TestUserService_CreateUser(t *testing.T) {
 
 		mockUserRepository.EXPECT().IsUserExistWithSameEmail(request.Email).Return(false)
 		mockUserRepository.EXPECT().IsUserExistWithSameUsername(request.Username).Return(true)
-		_, err := uService.CreateUser(request)
+		_, err := uService.CreateUser(request, "")
 		assert.NotNil(t, err)
 		assert.Error(t, err, "duplicated users")
 	})
@@ -139,7 +144,7 @@ func TestUserService_CreateUser(t *testing.T) {
 		mockUserRepository.EXPECT().IsUserExistWithSameUsername(request.Username).Return(false)
 		ok := currencyService.CheckIsCurrencyCodeExist(request.DefaultCurrencyCode)
 		assert.False(t, ok)
-		_, err := uService.CreateUser(request)
+		_, err := uService.CreateUser(request, "")
 		assert.NotNil(t, err)
 		assert.Error(t, err, "currency not found")
 	})
@@ -159,6 +164,7 @@ func TestUserService_CreateUser(t *testing.T) {
 		mockUserRepository.EXPECT().IsUserExistWithSameUsername(request.Username).Return(false)
 		ok := currencyService.CheckIsCurrencyCodeExist(request.DefaultCurrencyCode)
 		assert.True(t, ok)
+		onboardingService.EXPECT().ValidateCampaignCode("").Return(nil)
 
 		hashedPassword, err := uService.HashPassword(request.Password)
 		assert.Nil(t, err)
@@ -175,8 +181,8 @@ func TestUserService_CreateUser(t *testing.T) {
 
 		mockUserRepository.EXPECT().CreateUser(expectedUser).Return(&User{}, nil)
 		mockUService.EXPECT().HashPassword(request.Password).Return(hashedPassword, nil)
-		//accService.EXPECT().CreateUserAccount(expectedUser.Id, expectedUser.DefaultCurrencyCode, true).Return(nil, nil)
-		user, err := uService.CreateUser(request)
+		//accService.EXPECT().CreateUserAccount(expectedUser.Id, expectedUser.DefaultCurrencyCode).Return(nil, nil)
+		user, err := uService.CreateUser(request, "")
 		if err != nil {
 			t.Errorf("Not error expected, but got: %q", err.Error())
 		}
@@ -184,13 +190,53 @@ func TestUserService_CreateUser(t *testing.T) {
 	})
 }
 
+func TestUserService_CreateUser_OnboardingCredit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUserRepository := NewMockIUserRepository(ctrl)
+	accService := account.NewMockIAccountService(ctrl)
+	onboardingService := onboarding.NewMockIOnboardingService(ctrl)
+	mockUnitOfWork := uow.NewMockIUnitOfWork(ctrl)
+	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
+	currencyService.Cache.Set("TRY", "TRY", cache.NoExpiration)
+	uService := NewUserService(mockUserRepository, config.Config{}, currencyService, accService, onboardingService, mockUnitOfWork)
+	request := User{Username: "test", Email: "test@gmail.com", Password: "123", DefaultCurrencyCode: "TRY"}
+
+	mockUserRepository.EXPECT().IsUserExistWithSameEmail(request.Email).Return(false).AnyTimes()
+	mockUserRepository.EXPECT().IsUserExistWithSameUsername(request.Username).Return(false).AnyTimes()
+	mockUserRepository.EXPECT().WithTx(gomock.Any()).Return(mockUserRepository).AnyTimes()
+	mockUserRepository.EXPECT().CreateUser(gomock.Any()).Return(&User{Id: 1}, nil).AnyTimes()
+	accService.EXPECT().WithTx(gomock.Any()).Return(accService).AnyTimes()
+	accService.EXPECT().CreateUserAccount(uint(1), "TRY").Return(&account.Account{}, nil).AnyTimes()
+	onboardingService.EXPECT().ValidateCampaignCode("WELCOME").Return(nil).AnyTimes()
+	onboardingService.EXPECT().WithTx(gomock.Any()).Return(onboardingService).AnyTimes()
+	mockUnitOfWork.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(tx *gorm.DB) error) error {
+		return fn(nil)
+	}).AnyTimes()
+
+	t.Run("credit can not be granted", func(t *testing.T) {
+		onboardingService.EXPECT().GrantOnboardingCredit(uint(1), "TRY", "WELCOME").Return(nil, onboarding.ErrCampaignExhausted)
+
+		_, err := uService.CreateUser(request, "WELCOME")
+		assert.ErrorIs(t, err, onboarding.ErrCampaignExhausted)
+	})
+
+	t.Run("credit is granted", func(t *testing.T) {
+		onboardingService.EXPECT().GrantOnboardingCredit(uint(1), "TRY", "WELCOME").Return(&onboarding.GrantResponse{}, nil)
+
+		user, err := uService.CreateUser(request, "WELCOME")
+		assert.Nil(t, err)
+		assert.Equal(t, "test", user.Username)
+	})
+}
+
 func TestUserService_MatchPassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUserRepository := NewMockIUserRepository(ctrl)
 	accService := account.NewMockIAccountService(ctrl)
+	onboardingService := onboarding.NewMockIOnboardingService(ctrl)
 	currencyService := currency.NewCurrencyService(cache.New(5*time.Minute, 10*time.Minute))
 	currencyService.SetLocalCacheToCurrencies()
-	uService := NewUserService(mockUserRepository, config.Config{}, currencyService, accService)
+	uService := NewUserService(mockUserRepository, config.Config{}, currencyService, accService, onboardingService, uow.NewMockIUnitOfWork(ctrl))
 
 	pass := "123"
 	t.Run("match password", func(t *testing.T) {
@@ -2,8 +2,14 @@ package main
 
 import (
 	// Go imports
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	// External imports
@@ -17,12 +23,22 @@ import (
 	"github.com/mehmetokdemir/currency-conversion-service/config"
 	_ "github.com/mehmetokdemir/currency-conversion-service/docs"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/account"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/clock"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/currency"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/exchange"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/ledger"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/onboarding"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/payment"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/schedule"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/transfer"
+	"github.com/mehmetokdemir/currency-conversion-service/internal/uow"
 	"github.com/mehmetokdemir/currency-conversion-service/internal/user"
 	"github.com/mehmetokdemir/currency-conversion-service/middleware"
 )
 
+// shutdownTimeout bounds the wait for in-flight requests and the background workers on shutdown
+const shutdownTimeout = 10 * time.Second
+
 // @title Currency Conversion Service
 // @version 1.0.12
 // @description Currency Conversion Service.
@@ -40,30 +56,123 @@ func main() {
 	currencyService.SetLocalCacheToCurrencies()
 
 	db := config.Connect(serviceConfig)
+	unitOfWork := uow.NewUnitOfWork(db)
+
+	// Ledger Service
+	ledgerRepository := ledger.NewLedgerRepository(db)
+	if err = ledgerRepository.Migration(); err != nil {
+		log.Fatal(err)
+	}
+	ledgerService := ledger.NewLedgerService(ledgerRepository, clock.NewClock())
 
 	// Account Service
 	accountRepository := account.NewAccountRepository(db)
 	if err = accountRepository.Migration(); err != nil {
 		log.Fatal(err)
 	}
-	accountService := account.NewAccountService(accountRepository, serviceConfig)
+	accountService := account.NewAccountService(accountRepository, ledgerService, unitOfWork, serviceConfig)
+	if err = accountService.ReconcileLedger(); err != nil {
+		log.Fatal(err)
+	}
 	accountHandler := account.NewAccountHandler(accountService, currencyService)
 
+	// Exchange Repository
+	exchangeRepository := exchange.NewExchangeRepository(db)
+	if err = exchangeRepository.Migration(); err != nil {
+		log.Fatal(err)
+	}
+	rateConverter := exchange.NewRateConverter(exchangeRepository, serviceConfig)
+
+	// Background workers stop and the server shuts down on interrupt
+	schedulerCtx, stopSchedulers := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSchedulers()
+
+	// Onboarding Service
+	onboardingPolicy, err := onboarding.LoadPolicy(serviceConfig.OnboardingPolicyFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	onboardingRepository := onboarding.NewOnboardingRepository(db)
+	if err = onboardingRepository.Migration(); err != nil {
+		log.Fatal(err)
+	}
+	onboardingService := onboarding.NewOnboardingService(onboardingRepository, *onboardingPolicy, rateConverter, accountService, ledgerService, unitOfWork, clock.NewClock())
+	onboardingExpirer := onboarding.NewExpirer(onboardingService, serviceConfig.OnboardingExpiryInterval)
+	onboardingExpirer.Start(schedulerCtx)
+	onboardingHandler := onboarding.NewOnboardingHandler(onboardingService)
+
 	// User Service
 	userRepository := user.NewUserRepository(db)
 	if err = userRepository.Migration(); err != nil {
 		log.Fatal(err)
 	}
-	userService := user.NewUserService(userRepository, serviceConfig, currencyService, accountService)
+	userService := user.NewUserService(userRepository, serviceConfig, currencyService, accountService, onboardingService, unitOfWork)
 	userHandler := user.NewUserHandler(userService)
 
+	// Account Summary Service
+	summaryService := account.NewSummaryService(accountRepository, rateConverter, userService, currencyService, clock.NewClock())
+	summaryHandler := account.NewSummaryHandler(summaryService)
+
 	// Exchange Service
-	exchangeRepository := exchange.NewExchangeRepository(db)
-	if err = exchangeRepository.Migration(); err != nil {
+	rateProvider, err := exchange.NewRateProvider(serviceConfig, exchangeRepository)
+	if err != nil {
 		log.Fatal(err)
 	}
-	exchangeService := exchange.NewExchangeService(exchangeRepository, currencyService, accountService)
+	rateScheduler := exchange.NewRateScheduler(rateProvider, exchangeRepository, serviceConfig.RateRefreshInterval)
+	rateScheduler.Start(schedulerCtx)
+	exchangeService := exchange.NewExchangeService(exchangeRepository, currencyService, accountService, userService, unitOfWork, serviceConfig, clock.NewClock())
+	limitOrderMatcher := exchange.NewLimitOrderMatcher(exchangeService, serviceConfig.LimitOrderMatchInterval)
+	limitOrderMatcher.Start(schedulerCtx)
 	exchangeHandler := exchange.NewExchangeHandler(currencyService, exchangeService)
+	rateStream := exchange.NewRateStream(exchangeRepository, serviceConfig.RateStreamInterval)
+	rateStream.Start(schedulerCtx)
+	rateStreamHandler := exchange.NewRateStreamHandler(currencyService, rateStream, strings.Split(serviceConfig.RateStreamOrigins, ","))
+	pairService := exchange.NewPairService(exchangeRepository, currencyService, unitOfWork)
+	pairHandler := exchange.NewPairHandler(pairService)
+	sweeper := exchange.NewSweeper(exchangeService, serviceConfig.SweepInterval, serviceConfig.OfferRetention, clock.NewClock())
+	sweeper.Start(schedulerCtx)
+	sweeperHandler := exchange.NewSweeperHandler(sweeper)
+
+	// Account Statement Service
+	statementService := account.NewStatementService(ledgerService, exchangeService, currencyService, clock.NewClock())
+	statementHandler := account.NewStatementHandler(statementService)
+
+	// Schedule Service
+	scheduleRepository := schedule.NewScheduleRepository(db)
+	if err = scheduleRepository.Migration(); err != nil {
+		log.Fatal(err)
+	}
+	scheduleService := schedule.NewScheduleService(scheduleRepository, exchangeService, currencyService, accountService, unitOfWork, clock.NewClock())
+	scheduler := schedule.NewScheduler(scheduleService, serviceConfig.ConversionPlanRunInterval)
+	scheduler.Start(schedulerCtx)
+	scheduleHandler := schedule.NewScheduleHandler(scheduleService)
+
+	// Transfer Service
+	transferRepository := transfer.NewTransferRepository(db)
+	if err = transferRepository.Migration(); err != nil {
+		log.Fatal(err)
+	}
+	transferService := transfer.NewTransferService(transferRepository, userService, accountService, exchangeService, currencyService, unitOfWork, clock.NewClock())
+	transferHandler := transfer.NewTransferHandler(transferService)
+
+	// Payment Service
+	paymentRepository := payment.NewPaymentRepository(db)
+	if err = paymentRepository.Migration(); err != nil {
+		log.Fatal(err)
+	}
+	paymentGateway, err := payment.NewPaymentGateway(serviceConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	paymentService := payment.NewPaymentService(paymentRepository, paymentGateway, accountService, currencyService, unitOfWork, clock.NewClock())
+	// The fake gateway reports the outcomes in-process instead of calling the callback endpoint
+	if fakeGateway, ok := paymentGateway.(*payment.FakeGateway); ok {
+		fakeGateway.SetCallback(func(event payment.GatewayEvent) error {
+			_, err := paymentService.HandleGatewayEvent(event)
+			return err
+		})
+	}
+	paymentHandler := payment.NewPaymentHandler(paymentService)
 
 	// Gin App
 	router := gin.New()
@@ -81,6 +190,16 @@ func main() {
 	accountGroup.Use(middleware.AuthMiddleware())
 	{
 		accountHandler.AccountRoutes(accountGroup)
+		summaryHandler.SummaryRoutes(accountGroup)
+		statementHandler.StatementRoutes(accountGroup)
+		transferHandler.TransferRoutes(accountGroup)
+		paymentHandler.PaymentRoutes(accountGroup)
+	}
+
+	// Payment Gateway Routes
+	paymentGroup := router.Group("/payment")
+	{
+		paymentHandler.CallbackRoutes(paymentGroup)
 	}
 
 	// Exchange Routes
@@ -88,13 +207,72 @@ func main() {
 	exchangeGroup.Use(middleware.AuthMiddleware())
 	{
 		exchangeHandler.ExchangeRoutes(exchangeGroup)
+		scheduleHandler.ScheduleRoutes(exchangeGroup)
+	}
+
+	// Rate streams accept the token as query param too, so they are kept off the other exchange routes
+	streamGroup := router.Group("/exchange")
+	streamGroup.Use(middleware.StreamAuthMiddleware())
+	{
+		rateStreamHandler.StreamRoutes(streamGroup)
+	}
+
+	// Admin Routes
+	adminExchangeGroup := router.Group("/admin/exchange")
+	adminExchangeGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		pairHandler.PairRoutes(adminExchangeGroup)
+		sweeperHandler.SweeperRoutes(adminExchangeGroup)
+	}
+
+	adminOnboardingGroup := router.Group("/admin/onboarding")
+	adminOnboardingGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		onboardingHandler.OnboardingRoutes(adminOnboardingGroup)
+	}
+
+	adminAccountGroup := router.Group("/admin/account")
+	adminAccountGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		accountHandler.AdminRoutes(adminAccountGroup)
 	}
 
 	// Swagger Documentation
 	router.GET("/swagger/*any", swagger.WrapHandler(swaggerFiles.Handler))
 
-	if err = router.Run(fmt.Sprintf(":%s", serviceConfig.ServerPort)); err != nil {
-		log.Fatal(err)
+	server := &http.Server{Addr: fmt.Sprintf(":%s", serviceConfig.ServerPort), Handler: router}
+	// Open rate streams would keep the shutdown waiting until its timeout
+	server.RegisterOnShutdown(rateStream.Close)
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-schedulerCtx.Done()
+	log.Println("shutting down")
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	if err = server.Shutdown(shutdownCtx); err != nil {
+		log.Println("server shutdown:", err.Error())
+	}
+
+	// Runs of the background workers in progress are finished so their transactions are not cut off
+	workers := map[string]<-chan struct{}{
+		"rate scheduler":            rateScheduler.Done(),
+		"limit order matcher":       limitOrderMatcher.Done(),
+		"sweeper":                   sweeper.Done(),
+		"conversion plan scheduler": scheduler.Done(),
+		"onboarding expirer":        onboardingExpirer.Done(),
+	}
+	for name, done := range workers {
+		select {
+		case <-done:
+		case <-shutdownCtx.Done():
+			log.Println(name, "did not stop in time")
+		}
 	}
 }
 
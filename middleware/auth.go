@@ -28,28 +28,58 @@ func middlewareError(statusCode int, message string, detail string) helper.Respo
 
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		authenticate(c, c.GetHeader("X-Auth-Token"))
+	}
+}
 
-		if c.GetHeader("X-Auth-Token") == "" {
-			c.AbortWithStatusJSON(http.StatusForbidden, middlewareError(http.StatusForbidden, errors.ErrNotFoundError.Error(), "token not found"))
-			return
+// StreamAuthMiddleware is AuthMiddleware for the rate streams only. Browsers can not set headers on EventSource and
+// WebSocket connections, so the token may be given as query param too. Tokens in urls end up in access logs, no other
+// route accepts them.
+func StreamAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := c.GetHeader("X-Auth-Token")
+		if tokenString == "" {
+			tokenString = c.Query("token")
 		}
 
-		tk := dto.Token{}
-		token, err := jwt.ParseWithClaims(c.GetHeader("X-Auth-Token"), &tk, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("token_password")), nil
-		})
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusForbidden, middlewareError(http.StatusForbidden, errors.ErrExpiredTokenError.Error(), err.Error()))
-			return
-		}
+		authenticate(c, tokenString)
+	}
+}
+
+func authenticate(c *gin.Context, tokenString string) {
+	if tokenString == "" {
+		c.AbortWithStatusJSON(http.StatusForbidden, middlewareError(http.StatusForbidden, errors.ErrNotFoundError.Error(), "token not found"))
+		return
+	}
 
-		if !token.Valid {
-			c.AbortWithStatusJSON(http.StatusForbidden, middlewareError(http.StatusForbidden, errors.ErrInvalidTokenError.Error(), "token is not valid"))
+	tk := dto.Token{}
+	token, err := jwt.ParseWithClaims(tokenString, &tk, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("token_password")), nil
+	})
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusForbidden, middlewareError(http.StatusForbidden, errors.ErrExpiredTokenError.Error(), err.Error()))
+		return
+	}
+
+	if !token.Valid {
+		c.AbortWithStatusJSON(http.StatusForbidden, middlewareError(http.StatusForbidden, errors.ErrInvalidTokenError.Error(), "token is not valid"))
+		return
+	}
+
+	c.Set("user_id", tk.UserId)
+	c.Set("is_admin", tk.IsAdmin)
+
+	c.Next()
+}
+
+// AdminMiddleware has to run after AuthMiddleware, it lets only the tokens of admin users through
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("is_admin") {
+			c.AbortWithStatusJSON(http.StatusForbidden, middlewareError(http.StatusForbidden, errors.ErrForbiddenError.Error(), "admin permission required"))
 			return
 		}
 
-		c.Set("user_id", tk.UserId)
-
 		c.Next()
 	}
 }
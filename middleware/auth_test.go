@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	// Go imports
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// External imports
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	// Internal imports
+	"github.com/mehmetokdemir/currency-conversion-service/dto"
+)
+
+func TestAuthMiddleware_QueryToken(t *testing.T) {
+	t.Setenv("token_password", "secret")
+	tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &dto.Token{UserId: 1}).SignedString([]byte("secret"))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/account/list", AuthMiddleware(), ok)
+	router.GET("/exchange/stream", StreamAuthMiddleware(), ok)
+
+	get := func(path, header string) int {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		if header != "" {
+			req.Header.Set("X-Auth-Token", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("header token", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get("/account/list", tokenString))
+		assert.Equal(t, http.StatusOK, get("/exchange/stream", tokenString))
+	})
+
+	t.Run("query token only on streams", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, get("/account/list?token="+tokenString, ""))
+		assert.Equal(t, http.StatusOK, get("/exchange/stream?token="+tokenString, ""))
+	})
+}